@@ -6,16 +6,23 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/thomas-vilte/matecommit/internal/ai"
-	"github.com/thomas-vilte/matecommit/internal/ai/gemini"
+	// The provider packages register their LLM client with the ai package
+	_ "github.com/thomas-vilte/matecommit/internal/ai/anthropic"
+	_ "github.com/thomas-vilte/matecommit/internal/ai/gemini"
+	_ "github.com/thomas-vilte/matecommit/internal/ai/local"
+	_ "github.com/thomas-vilte/matecommit/internal/ai/openai"
+	"github.com/thomas-vilte/matecommit/internal/cassette"
 	"github.com/thomas-vilte/matecommit/internal/commands/cache"
 	"github.com/thomas-vilte/matecommit/internal/commands/completion"
 	"github.com/thomas-vilte/matecommit/internal/commands/config"
 	"github.com/thomas-vilte/matecommit/internal/commands/handler"
 	"github.com/thomas-vilte/matecommit/internal/commands/issues"
+	"github.com/thomas-vilte/matecommit/internal/commands/prompts"
 	"github.com/thomas-vilte/matecommit/internal/commands/pull_requests"
 	"github.com/thomas-vilte/matecommit/internal/commands/release"
 	"github.com/thomas-vilte/matecommit/internal/commands/stats"
@@ -26,7 +33,10 @@ import (
 	"github.com/thomas-vilte/matecommit/internal/git"
 	"github.com/thomas-vilte/matecommit/internal/i18n"
 	"github.com/thomas-vilte/matecommit/internal/logger"
+	"github.com/thomas-vilte/matecommit/internal/redact"
 	"github.com/thomas-vilte/matecommit/internal/services"
+	"github.com/thomas-vilte/matecommit/internal/services/cost"
+	"github.com/thomas-vilte/matecommit/internal/services/style"
 	"github.com/thomas-vilte/matecommit/internal/tickets"
 	"github.com/thomas-vilte/matecommit/internal/tickets/jira"
 	"github.com/thomas-vilte/matecommit/internal/ui"
@@ -77,8 +87,6 @@ func initializeApp() (*cli.Command, error) {
 	commitHandler := handler.NewSuggestionHandler(gitService, vcsClient, translations)
 	commands := setupCommands(translations, cfgApp, gitService, commitService, prService, issueService, templateService, commitHandler)
 
-	startBackgroundVersionCheck()
-
 	return &cli.Command{
 		Name:                  "matecommit",
 		Usage:                 translations.GetMessage("app_usage", 0, nil),
@@ -96,15 +104,73 @@ func initializeApp() (*cli.Command, error) {
 				Aliases: []string{"v"},
 				Usage:   translations.GetMessage("flags_global.verbose_flag", 0, nil),
 			},
+			&cli.BoolFlag{
+				Name:  "show-redactions",
+				Usage: translations.GetMessage("flags_global.show_redactions_flag", 0, nil),
+			},
+			&cli.BoolFlag{
+				Name:  "stream",
+				Usage: translations.GetMessage("flags_global.stream_flag", 0, nil),
+			},
+			&cli.StringFlag{
+				Name:  "record",
+				Usage: translations.GetMessage("flags_global.record_flag", 0, nil),
+			},
+			&cli.StringFlag{
+				Name:  "replay",
+				Usage: translations.GetMessage("flags_global.replay_flag", 0, nil),
+			},
 		},
 		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
 			logger.Initialize(c.Bool("debug"), c.Bool("verbose"))
+			cassetteActive, err := setupCassette(cfgApp, translations, c.String("record"), c.String("replay"))
+			if err != nil {
+				return ctx, err
+			}
+			// The version check would add unrelated GitHub calls to a cassette, and cached AI
+			// responses would never reach it.
+			if cassetteActive {
+				ctx = ai.WithoutCache(ctx)
+			} else {
+				startBackgroundVersionCheck()
+			}
 			handleVersionNotification(translations)
+			ctx = ai.WithRedactionReporter(ctx, createRedactionReporter(translations, c.Bool("show-redactions")))
+			ctx = ai.WithBudgetReporter(ctx, createBudgetReporter(translations))
+			ctx = ai.WithWorkspace(ctx, currentWorkspace(ctx, gitService))
+			// A live preview only makes sense on a terminal; piped output stays clean.
+			if c.Bool("stream") && ui.IsTerminal(os.Stdout) {
+				ctx = ai.WithStreamSink(ctx, ui.NewStreamPreview())
+			}
 			return ctx, nil
 		},
 	}, nil
 }
 
+// setupCassette sends the HTTP traffic of the run through a cassette when --record or --replay is set,
+// and reports whether it did.
+func setupCassette(cfgApp *cfg.Config, t *i18n.Translations, recordDir, replayDir string) (bool, error) {
+	switch {
+	case recordDir != "" && replayDir != "":
+		return false, domainErrors.NewAppError(domainErrors.TypeConfiguration, t.GetMessage("flags_global.record_replay_conflict", 0, nil), nil)
+	case recordDir != "":
+		recorder, err := cassette.NewRecorder(recordDir, http.DefaultTransport, cfgApp.Redaction)
+		if err != nil {
+			return false, err
+		}
+		cassette.Install(recorder)
+	case replayDir != "":
+		replayer, err := cassette.NewReplayer(replayDir, cfgApp.Redaction)
+		if err != nil {
+			return false, err
+		}
+		cassette.Install(replayer)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
 func checkCompletion() bool {
 	for _, arg := range os.Args {
 		if arg == "completion" || arg == "--generate-shell-completion" {
@@ -121,31 +187,40 @@ func initAIProviders(ctx context.Context, cfgApp *cfg.Config, t *i18n.Translatio
 
 	onConfirmation := createConfirmationCallback(t)
 
-	switch cfgApp.AIConfig.ActiveAI {
-	case "gemini":
-		commitAI, err := gemini.NewGeminiCommitSummarizer(ctx, cfgApp, onConfirmation)
-		if err != nil && !isCompletion {
-			logger.Warn(ctx, "could not create CommitSummarizer", "error", err)
+	client, err := ai.NewLLMClient(ctx, cfgApp, cfgApp.AIConfig.ActiveAI)
+	if err != nil {
+		if !isCompletion {
+			logger.Warn(ctx, "could not create AI client", "provider", cfgApp.AIConfig.ActiveAI, "error", err)
 			logger.Info(ctx, "AI is not configured. You can configure it with 'matecommit config init'")
 		}
+		return nil, nil, nil
+	}
 
-		prAI, err := gemini.NewGeminiPRSummarizer(ctx, cfgApp, onConfirmation)
-		if err != nil && !isCompletion {
-			logger.Debug(ctx, "could not create PRSummarizer", "error", err)
-		}
+	var (
+		commitAI ai.CommitSummarizer
+		prAI     ai.PRSummarizer
+		issueAI  ai.IssueContentGenerator
+	)
 
-		issueAI, err := gemini.NewGeminiIssueContentGenerator(ctx, cfgApp, onConfirmation)
-		if err != nil && !isCompletion {
-			logger.Debug(ctx, "could not create IssueContentGenerator", "error", err)
-		}
+	if commitService, err := ai.NewCommitSummarizerService(client, cfgApp, onConfirmation); err == nil {
+		commitAI = commitService
+	} else if !isCompletion {
+		logger.Warn(ctx, "could not create CommitSummarizer", "error", err)
+	}
 
-		return commitAI, prAI, issueAI
-	default:
-		if !isCompletion {
-			logger.Warn(ctx, "unsupported AI provider", "provider", cfgApp.AIConfig.ActiveAI)
-		}
-		return nil, nil, nil
+	if prService, err := ai.NewPRSummarizerService(client, cfgApp, onConfirmation); err == nil {
+		prAI = prService
+	} else if !isCompletion {
+		logger.Debug(ctx, "could not create PRSummarizer", "error", err)
+	}
+
+	if issueService, err := ai.NewIssueContentService(client, cfgApp, onConfirmation); err == nil {
+		issueAI = issueService
+	} else if !isCompletion {
+		logger.Debug(ctx, "could not create IssueContentGenerator", "error", err)
 	}
+
+	return commitAI, prAI, issueAI
 }
 
 func initVCSClient(ctx context.Context, gitService *git.GitService, cfgApp *cfg.Config, isCompletion bool) vcs.VCSClient {
@@ -175,7 +250,13 @@ func initVCSClient(ctx context.Context, gitService *git.GitService, cfgApp *cfg.
 			}
 			return nil
 		}
-		return github.NewGitHubClient(owner, repo, vcsConfig.Token)
+		client := github.NewGitHubClient(owner, repo, vcsConfig.Token)
+		if matcher, err := gitService.LoadIgnore(ctx); err == nil {
+			client.SetIgnoreMatcher(matcher)
+		} else if !isCompletion {
+			logger.Warn(ctx, "could not load ignore file", "error", err)
+		}
+		return client
 	default:
 		if !isCompletion {
 			logger.Debug(ctx, "unsupported VCS provider", "provider", provider)
@@ -215,6 +296,7 @@ func initServices(cfgApp *cfg.Config, gitService *git.GitService, commitAI ai.Co
 		services.WithTicketManager(ticketMgr),
 		services.WithVCSClient(vcsClient),
 		services.WithConfig(cfgApp),
+		services.WithStyleProfiler(style.NewProfiler(gitService)),
 	)
 
 	templateService := services.NewIssueTemplateService(
@@ -257,6 +339,7 @@ func setupCommands(t *i18n.Translations, cfgApp *cfg.Config, gitService *git.Git
 		completion.NewCompletionCommand(t),
 		stats.NewStatsCommand().CreateCommand(t, cfgApp),
 		cache.NewCacheCommand().CreateCommand(t, cfgApp),
+		prompts.NewPromptsCommand().CreateCommand(t, cfgApp),
 	}
 
 	commands = append(commands, &cli.Command{
@@ -312,6 +395,106 @@ func handleVersionNotification(t *i18n.Translations) {
 	}
 }
 
+// createRedactionReporter prints what was masked in a prompt; with review it lists every finding
+// and asks before sending the prompt.
+func createRedactionReporter(t *i18n.Translations, review bool) ai.RedactionReporter {
+	return func(command string, report redact.Report) bool {
+		ui.SuspendActiveSpinner()
+		defer ui.ResumeSuspendedSpinner()
+
+		counts := report.CountByDetector()
+		detectors := make([]string, 0, len(counts))
+		for name := range counts {
+			detectors = append(detectors, name)
+		}
+		sort.Strings(detectors)
+		summary := make([]string, 0, len(detectors))
+		for _, name := range detectors {
+			summary = append(summary, fmt.Sprintf("%s (%d)", name, counts[name]))
+		}
+
+		fmt.Println()
+		ui.PrintWarning(t.GetMessage("redaction.summary", 0, map[string]interface{}{
+			"Count":     len(report.Findings),
+			"Detectors": strings.Join(summary, ", "),
+		}))
+
+		if !review {
+			fmt.Println(color.HiBlackString(t.GetMessage("redaction.review_hint", 0, nil)))
+			return true
+		}
+
+		for _, finding := range report.Findings {
+			fmt.Println(t.GetMessage("redaction.finding", 0, map[string]interface{}{
+				"Line":     finding.Line,
+				"Detector": finding.Detector,
+				"Preview":  finding.Preview,
+			}))
+		}
+		fmt.Printf("%s ", t.GetMessage("redaction.review_prompt", 0, map[string]interface{}{
+			"Command": command,
+		}))
+
+		var response string
+		_, _ = fmt.Scanln(&response)
+		response = strings.TrimSpace(strings.ToLower(response))
+		return response == "" || response == "y" || response == "yes"
+	}
+}
+
+// currentWorkspace identifies where the run happens, for the history and the repository budget.
+// The repository is owner/name of its remote, or its root directory without one; outside a
+// repository only the working directory is known.
+func currentWorkspace(ctx context.Context, gitService *git.GitService) ai.Workspace {
+	var workspace ai.Workspace
+	if wd, err := os.Getwd(); err == nil {
+		workspace.Workdir = wd
+	}
+
+	if owner, repo, _, err := gitService.GetRepoInfo(ctx); err == nil {
+		workspace.Repo = owner + "/" + repo
+	} else if root, err := gitService.GetRepoRoot(ctx); err == nil {
+		workspace.Repo = root
+	} else {
+		return workspace
+	}
+	if branch, err := gitService.GetCurrentBranch(ctx); err == nil {
+		workspace.Branch = branch
+	}
+	return workspace
+}
+
+// createBudgetReporter lists the budget caps a call would exceed; with the confirm policy it asks
+// before making the call, defaulting to no.
+func createBudgetReporter(t *i18n.Translations) ai.BudgetReporter {
+	return func(command string, exceeded []cost.BudgetCheck, policy string) bool {
+		ui.SuspendActiveSpinner()
+		defer ui.ResumeSuspendedSpinner()
+
+		fmt.Println()
+		ui.PrintWarning(t.GetMessage("cost.budget_over_title", 0, map[string]interface{}{
+			"Command": command,
+		}))
+		for _, check := range exceeded {
+			fmt.Println(t.GetMessage("cost.budget_over_line", 0, map[string]interface{}{
+				"Scope":     t.GetMessage("cost.budget_scope_"+check.Scope, 0, nil),
+				"Spent":     fmt.Sprintf("%.4f", check.Spent),
+				"Estimated": fmt.Sprintf("%.4f", check.Estimated),
+				"Limit":     fmt.Sprintf("%.4f", check.Limit),
+			}))
+		}
+
+		if policy != cfg.BudgetPolicyConfirm {
+			return true
+		}
+		fmt.Printf("%s ", t.GetMessage("cost.budget_over_confirm", 0, nil))
+		var response string
+		_, _ = fmt.Scanln(&response)
+		response = strings.TrimSpace(strings.ToLower(response))
+		return response == "y" || response == "yes" || response == "s" || response == "si"
+	}
+}
+
 func createConfirmationCallback(t *i18n.Translations) ai.ConfirmationCallback {
 	return func(result ai.ConfirmationResult) (string, bool) {
 		ui.SuspendActiveSpinner()
@@ -326,8 +509,11 @@ func createConfirmationCallback(t *i18n.Translations) ai.ConfirmationCallback {
 		_, _ = cyan.Println(t.GetMessage("cost.confirmation_header", 0, nil))
 		_, _ = cyan.Println(t.GetMessage("cost.confirmation_separator", 0, nil))
 
+		rationale := result.Rationale
 		if result.RationaleKey != "" {
-			rationale := t.GetMessage(result.RationaleKey, 0, nil)
+			rationale = t.GetMessage(result.RationaleKey, 0, nil)
+		}
+		if rationale != "" {
 			_, _ = yellow.Println(t.GetMessage("cost.routing_suggestion", 0, map[string]interface{}{
 				"Rationale": rationale,
 			}))
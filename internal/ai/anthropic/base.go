@@ -0,0 +1,106 @@
+package anthropic
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/thomas-vilte/matecommit/internal/ai"
+	"github.com/thomas-vilte/matecommit/internal/config"
+	domainErrors "github.com/thomas-vilte/matecommit/internal/errors"
+)
+
+const (
+	defaultBaseURL     = "https://api.anthropic.com/v1"
+	apiVersion         = "2023-06-01"
+	defaultTemperature = 0.3
+	defaultMaxTokens   = 4096
+	requestTimeout     = 120 * time.Second
+)
+
+var _ ai.LLMClient = (*AnthropicProvider)(nil)
+
+func init() {
+	ai.RegisterClientFactory(string(config.AIAnthropic), func(ctx context.Context, cfg *config.Config) (ai.LLMClient, error) {
+		client, err := NewClient(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	})
+}
+
+// AnthropicProvider is the Anthropic adapter of the ai.LLMClient interface
+type AnthropicProvider struct {
+	apiKey      string
+	baseURL     string
+	httpClient  *http.Client
+	model       string
+	temperature float32
+	maxTokens   int
+}
+
+// NewAnthropicProvider creates a new instance of AnthropicProvider
+func NewAnthropicProvider(apiKey, model string) *AnthropicProvider {
+	return &AnthropicProvider{
+		apiKey:      apiKey,
+		baseURL:     defaultBaseURL,
+		httpClient:  &http.Client{Timeout: requestTimeout},
+		model:       model,
+		temperature: defaultTemperature,
+		maxTokens:   defaultMaxTokens,
+	}
+}
+
+// NewClient builds the provider from the "anthropic" entry of the configuration.
+func NewClient(_ context.Context, cfg *config.Config) (*AnthropicProvider, error) {
+	providerCfg, exists := cfg.AIProviders[string(config.AIAnthropic)]
+	if !exists || providerCfg.APIKey == "" {
+		return nil, domainErrors.ErrAPIKeyMissing
+	}
+
+	modelName := string(cfg.AIConfig.Models[config.AIAnthropic])
+	if modelName == "" {
+		modelName = providerCfg.Model
+	}
+	if modelName == "" {
+		modelName = string(config.DefaultModelForAI(config.AIAnthropic))
+	}
+
+	provider := NewAnthropicProvider(providerCfg.APIKey, modelName)
+	if providerCfg.Temperature > 0 {
+		provider.temperature = providerCfg.Temperature
+	}
+	if providerCfg.MaxTokens > 0 && providerCfg.MaxTokens < defaultMaxTokens {
+		provider.maxTokens = providerCfg.MaxTokens
+	}
+
+	return provider, nil
+}
+
+// CountTokens implements ai.CostAwareAIProvider using the messages/count_tokens endpoint
+func (p *AnthropicProvider) CountTokens(ctx context.Context, prompt string) (int, error) {
+	return p.countTokens(ctx, p.model, prompt)
+}
+
+// GetModelName implements ai.CostAwareAIProvider
+func (p *AnthropicProvider) GetModelName() string {
+	return p.model
+}
+
+// GetProviderName implements ai.CostAwareAIProvider
+func (p *AnthropicProvider) GetProviderName() string {
+	return "anthropic"
+}
+
+// withParams returns a copy of the provider that uses the generation parameters of the request
+func (p *AnthropicProvider) withParams(req ai.LLMRequest) *AnthropicProvider {
+	provider := *p
+	if req.Temperature > 0 {
+		provider.temperature = req.Temperature
+	}
+	if req.MaxTokens > 0 && req.MaxTokens < defaultMaxTokens {
+		provider.maxTokens = req.MaxTokens
+	}
+	return &provider
+}
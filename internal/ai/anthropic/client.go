@@ -0,0 +1,398 @@
+package anthropic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/thomas-vilte/matecommit/internal/ai"
+	domainErrors "github.com/thomas-vilte/matecommit/internal/errors"
+	"github.com/thomas-vilte/matecommit/internal/logger"
+	"github.com/thomas-vilte/matecommit/internal/models"
+)
+
+type (
+	// MessagesRequest is the body sent to the /messages endpoint.
+	MessagesRequest struct {
+		Model       string      `json:"model"`
+		MaxTokens   int         `json:"max_tokens"`
+		Messages    []Message   `json:"messages"`
+		Temperature float32     `json:"temperature,omitempty"`
+		Tools       []Tool      `json:"tools,omitempty"`
+		ToolChoice  *ToolChoice `json:"tool_choice,omitempty"`
+		Stream      bool        `json:"stream,omitempty"`
+	}
+
+	Message struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	}
+
+	// Tool describes the single tool the model is forced to call.
+	// Its input schema is what gives us structured JSON output.
+	Tool struct {
+		Name        string                 `json:"name"`
+		Description string                 `json:"description,omitempty"`
+		InputSchema map[string]interface{} `json:"input_schema"`
+	}
+
+	ToolChoice struct {
+		Type string `json:"type"`
+		Name string `json:"name,omitempty"`
+	}
+
+	// MessagesResponse is the subset of the /messages response used by matecommit.
+	MessagesResponse struct {
+		ID         string         `json:"id"`
+		Model      string         `json:"model"`
+		Content    []ContentBlock `json:"content"`
+		StopReason string         `json:"stop_reason"`
+		Usage      *Usage         `json:"usage,omitempty"`
+	}
+
+	ContentBlock struct {
+		Type  string          `json:"type"`
+		Text  string          `json:"text,omitempty"`
+		Name  string          `json:"name,omitempty"`
+		Input json.RawMessage `json:"input,omitempty"`
+	}
+
+	Usage struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	}
+
+	// streamEvent is one server-sent event of a streamed message.
+	// Only the fields of the events used to rebuild the message are decoded.
+	streamEvent struct {
+		Type         string            `json:"type"`
+		Index        int               `json:"index"`
+		Message      *MessagesResponse `json:"message,omitempty"`
+		ContentBlock *ContentBlock     `json:"content_block,omitempty"`
+		Delta        struct {
+			Type        string `json:"type"`
+			Text        string `json:"text"`
+			PartialJSON string `json:"partial_json"`
+			StopReason  string `json:"stop_reason"`
+		} `json:"delta"`
+		Usage *Usage `json:"usage,omitempty"`
+		Error *struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"error,omitempty"`
+	}
+
+	countTokensRequest struct {
+		Model    string    `json:"model"`
+		Messages []Message `json:"messages"`
+	}
+
+	countTokensResponse struct {
+		InputTokens int `json:"input_tokens"`
+	}
+
+	apiErrorResponse struct {
+		Error struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+)
+
+// Generate implements ai.LLMClient by forcing a tool call whose input is the structured answer
+func (p *AnthropicProvider) Generate(ctx context.Context, req ai.LLMRequest) (*ai.LLMResponse, error) {
+	model := req.Model
+	if model == "" {
+		model = p.model
+	}
+
+	resp, err := p.withParams(req).createMessage(ctx, model, req.Prompt, req.SchemaName, req.Schema.JSONSchema(false))
+	if err != nil {
+		return nil, err
+	}
+	return &ai.LLMResponse{Text: formatResponse(resp), Usage: extractUsage(resp)}, nil
+}
+
+// GenerateStream implements ai.StreamingClient by streaming the message and calling onChunk with every
+// text or tool input delta.
+func (p *AnthropicProvider) GenerateStream(ctx context.Context, req ai.LLMRequest, onChunk func(text string)) (*ai.LLMResponse, error) {
+	model := req.Model
+	if model == "" {
+		model = p.model
+	}
+
+	resp, err := p.withParams(req).streamMessage(ctx, model, req.Prompt, req.SchemaName, req.Schema.JSONSchema(false), onChunk)
+	if err != nil {
+		return nil, err
+	}
+	return &ai.LLMResponse{Text: formatResponse(resp), Usage: extractUsage(resp)}, nil
+}
+
+// createMessage sends a single-turn prompt and forces a tool call whose input matches the given schema.
+func (p *AnthropicProvider) createMessage(ctx context.Context, model, prompt, toolName string, schema map[string]interface{}) (*MessagesResponse, error) {
+	log := logger.FromContext(ctx)
+
+	log.Debug("calling anthropic API",
+		"model", model,
+		"prompt_length", len(prompt))
+
+	body, err := p.post(ctx, "/messages", p.newMessageRequest(model, prompt, toolName, schema))
+	if err != nil {
+		log.Error("anthropic API call failed",
+			"error", err,
+			"model", model)
+		return nil, err
+	}
+
+	var msgResp MessagesResponse
+	if err := json.Unmarshal(body, &msgResp); err != nil {
+		return nil, domainErrors.ErrInvalidAIOutput.WithError(fmt.Errorf("error decoding response: %w", err))
+	}
+
+	log.Debug("anthropic API response received",
+		"content_blocks", len(msgResp.Content),
+		"stop_reason", msgResp.StopReason)
+
+	return &msgResp, nil
+}
+
+// streamMessage is createMessage with a streamed answer.
+// The content block deltas are joined back into a regular message, so formatResponse works on both.
+func (p *AnthropicProvider) streamMessage(ctx context.Context, model, prompt, toolName string, schema map[string]interface{}, onChunk func(text string)) (*MessagesResponse, error) {
+	log := logger.FromContext(ctx)
+
+	reqBody := p.newMessageRequest(model, prompt, toolName, schema)
+	reqBody.Stream = true
+
+	log.Debug("streaming anthropic API",
+		"model", model,
+		"prompt_length", len(prompt))
+
+	resp, err := p.send(ctx, "/messages", reqBody)
+	if err != nil {
+		log.Error("anthropic API call failed",
+			"error", err,
+			"model", model)
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	msgResp := &MessagesResponse{Model: model}
+	inputs := make(map[int]*strings.Builder)
+	err = ai.ReadEventStream(resp.Body, func(data []byte) error {
+		var event streamEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			return domainErrors.ErrInvalidAIOutput.WithError(fmt.Errorf("error decoding stream event: %w", err))
+		}
+
+		switch event.Type {
+		case "message_start":
+			if event.Message != nil {
+				msgResp.ID = event.Message.ID
+				msgResp.Usage = event.Message.Usage
+			}
+		case "content_block_start":
+			if event.ContentBlock == nil {
+				return nil
+			}
+			for len(msgResp.Content) <= event.Index {
+				msgResp.Content = append(msgResp.Content, ContentBlock{})
+			}
+			msgResp.Content[event.Index] = *event.ContentBlock
+			msgResp.Content[event.Index].Input = nil
+			inputs[event.Index] = &strings.Builder{}
+		case "content_block_delta":
+			input, started := inputs[event.Index]
+			if !started {
+				return nil
+			}
+			switch event.Delta.Type {
+			case "text_delta":
+				msgResp.Content[event.Index].Text += event.Delta.Text
+				onChunk(event.Delta.Text)
+			case "input_json_delta":
+				input.WriteString(event.Delta.PartialJSON)
+				onChunk(event.Delta.PartialJSON)
+			}
+		case "message_delta":
+			msgResp.StopReason = event.Delta.StopReason
+			if event.Usage != nil {
+				if msgResp.Usage == nil {
+					msgResp.Usage = &Usage{}
+				}
+				msgResp.Usage.OutputTokens = event.Usage.OutputTokens
+			}
+		case "error":
+			message := "stream error"
+			if event.Error != nil {
+				message = event.Error.Type + ": " + event.Error.Message
+			}
+			return domainErrors.ErrAIGeneration.WithError(fmt.Errorf("anthropic API stream failed: %s", message))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, input := range inputs {
+		if msgResp.Content[i].Type == "tool_use" && input.Len() > 0 {
+			msgResp.Content[i].Input = json.RawMessage(input.String())
+		}
+	}
+
+	log.Debug("anthropic API stream completed",
+		"content_blocks", len(msgResp.Content),
+		"stop_reason", msgResp.StopReason)
+
+	return msgResp, nil
+}
+
+// newMessageRequest builds the body of a single-turn message that forces a tool call when a schema is given.
+func (p *AnthropicProvider) newMessageRequest(model, prompt, toolName string, schema map[string]interface{}) MessagesRequest {
+	reqBody := MessagesRequest{
+		Model:     model,
+		MaxTokens: p.maxTokens,
+		Messages: []Message{
+			{Role: "user", Content: prompt},
+		},
+		Temperature: p.temperature,
+	}
+	if schema != nil {
+		reqBody.Tools = []Tool{{
+			Name:        toolName,
+			Description: "Return the result as structured JSON",
+			InputSchema: schema,
+		}}
+		reqBody.ToolChoice = &ToolChoice{Type: "tool", Name: toolName}
+	}
+	return reqBody
+}
+
+// countTokens asks the API how many input tokens the prompt uses for the given model.
+func (p *AnthropicProvider) countTokens(ctx context.Context, model, prompt string) (int, error) {
+	body, err := p.post(ctx, "/messages/count_tokens", countTokensRequest{
+		Model:    model,
+		Messages: []Message{{Role: "user", Content: prompt}},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var resp countTokensResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return 0, fmt.Errorf("error decoding token count: %w", err)
+	}
+	return resp.InputTokens, nil
+}
+
+func (p *AnthropicProvider) post(ctx context.Context, path string, payload interface{}) ([]byte, error) {
+	resp, err := p.send(ctx, path, payload)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, domainErrors.ErrAIGeneration.WithError(fmt.Errorf("error reading response: %w", err))
+	}
+	return body, nil
+}
+
+// send posts payload to path and returns the response when the API accepted it.
+// The caller closes the body.
+func (p *AnthropicProvider) send(ctx context.Context, path string, payload interface{}) (*http.Response, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, domainErrors.ErrAIGeneration.WithError(fmt.Errorf("error encoding request: %w", err))
+	}
+
+	url := strings.TrimSuffix(p.baseURL, "/") + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, domainErrors.ErrAIGeneration.WithError(fmt.Errorf("error creating request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-api-key", p.apiKey)
+	req.Header.Set("anthropic-version", apiVersion)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, domainErrors.ErrAIGeneration.WithError(err)
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, domainErrors.ErrAIGeneration.WithError(fmt.Errorf("error reading response: %w", err))
+	}
+	return nil, parseAPIError(resp.StatusCode, body)
+}
+
+// parseAPIError maps an Anthropic error response to a domain error.
+func parseAPIError(statusCode int, body []byte) error {
+	var apiErr apiErrorResponse
+	message := strings.TrimSpace(string(body))
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Error.Message != "" {
+		message = apiErr.Error.Message
+	}
+	cause := fmt.Errorf("anthropic API returned status %d: %s", statusCode, message)
+
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return domainErrors.ErrAnthropicAPIKeyInvalid.WithError(cause)
+	case statusCode == http.StatusTooManyRequests || statusCode == 529:
+		return domainErrors.ErrAnthropicQuotaExceeded.WithError(cause)
+	case statusCode >= http.StatusInternalServerError:
+		return domainErrors.ErrAIServerError.
+			WithContext("status", statusCode).
+			WithError(cause)
+	default:
+		return domainErrors.ErrAIGeneration.
+			WithContext("status", statusCode).
+			WithError(cause)
+	}
+}
+
+// extractUsage extracts usage metadata from the Anthropic response
+func extractUsage(resp *MessagesResponse) *models.TokenUsage {
+	if resp == nil || resp.Usage == nil {
+		return nil
+	}
+	return &models.TokenUsage{
+		InputTokens:  resp.Usage.InputTokens,
+		OutputTokens: resp.Usage.OutputTokens,
+		TotalTokens:  resp.Usage.InputTokens + resp.Usage.OutputTokens,
+	}
+}
+
+// formatResponse returns the JSON input of the first tool call, or the text blocks if there is none.
+func formatResponse(resp *MessagesResponse) string {
+	if resp == nil {
+		return ""
+	}
+	var text strings.Builder
+	for _, block := range resp.Content {
+		switch block.Type {
+		case "tool_use":
+			return string(block.Input)
+		case "text":
+			text.WriteString(block.Text)
+		}
+	}
+	return text.String()
+}
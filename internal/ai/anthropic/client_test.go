@@ -0,0 +1,301 @@
+package anthropic
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomas-vilte/matecommit/internal/ai"
+	"github.com/thomas-vilte/matecommit/internal/config"
+	domainErrors "github.com/thomas-vilte/matecommit/internal/errors"
+)
+
+// newMessageResponse builds a response with a single tool call whose input is content.
+func newMessageResponse(content string) *MessagesResponse {
+	return &MessagesResponse{
+		Content: []ContentBlock{
+			{Type: "tool_use", Name: "result", Input: json.RawMessage(content)},
+		},
+		StopReason: "tool_use",
+		Usage:      &Usage{InputTokens: 10, OutputTokens: 5},
+	}
+}
+
+// newCountTokensServer stands in for the token counting endpoint.
+func newCountTokensServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/messages/count_tokens" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(countTokensResponse{InputTokens: 42})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCreateMessage(t *testing.T) {
+	t.Run("forces tool call and decodes response", func(t *testing.T) {
+		// Arrange
+		var received MessagesRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/messages", r.URL.Path)
+			assert.Equal(t, "test-key", r.Header.Get("x-api-key"))
+			assert.Equal(t, apiVersion, r.Header.Get("anthropic-version"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+			_ = json.NewEncoder(w).Encode(newMessageResponse(`{"title":"ok"}`))
+		}))
+		defer server.Close()
+
+		provider := NewAnthropicProvider("test-key", "claude-3-haiku-20240307")
+		provider.baseURL = server.URL
+
+		// Act
+		resp, err := provider.createMessage(context.Background(), "claude-3-haiku-20240307", "hello", "pr_summary", map[string]interface{}{"type": "object"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"title":"ok"}`, formatResponse(resp))
+		assert.Equal(t, "claude-3-haiku-20240307", received.Model)
+		assert.Equal(t, defaultMaxTokens, received.MaxTokens)
+		require.Len(t, received.Tools, 1)
+		assert.Equal(t, "pr_summary", received.Tools[0].Name)
+		require.NotNil(t, received.ToolChoice)
+		assert.Equal(t, "tool", received.ToolChoice.Type)
+		assert.Equal(t, "pr_summary", received.ToolChoice.Name)
+
+		usage := extractUsage(resp)
+		require.NotNil(t, usage)
+		assert.Equal(t, 15, usage.TotalTokens)
+	})
+
+	t.Run("maps API errors to domain errors", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			status int
+			want   *domainErrors.AppError
+		}{
+			{name: "invalid key", status: http.StatusUnauthorized, want: domainErrors.ErrAnthropicAPIKeyInvalid},
+			{name: "rate limit", status: http.StatusTooManyRequests, want: domainErrors.ErrAnthropicQuotaExceeded},
+			{name: "overloaded", status: 529, want: domainErrors.ErrAnthropicQuotaExceeded},
+			{name: "bad request", status: http.StatusBadRequest, want: domainErrors.ErrAIGeneration},
+			{name: "server error", status: http.StatusInternalServerError, want: domainErrors.ErrAIServerError},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Arrange
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(tt.status)
+					_, _ = w.Write([]byte(`{"type":"error","error":{"type":"some_error","message":"failure"}}`))
+				}))
+				defer server.Close()
+
+				provider := NewAnthropicProvider("test-key", "claude-3-haiku-20240307")
+				provider.baseURL = server.URL
+
+				// Act
+				resp, err := provider.createMessage(context.Background(), "claude-3-haiku-20240307", "hello", "", nil)
+
+				// Assert
+				assert.Nil(t, resp)
+				var appErr *domainErrors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.want.Message, appErr.Message)
+			})
+		}
+	})
+}
+
+func TestCountTokens(t *testing.T) {
+	provider := NewAnthropicProvider("test-key", "claude-3-haiku-20240307")
+	provider.baseURL = newCountTokensServer(t).URL
+
+	tokens, err := provider.CountTokens(context.Background(), "hello")
+
+	require.NoError(t, err)
+	assert.Equal(t, 42, tokens)
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("sends the neutral schema as the tool input schema", func(t *testing.T) {
+		// Arrange
+		var received MessagesRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			_ = json.NewEncoder(w).Encode(newMessageResponse(`{"title":"ok"}`))
+		}))
+		defer server.Close()
+
+		provider := NewAnthropicProvider("test-key", "claude-3-haiku-20240307")
+		provider.baseURL = server.URL
+
+		// Act
+		resp, err := provider.Generate(context.Background(), ai.LLMRequest{
+			Prompt:     "hello",
+			SchemaName: "pr_summary",
+			Schema: &ai.Schema{
+				Type:       ai.SchemaObject,
+				Required:   []string{"title"},
+				Properties: map[string]*ai.Schema{"title": {Type: ai.SchemaString}},
+			},
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"title":"ok"}`, resp.Text)
+		assert.Equal(t, 15, resp.Usage.TotalTokens)
+		assert.Equal(t, "claude-3-haiku-20240307", received.Model)
+		require.Len(t, received.Tools, 1)
+		assert.Equal(t, "pr_summary", received.Tools[0].Name)
+		assert.Equal(t, "object", received.Tools[0].InputSchema["type"])
+		assert.Equal(t, []interface{}{"title"}, received.Tools[0].InputSchema["required"])
+	})
+
+	t.Run("request parameters override the provider settings", func(t *testing.T) {
+		// Arrange
+		var received MessagesRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			_ = json.NewEncoder(w).Encode(&MessagesResponse{Content: []ContentBlock{{Type: "text", Text: "ok"}}})
+		}))
+		defer server.Close()
+
+		provider := NewAnthropicProvider("test-key", "claude-3-haiku-20240307")
+		provider.baseURL = server.URL
+
+		// Act
+		_, err := provider.Generate(context.Background(), ai.LLMRequest{Prompt: "hello", Temperature: 0.8, MaxTokens: 1000})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, float32(0.8), received.Temperature)
+		assert.Equal(t, 1000, received.MaxTokens)
+	})
+
+	t.Run("plain text request returns the text blocks", func(t *testing.T) {
+		// Arrange
+		var received MessagesRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			_ = json.NewEncoder(w).Encode(&MessagesResponse{Content: []ContentBlock{{Type: "text", Text: "plain"}}})
+		}))
+		defer server.Close()
+
+		provider := NewAnthropicProvider("test-key", "claude-3-haiku-20240307")
+		provider.baseURL = server.URL
+
+		// Act
+		resp, err := provider.Generate(context.Background(), ai.LLMRequest{Prompt: "hello"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "plain", resp.Text)
+		assert.Empty(t, received.Tools)
+		assert.Nil(t, received.ToolChoice)
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("missing API key", func(t *testing.T) {
+		client, err := NewClient(context.Background(), &config.Config{})
+
+		assert.Nil(t, client)
+		assert.ErrorIs(t, err, domainErrors.ErrAPIKeyMissing)
+	})
+
+	t.Run("max tokens above the API default are capped", func(t *testing.T) {
+		cfg := &config.Config{
+			AIProviders: map[string]config.AIProviderConfig{
+				"anthropic": {APIKey: "key", MaxTokens: 10000},
+			},
+		}
+
+		client, err := NewClient(context.Background(), cfg)
+
+		require.NoError(t, err)
+		assert.Equal(t, defaultMaxTokens, client.maxTokens)
+		assert.Equal(t, string(config.DefaultModelForAI(config.AIAnthropic)), client.GetModelName())
+	})
+}
+
+func TestGenerateStream(t *testing.T) {
+	t.Run("rebuilds the tool input from the stream", func(t *testing.T) {
+		// Arrange
+		var received MessagesRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = io.WriteString(w, `event: message_start
+data: {"type":"message_start","message":{"id":"msg_1","model":"claude-3-haiku-20240307","content":[],"usage":{"input_tokens":10,"output_tokens":1}}}
+
+event: content_block_start
+data: {"type":"content_block_start","index":0,"content_block":{"type":"tool_use","id":"toolu_1","name":"pr_summary","input":{}}}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":0,"delta":{"type":"input_json_delta","partial_json":"{\"title\":"}}
+
+event: content_block_delta
+data: {"type":"content_block_delta","index":0,"delta":{"type":"input_json_delta","partial_json":"\"ok\"}"}}
+
+event: content_block_stop
+data: {"type":"content_block_stop","index":0}
+
+event: message_delta
+data: {"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":5}}
+
+event: message_stop
+data: {"type":"message_stop"}
+
+`)
+		}))
+		defer server.Close()
+
+		provider := NewAnthropicProvider("test-key", "claude-3-haiku-20240307")
+		provider.baseURL = server.URL
+		var chunks []string
+
+		// Act
+		resp, err := provider.GenerateStream(context.Background(), ai.LLMRequest{
+			Prompt:     "hello",
+			SchemaName: "pr_summary",
+			Schema:     &ai.Schema{Type: ai.SchemaObject, Properties: map[string]*ai.Schema{"title": {Type: ai.SchemaString}}},
+		}, func(text string) {
+			chunks = append(chunks, text)
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"title":"ok"}`, resp.Text)
+		assert.Equal(t, []string{`{"title":`, `"ok"}`}, chunks)
+		require.NotNil(t, resp.Usage)
+		assert.Equal(t, 15, resp.Usage.TotalTokens)
+		assert.True(t, received.Stream)
+	})
+
+	t.Run("reports error events", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, "event: error\ndata: {\"type\":\"error\",\"error\":{\"type\":\"overloaded_error\",\"message\":\"Overloaded\"}}\n\n")
+		}))
+		defer server.Close()
+
+		provider := NewAnthropicProvider("test-key", "claude-3-haiku-20240307")
+		provider.baseURL = server.URL
+
+		// Act
+		_, err := provider.GenerateStream(context.Background(), ai.LLMRequest{Prompt: "hello"}, func(string) {})
+
+		// Assert
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domainErrors.ErrAIGeneration)
+		assert.Contains(t, err.Error(), "Overloaded")
+	})
+}
@@ -0,0 +1,107 @@
+package ai
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/thomas-vilte/matecommit/internal/config"
+	"github.com/thomas-vilte/matecommit/internal/errors"
+	"github.com/thomas-vilte/matecommit/internal/services/cost"
+)
+
+// BudgetReporter is told about the caps a call would exceed before it is made. With the confirm
+// policy returning false cancels the call; with warn the answer is ignored.
+type BudgetReporter func(command string, exceeded []cost.BudgetCheck, policy string) (proceed bool)
+
+type budgetReporterKey struct{}
+
+// WithBudgetReporter makes every wrapped call made with ctx report the caps it exceeds to reporter.
+func WithBudgetReporter(ctx context.Context, reporter BudgetReporter) context.Context {
+	return context.WithValue(ctx, budgetReporterKey{}, reporter)
+}
+
+// budgetReporterFromContext returns the reporter set by WithBudgetReporter, or nil.
+func budgetReporterFromContext(ctx context.Context) BudgetReporter {
+	reporter, _ := ctx.Value(budgetReporterKey{}).(BudgetReporter)
+	return reporter
+}
+
+// Workspace is where a call was made, recorded with it to attribute costs
+type Workspace struct {
+	// Repo is owner/name of the repository, or its root directory without a remote; the repository caps use it
+	Repo    string
+	Branch  string
+	Workdir string
+}
+
+type workspaceKey struct{}
+
+// WithWorkspace attributes every wrapped call made with ctx to workspace, for the history and the repository caps.
+func WithWorkspace(ctx context.Context, workspace Workspace) context.Context {
+	return context.WithValue(ctx, workspaceKey{}, workspace)
+}
+
+// workspaceFromContext returns the workspace set by WithWorkspace, or an empty one.
+func workspaceFromContext(ctx context.Context) Workspace {
+	workspace, _ := ctx.Value(workspaceKey{}).(Workspace)
+	return workspace
+}
+
+// budgetLimits returns the caps configured for a wrapped command
+func budgetLimits(cfg *config.Config, command string) cost.BudgetLimits {
+	if cfg == nil {
+		return cost.BudgetLimits{}
+	}
+	budget := cfg.AIConfig.Budget
+	return cost.BudgetLimits{
+		Monthly:      budget.Monthly,
+		CommandDaily: budget.Commands[commandKey(command)],
+		RepoDaily:    budget.RepoDaily,
+		RepoMonthly:  budget.RepoMonthly,
+	}
+}
+
+// enforceBudget applies the budget policy to a call of the given estimated cost.
+// It returns ErrBudgetExceeded, wrapping the first exceeded cap, when the call must not be made.
+func (w *CostAwareWrapper) enforceBudget(ctx context.Context, command string, estimatedCost float64) error {
+	exceeded, err := w.manager.CheckLimits(budgetLimits(w.appConfig, command), command, workspaceFromContext(ctx).Repo, estimatedCost)
+	if err != nil {
+		return err
+	}
+	if len(exceeded) == 0 {
+		return nil
+	}
+
+	policy := config.BudgetPolicyBlock
+	if w.appConfig != nil {
+		policy = w.appConfig.AIConfig.Budget.EffectivePolicy()
+	}
+	first := exceeded[0]
+	slog.Warn("AI budget exceeded",
+		"command", command,
+		"scope", first.Scope,
+		"limit", first.Limit,
+		"spent", first.Spent,
+		"estimated_cost", estimatedCost,
+		"policy", policy)
+
+	reporter := budgetReporterFromContext(ctx)
+	switch policy {
+	case config.BudgetPolicyWarn:
+		if reporter != nil {
+			reporter(command, exceeded, policy)
+		}
+		return nil
+	case config.BudgetPolicyConfirm:
+		if reporter != nil && reporter(command, exceeded, policy) {
+			return nil
+		}
+	}
+
+	return errors.ErrBudgetExceeded.WithError(&errors.BudgetExceededError{
+		Scope:     first.Scope,
+		Limit:     first.Limit,
+		Spent:     first.Spent,
+		Estimated: first.Estimated,
+	})
+}
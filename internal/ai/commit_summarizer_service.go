@@ -0,0 +1,218 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/thomas-vilte/matecommit/internal/config"
+	domainErrors "github.com/thomas-vilte/matecommit/internal/errors"
+	"github.com/thomas-vilte/matecommit/internal/logger"
+	"github.com/thomas-vilte/matecommit/internal/models"
+)
+
+var _ CommitSummarizer = (*CommitSummarizerService)(nil)
+
+// CommitSummarizerService generates commit suggestions with any LLM client.
+type CommitSummarizerService struct {
+	client     LLMClient
+	wrapper    *CostAwareWrapper
+	generateFn GenerateFunc
+	reducer    *diffReducer
+	config     *config.Config
+}
+
+type (
+	commitSuggestionsJSON struct {
+		Suggestions []CommitSuggestionJSON `json:"suggestions"`
+	}
+
+	CommitSuggestionJSON struct {
+		Title        string            `json:"title"`
+		Desc         string            `json:"desc"`
+		Files        []string          `json:"files"`
+		Analysis     *CodeAnalysisJSON `json:"analysis,omitempty"`
+		Requirements *RequirementsJSON `json:"requirements,omitempty"`
+	}
+
+	CodeAnalysisJSON struct {
+		OverView string `json:"overview"`
+		Purpose  string `json:"purpose"`
+		Impact   string `json:"impact"`
+	}
+
+	RequirementsJSON struct {
+		Status      string   `json:"status"`
+		Missing     []string `json:"missing"`
+		Suggestions []string `json:"suggestions"`
+	}
+)
+
+// NewCommitSummarizerService creates the commit summarizer on top of client.
+func NewCommitSummarizerService(client LLMClient, cfg *config.Config, onConfirmation ConfirmationCallback) (*CommitSummarizerService, error) {
+	wrapper, err := newServiceWrapper(client, cfg, 800, onConfirmation)
+	if err != nil {
+		return nil, err
+	}
+
+	reducer, err := newDiffReducer(client, cfg, "suggest-commits")
+	if err != nil {
+		return nil, err
+	}
+
+	return &CommitSummarizerService{
+		client:     client,
+		wrapper:    wrapper,
+		generateFn: commandGenerateFunc(client, cfg, "suggest-commits"),
+		reducer:    reducer,
+		config:     cfg,
+	}, nil
+}
+
+// newServiceWrapper creates the cost-aware wrapper shared by the feature services.
+func newServiceWrapper(client LLMClient, cfg *config.Config, estimatedOutputTokens int, onConfirmation ConfirmationCallback) (*CostAwareWrapper, error) {
+	budgetDaily := 0.0
+	if cfg.AIConfig.BudgetDaily != nil {
+		budgetDaily = *cfg.AIConfig.BudgetDaily
+	}
+
+	wrapper, err := NewCostAwareWrapper(WrapperConfig{
+		Provider:              client,
+		BudgetDaily:           budgetDaily,
+		EstimatedOutputTokens: estimatedOutputTokens,
+		OnConfirmation:        onConfirmation,
+		Config:                cfg,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating wrapper: %w", err)
+	}
+	return wrapper, nil
+}
+
+func (s *CommitSummarizerService) GenerateSuggestions(ctx context.Context, info models.CommitInfo, count int) ([]models.CommitSuggestion, error) {
+	log := logger.FromContext(ctx)
+
+	log.Info("generating commit suggestions",
+		"provider", s.client.GetProviderName(),
+		"count", count,
+		"files", len(info.Files),
+		"has_issue_info", info.IssueInfo != nil,
+		"has_ticket_info", info.TicketInfo != nil)
+
+	if count <= 0 {
+		return nil, domainErrors.NewAppError(domainErrors.TypeInternal, "invalid suggestion count", nil)
+	}
+
+	if len(info.Files) == 0 {
+		return nil, domainErrors.NewAppError(domainErrors.TypeGit, "no files to summarize", nil)
+	}
+
+	diff, err := s.reducer.Reduce(ctx, info.Diff)
+	if err != nil {
+		log.Error("failed to reduce diff",
+			"error", err)
+		return nil, err
+	}
+	info.Diff = diff
+
+	prompt := s.generatePrompt(s.config.Language, info, count)
+
+	log.Debug("calling AI for commit suggestions",
+		"prompt_length", len(prompt),
+		"language", s.config.Language)
+
+	resp, usage, err := s.wrapper.WrapGenerate(withFileCount(ctx, len(info.Files)), "suggest-commits", prompt, s.generateFn)
+	if err != nil {
+		log.Error("failed to generate suggestions",
+			"error", err)
+		return nil, err
+	}
+
+	responseText := extractResponseText(resp)
+	if responseText == "" {
+		return nil, domainErrors.ErrInvalidAIOutput.
+			WithContext("reason", "empty response from AI").
+			WithContext("operation", "generate commit suggestions")
+	}
+
+	suggestions, err := s.parseSuggestionsJSON(responseText)
+	if err != nil {
+		respLen := len(responseText)
+		preview := responseText
+		if respLen > 500 {
+			preview = responseText[:500] + "..."
+		}
+		return nil, domainErrors.ErrInvalidAIOutput.
+			WithContext("reason", "failed to parse JSON").
+			WithContext("response_length", respLen).
+			WithContext("preview", preview).
+			WithError(err)
+	}
+	if len(suggestions) == 0 {
+		log.Warn("AI generated no suggestions")
+		return nil, domainErrors.ErrInvalidAIOutput.
+			WithContext("reason", "AI generated no suggestions")
+	}
+	for i := range suggestions {
+		suggestions[i].Usage = usage
+	}
+	if info.IssueInfo != nil && info.IssueInfo.Number > 0 {
+		log.Debug("ensuring issue reference in suggestions",
+			"issue_number", info.IssueInfo.Number)
+		suggestions = EnsureIssueReference(suggestions, info.IssueInfo.Number)
+	}
+
+	log.Info("commit suggestions generated successfully",
+		"count", len(suggestions))
+
+	return suggestions, nil
+}
+
+func (s *CommitSummarizerService) parseSuggestionsJSON(responseText string) ([]models.CommitSuggestion, error) {
+	if responseText == "" {
+		return nil, fmt.Errorf("empty response text from AI")
+	}
+	jsonSuggestions, err := decodeSuggestions(responseText)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing JSON: %w", err)
+	}
+	suggestions := make([]models.CommitSuggestion, 0, len(jsonSuggestions))
+	for _, js := range jsonSuggestions {
+		suggestion := models.CommitSuggestion{
+			CommitTitle: js.Title,
+			Explanation: js.Desc,
+			Files:       js.Files,
+		}
+		if js.Analysis != nil {
+			suggestion.CodeAnalysis = models.CodeAnalysis{
+				ChangesOverview: js.Analysis.OverView,
+				PrimaryPurpose:  js.Analysis.Purpose,
+				TechnicalImpact: js.Analysis.Impact,
+			}
+		}
+		if js.Requirements != nil {
+			suggestion.RequirementsAnalysis = models.RequirementsAnalysis{
+				CriteriaStatus:         models.CriteriaStatus(js.Requirements.Status),
+				MissingCriteria:        js.Requirements.Missing,
+				ImprovementSuggestions: js.Requirements.Suggestions,
+			}
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions, nil
+}
+
+func (s *CommitSummarizerService) generatePrompt(locale string, info models.CommitInfo, count int) string {
+	return BuildCommitPrompt(locale, info, count)
+}
+
+// decodeSuggestions accepts both the {"suggestions": [...]} object of the schema and a bare array,
+// which some models still answer with.
+func decodeSuggestions(text string) ([]CommitSuggestionJSON, error) {
+	trimmed := strings.TrimSpace(text)
+	if strings.HasPrefix(trimmed, "[") {
+		return decodeJSON[[]CommitSuggestionJSON](trimmed)
+	}
+	envelope, err := decodeJSON[commitSuggestionsJSON](trimmed)
+	return envelope.Suggestions, err
+}
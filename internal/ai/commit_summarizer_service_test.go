@@ -1,4 +1,4 @@
-package gemini
+package ai
 
 import (
 	"context"
@@ -9,7 +9,6 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/thomas-vilte/matecommit/internal/config"
 	"github.com/thomas-vilte/matecommit/internal/models"
-	"google.golang.org/genai"
 )
 
 const (
@@ -41,32 +40,19 @@ const (
 ]`
 )
 
-func TestGeminiCommitSummarizer(t *testing.T) {
-	t.Run("NewGeminiCommitSummarizer with empty API key", func(t *testing.T) {
-		// arrange
-		ctx := context.Background()
-		cfg := &config.Config{
-			AIProviders: map[string]config.AIProviderConfig{},
-		}
-
-		// act
-		service, err := NewGeminiCommitSummarizer(ctx, cfg, nil)
-
-		// assert
-		assert.Nil(t, service)
-		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "API key is missing")
-	})
+// newFakeClient returns an LLM client that is never reached by the tests that use it.
+func newFakeClient() *fakeLLMClient {
+	return &fakeLLMClient{provider: "fake", model: "fake-model"}
+}
 
+func TestCommitSummarizerService(t *testing.T) {
 	t.Run("GenerateSuggestions with invalid count", func(t *testing.T) {
 		// arrange
 		ctx := context.Background()
-		cfg := &config.Config{
-			AIProviders: map[string]config.AIProviderConfig{"gemini": {APIKey: "test-api-key", Model: "gemini-2.5-flash", Temperature: 0.3, MaxTokens: 10000}},
-		}
+		cfg := &config.Config{}
 
 		// act
-		service, err := NewGeminiCommitSummarizer(ctx, cfg, nil)
+		service, err := NewCommitSummarizerService(newFakeClient(), cfg, nil)
 		if err != nil {
 			t.Fatalf("Error creando servicio: %v", err)
 		}
@@ -88,12 +74,10 @@ func TestGeminiCommitSummarizer(t *testing.T) {
 	t.Run("GenerateSuggestions no files", func(t *testing.T) {
 		// arrange
 		ctx := context.Background()
-		cfg := &config.Config{
-			AIProviders: map[string]config.AIProviderConfig{"gemini": {APIKey: "test-api-key", Model: "gemini-2.5-flash", Temperature: 0.3, MaxTokens: 10000}},
-		}
+		cfg := &config.Config{}
 
 		// act
-		service, err := NewGeminiCommitSummarizer(ctx, cfg, nil)
+		service, err := NewCommitSummarizerService(newFakeClient(), cfg, nil)
 		if err != nil {
 			t.Fatalf("Error creando servicio: %v", err)
 		}
@@ -114,29 +98,14 @@ func TestGeminiCommitSummarizer(t *testing.T) {
 
 	t.Run("ParseSuggestionsJSON correct format", func(t *testing.T) {
 		// arrange
-		ctx := context.Background()
-		cfg := &config.Config{
-			AIProviders: map[string]config.AIProviderConfig{"gemini": {APIKey: "test-api-key", Model: "gemini-2.5-flash", Temperature: 0.3, MaxTokens: 10000}},
-		}
+		cfg := &config.Config{}
 
 		// act
-		service, err := NewGeminiCommitSummarizer(ctx, cfg, nil)
+		service, err := NewCommitSummarizerService(newFakeClient(), cfg, nil)
 		assert.NoError(t, err)
 
-		resp := &genai.GenerateContentResponse{
-			Candidates: []*genai.Candidate{
-				{
-					Content: &genai.Content{
-						Parts: []*genai.Part{
-							{Text: responseJSON},
-						},
-					},
-				},
-			},
-		}
-
 		// act
-		suggestions, err := service.parseSuggestionsJSON(formatResponse(resp))
+		suggestions, err := service.parseSuggestionsJSON(responseJSON)
 
 		// assert
 		assert.NoError(t, err)
@@ -159,41 +128,15 @@ func TestGeminiCommitSummarizer(t *testing.T) {
 		}
 	})
 
-	t.Run("formatResponse", func(t *testing.T) {
-		// Arrange
-		resp := &genai.GenerateContentResponse{
-			Candidates: []*genai.Candidate{
-				{
-					Content: &genai.Content{
-						Parts: []*genai.Part{
-							{Text: "test content"},
-						},
-					},
-				},
-			},
-		}
-
-		// Act
-		result := formatResponse(resp)
-
-		// Assert
-		expected := "test content"
-		if result != expected {
-			t.Errorf("formatResponse incorrecto. Esperado: %s, Obtenido: %s", expected, result)
-		}
-	})
-
 	t.Run("generatePrompt with valid parameters", func(t *testing.T) {
 		// arrange
-		ctx := context.Background()
 		cfg := &config.Config{
-			Language:    "es",
-			UseEmoji:    true,
-			AIProviders: map[string]config.AIProviderConfig{"gemini": {APIKey: "test-api-key", Model: "gemini-2.5-flash", Temperature: 0.3, MaxTokens: 10000}},
+			Language: "es",
+			UseEmoji: true,
 		}
 
 		// act
-		service, err := NewGeminiCommitSummarizer(ctx, cfg, nil)
+		service, err := NewCommitSummarizerService(newFakeClient(), cfg, nil)
 		assert.NoError(t, err)
 
 		info := models.CommitInfo{
@@ -214,15 +157,13 @@ func TestGeminiCommitSummarizer(t *testing.T) {
 
 	t.Run("generatePrompt with en locale", func(t *testing.T) {
 		// arrange
-		ctx := context.Background()
 		cfg := &config.Config{
-			Language:    "en",
-			UseEmoji:    true,
-			AIProviders: map[string]config.AIProviderConfig{"gemini": {APIKey: "test-api-key", Model: "gemini-2.5-flash", Temperature: 0.3, MaxTokens: 10000}},
+			Language: "en",
+			UseEmoji: true,
 		}
 
 		// act
-		service, err := NewGeminiCommitSummarizer(ctx, cfg, nil)
+		service, err := NewCommitSummarizerService(newFakeClient(), cfg, nil)
 		assert.NoError(t, err)
 
 		info := models.CommitInfo{
@@ -243,15 +184,13 @@ func TestGeminiCommitSummarizer(t *testing.T) {
 
 	t.Run("generatePrompt with en locale", func(t *testing.T) {
 		// arrange
-		ctx := context.Background()
 		cfg := &config.Config{
-			Language:    "en",
-			UseEmoji:    true,
-			AIProviders: map[string]config.AIProviderConfig{"gemini": {APIKey: "test-api-key", Model: "gemini-2.5-flash", Temperature: 0.3, MaxTokens: 10000}},
+			Language: "en",
+			UseEmoji: true,
 		}
 
 		// act
-		service, err := NewGeminiCommitSummarizer(ctx, cfg, nil)
+		service, err := NewCommitSummarizerService(newFakeClient(), cfg, nil)
 		assert.NoError(t, err)
 
 		info := models.CommitInfo{
@@ -271,29 +210,12 @@ func TestGeminiCommitSummarizer(t *testing.T) {
 		assert.Contains(t, prompt, "technical analysis", "Should include the technical analysis section")
 	})
 
-	t.Run("parseSuggestionsJSON with nil response", func(t *testing.T) {
-		// arrange
-		service := &GeminiCommitSummarizer{}
-		resp := (*genai.GenerateContentResponse)(nil)
-
-		// act
-		suggestions, err := service.parseSuggestionsJSON(formatResponse(resp))
-
-		// assert
-		assert.Error(t, err)
-		assert.Nil(t, suggestions)
-		assert.Contains(t, err.Error(), "empty response text from AI")
-	})
-
-	t.Run("parseSuggestionsJSON with empty candidates", func(t *testing.T) {
+	t.Run("parseSuggestionsJSON with empty response", func(t *testing.T) {
 		// arrange
-		service := &GeminiCommitSummarizer{}
-		resp := &genai.GenerateContentResponse{
-			Candidates: []*genai.Candidate{},
-		}
+		service := &CommitSummarizerService{}
 
 		// act
-		suggestions, err := service.parseSuggestionsJSON(formatResponse(resp))
+		suggestions, err := service.parseSuggestionsJSON("")
 
 		// assert
 		assert.Error(t, err)
@@ -303,21 +225,12 @@ func TestGeminiCommitSummarizer(t *testing.T) {
 
 	t.Run("parseSuggestionsJSON with invalid JSON", func(t *testing.T) {
 		// arrange
-		ctx := context.Background()
-		cfg := &config.Config{
-			AIProviders: map[string]config.AIProviderConfig{"gemini": {APIKey: "test-api-key", Model: "gemini-2.5-flash", Temperature: 0.3, MaxTokens: 10000}},
-		}
+		cfg := &config.Config{}
 		// act
-		service, _ := NewGeminiCommitSummarizer(ctx, cfg, nil)
-
-		resp := &genai.GenerateContentResponse{
-			Candidates: []*genai.Candidate{
-				{Content: &genai.Content{Parts: []*genai.Part{{Text: "invalid json"}}}},
-			},
-		}
+		service, _ := NewCommitSummarizerService(newFakeClient(), cfg, nil)
 
 		// act
-		suggestions, err := service.parseSuggestionsJSON(formatResponse(resp))
+		suggestions, err := service.parseSuggestionsJSON("invalid json")
 
 		// assert
 		assert.Error(t, err)
@@ -327,10 +240,9 @@ func TestGeminiCommitSummarizer(t *testing.T) {
 
 	t.Run("parseSuggestionsJSON status passthrough", func(t *testing.T) {
 		// arrange
-		ctx := context.Background()
-		cfg := &config.Config{AIProviders: map[string]config.AIProviderConfig{"gemini": {APIKey: "test-api-key", Model: "gemini-2.5-flash", Temperature: 0.3, MaxTokens: 10000}}}
+		cfg := &config.Config{}
 		// act
-		service, _ := NewGeminiCommitSummarizer(ctx, cfg, nil)
+		service, _ := NewCommitSummarizerService(newFakeClient(), cfg, nil)
 
 		testCases := []struct {
 			inputStatus    string
@@ -354,14 +266,8 @@ func TestGeminiCommitSummarizer(t *testing.T) {
 				}
 			}]`, tc.inputStatus)
 
-			resp := &genai.GenerateContentResponse{
-				Candidates: []*genai.Candidate{
-					{Content: &genai.Content{Parts: []*genai.Part{{Text: jsonStr}}}},
-				},
-			}
-
 			// act
-			suggestions, err := service.parseSuggestionsJSON(formatResponse(resp))
+			suggestions, err := service.parseSuggestionsJSON(jsonStr)
 
 			// assert
 			assert.NoError(t, err)
@@ -370,8 +276,7 @@ func TestGeminiCommitSummarizer(t *testing.T) {
 		}
 	})
 
-	t.Run("ensureIssueReference", func(t *testing.T) {
-		service := &GeminiCommitSummarizer{}
+	t.Run("EnsureIssueReference", func(t *testing.T) {
 		issueNum := 123
 		suggestions := []models.CommitSuggestion{
 			{CommitTitle: "feat: something"},
@@ -380,7 +285,7 @@ func TestGeminiCommitSummarizer(t *testing.T) {
 			{CommitTitle: "refactor: code fixes #123"},
 		}
 
-		result := service.ensureIssueReference(suggestions, issueNum)
+		result := EnsureIssueReference(suggestions, issueNum)
 
 		assert.Equal(t, "feat: something (#123)", result[0].CommitTitle)
 		assert.Equal(t, "fix: bug (#123)", result[1].CommitTitle)
@@ -399,9 +304,7 @@ func TestGenerateSuggestions_HappyPath(t *testing.T) {
 	}()
 	oldHome := os.Getenv("HOME")
 	cfg := &config.Config{
-		AIProviders: map[string]config.AIProviderConfig{"gemini": {APIKey: "test"}},
-		AIConfig:    config.AIConfig{Models: map[config.AI]config.Model{config.AIGemini: "gemini-pro"}},
-		Language:    "en",
+		Language: "en",
 	}
 	_ = os.Setenv("HOME", tmpHome)
 	defer func() {
@@ -412,16 +315,12 @@ func TestGenerateSuggestions_HappyPath(t *testing.T) {
 
 	ctx := context.Background()
 	// act
-	service, _ := NewGeminiCommitSummarizer(ctx, cfg, nil)
+	service, _ := NewCommitSummarizerService(newFakeClient(), cfg, nil)
 	service.wrapper.SetSkipConfirmation(true)
 
 	t.Run("successful suggestions generation", func(t *testing.T) {
 		service.generateFn = func(ctx context.Context, mName string, p string) (interface{}, *models.TokenUsage, error) {
-			return &genai.GenerateContentResponse{
-				Candidates: []*genai.Candidate{
-					{Content: &genai.Content{Parts: []*genai.Part{{Text: responseJSON}}}},
-				},
-			}, &models.TokenUsage{TotalTokens: 200}, nil
+			return responseJSON, &models.TokenUsage{TotalTokens: 200}, nil
 		}
 
 		info := models.CommitInfo{
@@ -438,10 +337,8 @@ func TestGenerateSuggestions_HappyPath(t *testing.T) {
 }
 
 func TestGeneratePrompt_WithCriteria(t *testing.T) {
-	cfg := &config.Config{
-		AIProviders: map[string]config.AIProviderConfig{"gemini": {APIKey: "test"}},
-	}
-	service := &GeminiCommitSummarizer{config: cfg}
+	cfg := &config.Config{}
+	service := &CommitSummarizerService{config: cfg}
 
 	t.Run("formats criteria correctly in English", func(t *testing.T) {
 		info := models.CommitInfo{
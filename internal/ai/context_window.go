@@ -0,0 +1,23 @@
+package ai
+
+import "github.com/thomas-vilte/matecommit/internal/config"
+
+// defaultContextWindow is assumed for providers and models missing from the registry
+const defaultContextWindow = config.DefaultContextWindow
+
+// ContextWindow returns the context window, in tokens, of a provider model as listed in the model registry.
+// Dated and tagged versions (claude-3-haiku-20240307, qwen2.5-coder:7b) share the entry of their family,
+// and models the registry does not list get the defaults of their provider.
+func ContextWindow(provider, model string) int {
+	window := config.DefaultModelRegistry().Capabilities(config.AI(provider), model).ContextWindow
+	if window <= 0 {
+		return defaultContextWindow
+	}
+	return window
+}
+
+// DiffTokenBudget returns how many tokens of diff a prompt for the model may carry.
+// Half of the window is left for the instructions, the rest of the context and the answer.
+func DiffTokenBudget(provider, model string) int {
+	return ContextWindow(provider, model) / 2
+}
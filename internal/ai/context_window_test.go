@@ -0,0 +1,33 @@
+package ai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextWindow(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		model    string
+		want     int
+	}{
+		{name: "exact model", provider: "gemini", model: "gemini-1.5-pro", want: 2_097_152},
+		{name: "dated model version", provider: "anthropic", model: "claude-3-haiku-20240307", want: 200_000},
+		{name: "tagged local model", provider: "local", model: "qwen2.5-coder:7b", want: 32_768},
+		{name: "unknown model of a known provider", provider: "openai", model: "gpt-5", want: 128_000},
+		{name: "unknown provider", provider: "acme", model: "acme-1", want: defaultContextWindow},
+		{name: "case insensitive", provider: "OpenAI", model: "GPT-4o", want: 128_000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ContextWindow(tt.provider, tt.model))
+		})
+	}
+}
+
+func TestDiffTokenBudget(t *testing.T) {
+	assert.Equal(t, 4_096, DiffTokenBudget("local", "llama3.1"))
+}
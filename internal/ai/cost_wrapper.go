@@ -9,10 +9,13 @@ import (
 	"time"
 
 	"github.com/thomas-vilte/matecommit/internal/cache"
+	"github.com/thomas-vilte/matecommit/internal/config"
 	"github.com/thomas-vilte/matecommit/internal/errors"
 	"github.com/thomas-vilte/matecommit/internal/models"
+	"github.com/thomas-vilte/matecommit/internal/redact"
 	"github.com/thomas-vilte/matecommit/internal/services/cost"
 	"github.com/thomas-vilte/matecommit/internal/services/routing"
+	"github.com/thomas-vilte/matecommit/internal/tokens"
 )
 
 type ConfirmationCallback func(result ConfirmationResult) (choice string, proceed bool)
@@ -25,7 +28,10 @@ type ConfirmationResult struct {
 	OutputTokens   int
 	SuggestedModel string
 	CurrentModel   string
-	RationaleKey   string
+	// RationaleKey is the translation key explaining a built-in routing suggestion
+	RationaleKey string
+	// Rationale is the explanation written in a configured routing rule
+	Rationale string
 }
 
 type CostAwareWrapper struct {
@@ -34,9 +40,11 @@ type CostAwareWrapper struct {
 	manager               *cost.Manager
 	cache                 *cache.Cache
 	modelSelector         *routing.ModelSelector
+	redactor              *redact.Redactor
 	estimatedOutputTokens int
 	skipConfirmation      bool
 	onConfirmation        ConfirmationCallback
+	appConfig             *config.Config
 }
 
 type WrapperConfig struct {
@@ -45,6 +53,10 @@ type WrapperConfig struct {
 	EstimatedOutputTokens int
 	SkipConfirmation      bool
 	OnConfirmation        ConfirmationCallback
+	// Config is used to resolve the fallback chain (AIConfig.Fallback), the per-command settings
+	// (AIConfig.Commands), the routing rules (AIConfig.Routing) and the redaction patterns (Redaction);
+	// nil disables the first two and uses the built-in routing rules and detectors
+	Config *config.Config
 }
 
 // NewCostAwareWrapper creates a provider-agnostic wrapper
@@ -59,18 +71,39 @@ func NewCostAwareWrapper(cfg WrapperConfig) (*CostAwareWrapper, error) {
 		return nil, fmt.Errorf("error creating cache: %w", err)
 	}
 
+	redactor, err := redact.New(redactionConfig(cfg.Config))
+	if err != nil {
+		return nil, fmt.Errorf("error creating redactor: %w", err)
+	}
+
 	return &CostAwareWrapper{
 		provider:              cfg.Provider,
 		calculator:            cost.NewCalculator(),
 		manager:               manager,
 		cache:                 cacheService,
-		modelSelector:         routing.NewModelSelector(),
+		modelSelector:         routing.NewModelSelector(routingRules(cfg.Config)),
+		redactor:              redactor,
 		estimatedOutputTokens: cfg.EstimatedOutputTokens,
 		skipConfirmation:      cfg.SkipConfirmation,
 		onConfirmation:        cfg.OnConfirmation,
+		appConfig:             cfg.Config,
 	}, nil
 }
 
+type noCacheKey struct{}
+
+// WithoutCache makes every wrapped call made with ctx skip the response cache in both directions,
+// so each call reaches the provider and its answer is not stored.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+// cacheDisabled reports whether ctx was created by WithoutCache.
+func cacheDisabled(ctx context.Context) bool {
+	disabled, _ := ctx.Value(noCacheKey{}).(bool)
+	return disabled
+}
+
 // SetSkipConfirmation allows enabling or disabling manual user confirmation.
 func (w *CostAwareWrapper) SetSkipConfirmation(skip bool) {
 	w.skipConfirmation = skip
@@ -85,8 +118,19 @@ func (w *CostAwareWrapper) WrapGenerate(
 ) (interface{}, *models.TokenUsage, error) {
 	startTime := time.Now()
 
+	// Secrets are masked before anything else, so they reach neither the provider nor the cache
+	prompt, err := w.redactPrompt(ctx, command, prompt)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	providerName := w.provider.GetProviderName()
 	originalModel := w.provider.GetModelName()
+	// A model configured for the command replaces the provider default and skips routing
+	commandModel := string(commandSettings(w.appConfig, command).Model)
+	if commandModel != "" {
+		originalModel = commandModel
+	}
 	modelToUse := originalModel
 
 	contentHash := w.cache.GenerateHash(providerName + originalModel + prompt)
@@ -95,9 +139,12 @@ func (w *CostAwareWrapper) WrapGenerate(
 		"command", command,
 		"cache_key_hash", contentHash)
 
-	if cachedData, hit, err := w.cache.Get(contentHash); err == nil && hit {
-		var cachedResp interface{}
-		if err := json.Unmarshal(cachedData, &cachedResp); err == nil {
+	useCache := !cacheDisabled(ctx)
+	if cachedData, hit, err := w.cache.Get(contentHash); useCache && err == nil && hit {
+		// Responses are cached as raw text; entries of any other shape are treated as a miss,
+		// and so are entries that no longer pass the schema of the command
+		var cachedResp string
+		if err := json.Unmarshal(cachedData, &cachedResp); err == nil && w.isValidCached(command, cachedResp) {
 			slog.Info("cache hit",
 				"command", command,
 				"cache_key_hash", contentHash)
@@ -107,7 +154,9 @@ func (w *CostAwareWrapper) WrapGenerate(
 				CostUSD:    0,
 				DurationMs: time.Since(startTime).Milliseconds(),
 				Model:      originalModel,
+				Provider:   providerName,
 			}
+			w.recordCacheHit(ctx, command, contentHash, usage)
 			return cachedResp, usage, nil
 		}
 	}
@@ -115,58 +164,52 @@ func (w *CostAwareWrapper) WrapGenerate(
 	slog.Debug("cache miss, generating new content",
 		"command", command)
 
-	var inputTokens int
-	tokens, err := w.provider.CountTokens(ctx, prompt)
-	if err == nil {
-		inputTokens = tokens
-	} else {
-		inputTokens = len(prompt) / 4
-
-		msg := "failed to count tokens via API, using local estimation"
-		errStr := err.Error()
-
-		if strings.Contains(errStr, "not supported") || strings.Contains(errStr, "not found") {
-			slog.Debug(msg,
-				"provider", w.provider.GetProviderName(),
-				"model", w.provider.GetModelName(),
-				"reason", "model_not_supported_or_found")
-		} else {
-			slog.Warn(msg,
-				"provider", w.provider.GetProviderName(),
-				"model", w.provider.GetModelName(),
-				"estimated_tokens", inputTokens,
-				"error", err)
+	estimate := w.countInputTokens(ctx, providerName, prompt)
+	inputTokens := estimate.Tokens
+
+	suggestedModel := originalModel
+	var rule config.RoutingRule
+	if commandModel == "" {
+		matched, _, ok := w.modelSelector.Select(routing.Request{
+			Operation:       commandKey(command),
+			Provider:        providerName,
+			EstimatedTokens: inputTokens,
+			FileCount:       fileCountFromContext(ctx),
+		})
+		if ok && matched.Model != "" {
+			rule = matched
+			suggestedModel = string(rule.Model)
+			slog.Debug("routing rule matched",
+				"command", command,
+				"rule", rule.Name,
+				"model", suggestedModel)
 		}
 	}
-
-	suggestedModel := w.modelSelector.SelectBestModel(command, inputTokens)
 	hasSuggestion := suggestedModel != originalModel
 
 	estimatedCost := w.calculator.EstimateCost(providerName, originalModel, inputTokens, w.estimatedOutputTokens)
 
-	budgetStatus, err := w.manager.CheckBudget(estimatedCost)
-	if err != nil {
+	if err := w.enforceBudget(ctx, command, estimatedCost); err != nil {
 		return nil, nil, err
 	}
 
-	if budgetStatus.IsExceeded {
-		return nil, nil, errors.ErrQuotaExceeded
-	}
-
 	if (estimatedCost > 0.0001 || hasSuggestion) && !w.skipConfirmation && w.onConfirmation != nil {
-		rationaleKey := ""
-		if hasSuggestion {
-			rationaleKey = w.modelSelector.GetRationale(suggestedModel)
-		}
-
-		choice, proceed := w.onConfirmation(ConfirmationResult{
+		result := ConfirmationResult{
 			EstimatedCost:  estimatedCost,
 			InputTokens:    inputTokens,
 			OutputTokens:   w.estimatedOutputTokens,
 			SuggestedModel: suggestedModel,
 			CurrentModel:   originalModel,
-			RationaleKey:   rationaleKey,
-		})
+		}
+		if hasSuggestion {
+			if rationale, isKey := w.modelSelector.GetRationale(rule); isKey {
+				result.RationaleKey = rationale
+			} else {
+				result.Rationale = rationale
+			}
+		}
+
+		choice, proceed := w.onConfirmation(result)
 
 		if !proceed {
 			return nil, nil, errors.NewAppError(errors.TypeInternal, "operation cancelled by user", nil)
@@ -176,12 +219,88 @@ func (w *CostAwareWrapper) WrapGenerate(
 		}
 	}
 
+	attempt := 1
+	attemptStart := time.Now()
+	activeFn := generateFn
 	resp, usage, err := generateFn(ctx, modelToUse, prompt)
+	if err != nil {
+		w.recordFailedAttempt(ctx, command, providerName, modelToUse, contentHash, attempt, 0, attemptStart, err)
+	}
+	if err != nil && isRetryable(err) && len(w.fallbackChain()) > 0 {
+		for _, entry := range w.fallbackChain() {
+			client, clientErr := NewLLMClient(ctx, w.appConfig, entry.Provider)
+			if clientErr != nil {
+				slog.Warn("could not create fallback provider",
+					"provider", entry.Provider,
+					"error", clientErr)
+				continue
+			}
+			fallbackFn := commandGenerateFunc(client, w.appConfig, command)
+
+			fallbackModel := string(entry.Model)
+			if fallbackModel == "" {
+				fallbackModel = string(config.DefaultModelForAI(entry.Provider))
+			}
+			// The caps apply to every provider of the chain, priced at its own rates
+			fallbackCost := w.calculator.EstimateCost(string(entry.Provider), fallbackModel, inputTokens, w.estimatedOutputTokens)
+			if budgetErr := w.enforceBudget(ctx, command, fallbackCost); budgetErr != nil {
+				err = budgetErr
+				continue
+			}
+
+			attempt++
+			providerName = string(entry.Provider)
+			modelToUse = fallbackModel
+
+			slog.Info("retrying with fallback provider",
+				"command", command,
+				"provider", providerName,
+				"model", modelToUse,
+				"attempt", attempt,
+				"previous_error", err)
+
+			attemptStart = time.Now()
+			resp, usage, err = fallbackFn(ctx, modelToUse, prompt)
+			if err == nil {
+				activeFn = fallbackFn
+				break
+			}
+			w.recordFailedAttempt(ctx, command, providerName, modelToUse, contentHash, attempt, 0, attemptStart, err)
+			if !isRetryable(err) {
+				break
+			}
+		}
+	}
 	if err != nil {
 		return nil, nil, err
 	}
 
-	if err := w.cache.Set(contentHash, resp); err != nil {
+	// The estimate was made for the active provider, so only its own call can tell how accurate it was
+	if attempt > 1 {
+		estimate = inputEstimate{}
+	}
+	w.recordUsage(ctx, command, providerName, modelToUse, contentHash, attempt, 0, attemptStart, usage, estimate)
+
+	if _, schema := schemaForCommand(command); schema != nil {
+		resp, usage, err = w.ensureValid(ctx, command, prompt, schema, activeFn, providerName, modelToUse, contentHash, attempt, resp, usage)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if !useCache {
+		slog.Debug("response cache disabled for this call",
+			"command", command)
+	} else if attempt > 1 {
+		// The cache key names the active provider and model, which did not write this answer
+		slog.Debug("fallback response not cached",
+			"command", command,
+			"provider", providerName)
+	} else if err := w.cache.Set(contentHash, resp, cache.Metadata{
+		Command:  command,
+		Provider: providerName,
+		Model:    modelToUse,
+	}); err != nil {
 		slog.Warn("failed to cache response",
 			"command", command,
 			"error", err)
@@ -192,24 +311,264 @@ func (w *CostAwareWrapper) WrapGenerate(
 	}
 
 	if usage != nil {
-		usage.Model = modelToUse
-		usage.CostUSD = w.calculator.EstimateCost(providerName, modelToUse, usage.InputTokens, usage.OutputTokens)
 		usage.DurationMs = time.Since(startTime).Milliseconds()
-		usage.CacheHit = false
-
-		_ = w.manager.SaveActivity(cost.ActivityRecord{
-			Timestamp:    time.Now(),
-			Command:      command,
-			Provider:     providerName,
-			Model:        modelToUse,
-			TokensInput:  usage.InputTokens,
-			TokensOutput: usage.OutputTokens,
-			CostUSD:      usage.CostUSD,
-			DurationMs:   usage.DurationMs,
-			CacheHit:     false,
-			Hash:         contentHash,
-		})
+		usage.Attempts = attempt
 	}
 
 	return resp, usage, nil
 }
+
+// ensureValid validates a structured response and, while it does not match the schema, sends it back
+// to the model with the validation errors, at most maxRepairAttempts times.
+// Every repair call is costed and recorded; the returned usage adds them to the first call.
+func (w *CostAwareWrapper) ensureValid(
+	ctx context.Context,
+	command, prompt string,
+	schema *Schema,
+	generateFn GenerateFunc,
+	provider, model, hash string,
+	attempt int,
+	resp interface{},
+	usage *models.TokenUsage,
+) (interface{}, *models.TokenUsage, error) {
+	text, problems := validateResponse(schema, extractResponseText(resp))
+
+	for repair := 1; len(problems) > 0 && repair <= maxRepairAttempts; repair++ {
+		slog.Warn("AI response does not match the schema, asking the model to repair it",
+			"command", command,
+			"model", model,
+			"repair", repair,
+			"errors", len(problems),
+			"first_error", problems[0].String())
+
+		repairPrompt, err := RenderPrompt("repairPrompt", GetRepairPromptTemplate(w.language()), PromptData{
+			Prompt:   prompt,
+			Response: text,
+			Errors:   formatValidationErrors(problems),
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		repairStart := time.Now()
+		repaired, repairUsage, err := generateFn(ctx, model, repairPrompt)
+		if err != nil {
+			w.recordFailedAttempt(ctx, command, provider, model, hash, attempt, repair, repairStart, err)
+			return nil, nil, err
+		}
+		w.recordUsage(ctx, command, provider, model, hash, attempt, repair, repairStart, repairUsage, inputEstimate{})
+		usage = addUsage(usage, repairUsage)
+		if usage != nil {
+			usage.Repairs = repair
+		}
+
+		text, problems = validateResponse(schema, extractResponseText(repaired))
+	}
+
+	if len(problems) > 0 {
+		errs := make([]string, 0, len(problems))
+		for _, problem := range problems {
+			errs = append(errs, problem.String())
+		}
+		return nil, nil, errors.ErrInvalidAIOutput.
+			WithContext("reason", "response does not match the schema").
+			WithContext("command", command).
+			WithContext("validation_errors", strings.Join(errs, "; "))
+	}
+	return text, usage, nil
+}
+
+// isValidCached reports whether a cached response still passes the schema of its command
+func (w *CostAwareWrapper) isValidCached(command, text string) bool {
+	_, schema := schemaForCommand(command)
+	if schema == nil {
+		return true
+	}
+	_, problems := validateResponse(schema, text)
+	return len(problems) == 0
+}
+
+// recordUsage completes the usage of a successful call with its cost and stores it in the history,
+// next to the input estimate made before the call when there is one
+func (w *CostAwareWrapper) recordUsage(ctx context.Context, command, provider, model, hash string, attempt, repair int, start time.Time, usage *models.TokenUsage, estimate inputEstimate) {
+	if usage == nil {
+		return
+	}
+	workspace := workspaceFromContext(ctx)
+	usage.Model = model
+	usage.CostUSD = w.calculator.EstimateCost(provider, model, usage.InputTokens, usage.OutputTokens)
+	usage.DurationMs = time.Since(start).Milliseconds()
+	usage.CacheHit = false
+	usage.Provider = provider
+
+	_ = w.manager.SaveActivity(cost.ActivityRecord{
+		Timestamp:    time.Now(),
+		Command:      command,
+		Provider:     provider,
+		Model:        model,
+		TokensInput:  usage.InputTokens,
+		TokensOutput: usage.OutputTokens,
+		CostUSD:      usage.CostUSD,
+		DurationMs:   usage.DurationMs,
+		CacheHit:     false,
+		Hash:         hash,
+		Attempt:      attempt,
+		Repair:       repair,
+
+		TokensEstimated: estimate.Tokens,
+		EstimateSource:  estimate.Source,
+		Repo:            workspace.Repo,
+		Branch:          workspace.Branch,
+		Workdir:         workspace.Workdir,
+	})
+}
+
+// recordCacheHit stores a call answered from the cache, which costs nothing but counts for the hit
+// rate and the cache latency
+func (w *CostAwareWrapper) recordCacheHit(ctx context.Context, command, hash string, usage *models.TokenUsage) {
+	workspace := workspaceFromContext(ctx)
+	_ = w.manager.SaveActivity(cost.ActivityRecord{
+		Timestamp:  time.Now(),
+		Command:    command,
+		Provider:   usage.Provider,
+		Model:      usage.Model,
+		DurationMs: usage.DurationMs,
+		CacheHit:   true,
+		Hash:       hash,
+		Repo:       workspace.Repo,
+		Branch:     workspace.Branch,
+		Workdir:    workspace.Workdir,
+	})
+}
+
+// inputEstimate is the input token count of a prompt known before the call, and where it came from
+type inputEstimate struct {
+	Tokens int
+	Source string
+}
+
+// countInputTokens counts the tokens of a prompt the way ai_config.token_counting asks for.
+// The exact mode asks the provider and falls back to the local estimate when it cannot count.
+func (w *CostAwareWrapper) countInputTokens(ctx context.Context, providerName, prompt string) inputEstimate {
+	switch w.tokenCountingMode() {
+	case config.TokenCountingOff:
+		return inputEstimate{}
+	case config.TokenCountingEstimate:
+		return inputEstimate{Tokens: tokens.Estimate(providerName, prompt), Source: cost.EstimateSourceLocal}
+	}
+
+	count, err := w.provider.CountTokens(ctx, prompt)
+	if err == nil {
+		return inputEstimate{Tokens: count, Source: cost.EstimateSourceAPI}
+	}
+
+	estimate := inputEstimate{Tokens: tokens.Estimate(providerName, prompt), Source: cost.EstimateSourceLocal}
+
+	msg := "failed to count tokens via API, using local estimation"
+	errStr := err.Error()
+
+	if strings.Contains(errStr, "not supported") || strings.Contains(errStr, "not found") {
+		slog.Debug(msg,
+			"provider", w.provider.GetProviderName(),
+			"model", w.provider.GetModelName(),
+			"reason", "model_not_supported_or_found")
+	} else {
+		slog.Warn(msg,
+			"provider", w.provider.GetProviderName(),
+			"model", w.provider.GetModelName(),
+			"estimated_tokens", estimate.Tokens,
+			"error", err)
+	}
+	return estimate
+}
+
+// tokenCountingMode returns the configured token counting mode
+func (w *CostAwareWrapper) tokenCountingMode() string {
+	if w.appConfig == nil {
+		return config.TokenCountingExact
+	}
+	return w.appConfig.AIConfig.TokenCountingMode()
+}
+
+// addUsage returns the tokens and cost of both calls; the model and provider of total are kept
+func addUsage(total, extra *models.TokenUsage) *models.TokenUsage {
+	if extra == nil {
+		return total
+	}
+	if total == nil {
+		return extra
+	}
+	total.InputTokens += extra.InputTokens
+	total.OutputTokens += extra.OutputTokens
+	total.TotalTokens += extra.TotalTokens
+	total.CostUSD += extra.CostUSD
+	return total
+}
+
+// language returns the language of the prompts written by the wrapper itself
+func (w *CostAwareWrapper) language() string {
+	if w.appConfig == nil {
+		return ""
+	}
+	return w.appConfig.Language
+}
+
+// redactPrompt masks the secrets of a prompt and lets the reporter of ctx review what was masked
+func (w *CostAwareWrapper) redactPrompt(ctx context.Context, command, prompt string) (string, error) {
+	redacted, report := w.redactor.Redact(prompt)
+	if report.Empty() {
+		return prompt, nil
+	}
+
+	slog.Info("masked secrets in prompt",
+		"command", command,
+		"count", len(report.Findings))
+
+	if reporter := redactionReporterFromContext(ctx); reporter != nil && !reporter(command, report) {
+		return "", errors.NewAppError(errors.TypeInternal, "operation cancelled by user", nil)
+	}
+	return redacted, nil
+}
+
+// routingRules returns the routing rules of the configuration; nil selects the built-in ones
+func routingRules(cfg *config.Config) []config.RoutingRule {
+	if cfg == nil {
+		return nil
+	}
+	return cfg.AIConfig.Routing
+}
+
+// fallbackChain returns the providers to try after the active one fails
+func (w *CostAwareWrapper) fallbackChain() []config.AIFallback {
+	if w.appConfig == nil {
+		return nil
+	}
+	return w.appConfig.AIConfig.Fallback
+}
+
+// recordFailedAttempt stores a failed call, of the fallback chain or a repair, so every call shows up in the history
+func (w *CostAwareWrapper) recordFailedAttempt(ctx context.Context, command, provider, model, hash string, attempt, repair int, start time.Time, err error) {
+	slog.Warn("AI call failed",
+		"command", command,
+		"provider", provider,
+		"model", model,
+		"attempt", attempt,
+		"repair", repair,
+		"error", err)
+
+	workspace := workspaceFromContext(ctx)
+	_ = w.manager.SaveActivity(cost.ActivityRecord{
+		Timestamp:  time.Now(),
+		Command:    command,
+		Provider:   provider,
+		Model:      model,
+		DurationMs: time.Since(start).Milliseconds(),
+		Hash:       hash,
+		Attempt:    attempt,
+		Repair:     repair,
+		Error:      err.Error(),
+		Repo:       workspace.Repo,
+		Branch:     workspace.Branch,
+		Workdir:    workspace.Workdir,
+	})
+}
@@ -0,0 +1,252 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/thomas-vilte/matecommit/internal/config"
+	"github.com/thomas-vilte/matecommit/internal/logger"
+	"github.com/thomas-vilte/matecommit/internal/tokens"
+)
+
+// diffReducerCommand is the command the chunk summaries are tracked under
+const diffReducerCommand = "summarize-diff"
+
+// truncatedHunkMarker closes a hunk cut to fit in a chunk
+const truncatedHunkMarker = "\n... (hunk truncated)\n"
+
+// diffFile is the section of a unified diff that belongs to one file.
+type diffFile struct {
+	Path string
+	// Header holds the "diff --git" line and the metadata before the first hunk
+	Header string
+	Hunks  []string
+}
+
+func (f diffFile) text() string {
+	return f.Header + strings.Join(f.Hunks, "")
+}
+
+// diffChunk is a group of files, or of hunks of a single file, summarized in one call.
+type diffChunk struct {
+	Files []string
+	Text  string
+}
+
+// diffReducer keeps diffs within the context window of the model with a map-reduce pass:
+// a diff over the token budget is split into per-file hunk chunks, every chunk is summarized,
+// and the summaries take the place of the diff in the prompt.
+type diffReducer struct {
+	client     LLMClient
+	wrapper    *CostAwareWrapper
+	generateFn GenerateFunc
+	model      string
+	language   string
+}
+
+// newDiffReducer creates the reducer for the prompts of a wrapped command.
+// The budget is taken from the model configured for the command, falling back to the client model.
+func newDiffReducer(client LLMClient, cfg *config.Config, command string) (*diffReducer, error) {
+	// The chunk calls are part of the command being confirmed, so they are not confirmed one by one
+	wrapper, err := newServiceWrapper(client, cfg, 400, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	model := client.GetModelName()
+	if commandModel := commandSettings(cfg, command).Model; commandModel != "" {
+		model = string(commandModel)
+	}
+
+	return &diffReducer{
+		client:     client,
+		wrapper:    wrapper,
+		generateFn: commandGenerateFunc(client, cfg, diffReducerCommand),
+		model:      model,
+		language:   cfg.Language,
+	}, nil
+}
+
+// Reduce returns text unchanged when it fits the diff budget of the model.
+// Otherwise everything before the first "diff --git" line is kept and the diff is replaced
+// by the summaries of its chunks.
+func (r *diffReducer) Reduce(ctx context.Context, text string) (string, error) {
+	log := logger.FromContext(ctx)
+
+	budget := DiffTokenBudget(r.client.GetProviderName(), r.model)
+	// A token is never shorter than a byte, so short texts fit without counting
+	if len(text) <= budget {
+		return text, nil
+	}
+
+	tokens := r.countTokens(ctx, text)
+	if tokens <= budget {
+		return text, nil
+	}
+
+	preamble, files := splitDiff(text)
+	if len(files) == 0 {
+		log.Warn("text exceeds the diff budget but contains no diff",
+			"tokens", tokens,
+			"budget", budget)
+		return text, nil
+	}
+
+	// The characters per token measured on this diff size the chunks without counting each one
+	chunkChars := int(float64(budget) * float64(len(text)) / float64(tokens))
+	chunks := chunkDiff(files, chunkChars)
+
+	log.Info("diff exceeds the context budget, summarizing in chunks",
+		"model", r.model,
+		"tokens", tokens,
+		"budget", budget,
+		"files", len(files),
+		"chunks", len(chunks))
+
+	summaries := make([]string, 0, len(chunks))
+	for i, chunk := range chunks {
+		prompt, err := RenderPrompt("diffChunkPrompt", GetDiffChunkPromptTemplate(r.language), PromptData{
+			Part:  i + 1,
+			Parts: len(chunks),
+			Files: formatChanges(chunk.Files),
+			Diff:  fmt.Sprintf("```diff\n%s\n```", chunk.Text),
+		})
+		if err != nil {
+			return "", err
+		}
+
+		resp, _, err := r.wrapper.WrapGenerate(ctx, diffReducerCommand, prompt, r.generateFn)
+		if err != nil {
+			return "", fmt.Errorf("error summarizing diff chunk %d of %d: %w", i+1, len(chunks), err)
+		}
+		summaries = append(summaries, strings.TrimSpace(extractResponseText(resp)))
+	}
+
+	return r.merge(preamble, chunks, summaries), nil
+}
+
+// merge joins the chunk summaries behind the preamble of the original text.
+func (r *diffReducer) merge(preamble string, chunks []diffChunk, summaries []string) string {
+	var sb strings.Builder
+	sb.WriteString(preamble)
+
+	header, _ := RenderPrompt("reducedDiffHeader", GetReducedDiffHeader(r.language), PromptData{Parts: len(chunks)})
+	sb.WriteString(header)
+	sb.WriteString("\n")
+
+	for i, summary := range summaries {
+		sb.WriteString(fmt.Sprintf("\n### %d/%d: %s\n%s\n", i+1, len(chunks), strings.Join(chunks[i].Files, ", "), summary))
+	}
+
+	return sb.String()
+}
+
+// countTokens counts the tokens of text with the provider, or estimates them when it cannot.
+// Counting sends the text to the provider, so it is redacted like any prompt.
+// Outside the exact mode the local estimate is used, since chunking always needs a count.
+func (r *diffReducer) countTokens(ctx context.Context, text string) int {
+	if r.wrapper.tokenCountingMode() != config.TokenCountingExact {
+		return tokens.Estimate(r.client.GetProviderName(), text)
+	}
+
+	redacted, _ := r.wrapper.redactor.Redact(text)
+	count, err := r.client.CountTokens(ctx, redacted)
+	if err != nil || count <= 0 {
+		return tokens.Estimate(r.client.GetProviderName(), text)
+	}
+	return count
+}
+
+// splitDiff separates the text before the first file of a unified diff from the per-file sections.
+func splitDiff(text string) (string, []diffFile) {
+	var (
+		preamble strings.Builder
+		files    []diffFile
+		current  *diffFile
+	)
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			files = append(files, diffFile{Path: diffFilePath(line), Header: line})
+			current = &files[len(files)-1]
+		case current == nil:
+			preamble.WriteString(line)
+		case strings.HasPrefix(line, "@@"):
+			current.Hunks = append(current.Hunks, line)
+		case len(current.Hunks) == 0:
+			current.Header += line
+		default:
+			current.Hunks[len(current.Hunks)-1] += line
+		}
+	}
+
+	return preamble.String(), files
+}
+
+// diffFilePath returns the path of the "b/" side of a "diff --git a/x b/x" line.
+func diffFilePath(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) < 4 {
+		return strings.TrimSpace(strings.TrimPrefix(line, "diff --git "))
+	}
+	return strings.TrimPrefix(fields[3], "b/")
+}
+
+// chunkDiff packs whole files into chunks of at most maxChars characters.
+// A file that does not fit on its own is split at hunk boundaries, repeating its header in every chunk,
+// and a hunk that does not fit on its own is truncated. A header longer than half a chunk is truncated
+// too, so every chunk has room for its hunks.
+func chunkDiff(files []diffFile, maxChars int) []diffChunk {
+	var (
+		chunks  []diffChunk
+		current diffChunk
+	)
+
+	flush := func() {
+		if current.Text != "" {
+			chunks = append(chunks, current)
+		}
+		current = diffChunk{}
+	}
+
+	for _, file := range files {
+		text := file.text()
+		if len(text) <= maxChars {
+			if len(current.Text)+len(text) > maxChars {
+				flush()
+			}
+			current.Files = append(current.Files, file.Path)
+			current.Text += text
+			continue
+		}
+
+		flush()
+		header := truncateText(file.Header, maxChars/2)
+		part := diffChunk{Files: []string{file.Path}, Text: header}
+		for _, hunk := range file.Hunks {
+			if len(part.Text)+len(hunk) > maxChars && part.Text != header {
+				chunks = append(chunks, part)
+				part = diffChunk{Files: []string{file.Path}, Text: header}
+			}
+			part.Text += truncateText(hunk, maxChars-len(part.Text))
+		}
+		chunks = append(chunks, part)
+	}
+	flush()
+
+	return chunks
+}
+
+// truncateText cuts text to at most maxChars characters, ending it with truncatedHunkMarker when
+// there is room for it.
+func truncateText(text string, maxChars int) string {
+	if len(text) <= maxChars {
+		return text
+	}
+	if room := maxChars - len(truncatedHunkMarker); room > 0 {
+		return text[:room] + truncatedHunkMarker
+	}
+	return text[:max(maxChars, 0)]
+}
@@ -0,0 +1,169 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomas-vilte/matecommit/internal/config"
+)
+
+// fileDiff returns the diff of a file with one hunk per entry of hunkSizes, each about that many bytes long.
+func fileDiff(path string, hunkSizes ...int) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("diff --git a/%s b/%s\nindex 123..456 100644\n--- a/%s\n+++ b/%s\n", path, path, path, path))
+	for i, size := range hunkSizes {
+		sb.WriteString(fmt.Sprintf("@@ -%d,1 +%d,1 @@\n", i+1, i+1))
+		sb.WriteString("+" + strings.Repeat("x", size) + "\n")
+	}
+	return sb.String()
+}
+
+func TestSplitDiff(t *testing.T) {
+	// Arrange
+	text := "Changes:\n" + fileDiff("main.go", 10, 20) + fileDiff("docs/README.md", 5)
+
+	// Act
+	preamble, files := splitDiff(text)
+
+	// Assert
+	assert.Equal(t, "Changes:\n", preamble)
+	require.Len(t, files, 2)
+	assert.Equal(t, "main.go", files[0].Path)
+	assert.Len(t, files[0].Hunks, 2)
+	assert.True(t, strings.HasPrefix(files[0].Header, "diff --git a/main.go b/main.go\n"))
+	assert.True(t, strings.HasPrefix(files[0].Hunks[1], "@@ -2,1 +2,1 @@\n"))
+	assert.Equal(t, "docs/README.md", files[1].Path)
+	assert.Equal(t, text, preamble+files[0].text()+files[1].text())
+}
+
+func TestChunkDiff(t *testing.T) {
+	t.Run("packs small files together", func(t *testing.T) {
+		_, files := splitDiff(fileDiff("a.go", 100) + fileDiff("b.go", 100) + fileDiff("c.go", 100))
+
+		chunks := chunkDiff(files, 400)
+
+		require.Len(t, chunks, 2)
+		assert.Equal(t, []string{"a.go", "b.go"}, chunks[0].Files)
+		assert.Equal(t, []string{"c.go"}, chunks[1].Files)
+	})
+
+	t.Run("splits a large file at hunk boundaries", func(t *testing.T) {
+		_, files := splitDiff(fileDiff("big.go", 150, 150, 150))
+
+		chunks := chunkDiff(files, 500)
+
+		require.Len(t, chunks, 2)
+		for _, chunk := range chunks {
+			assert.Equal(t, []string{"big.go"}, chunk.Files)
+			assert.True(t, strings.HasPrefix(chunk.Text, files[0].Header))
+			assert.LessOrEqual(t, len(chunk.Text), 500)
+		}
+	})
+
+	t.Run("truncates a hunk larger than a chunk", func(t *testing.T) {
+		_, files := splitDiff(fileDiff("huge.go", 1000))
+
+		chunks := chunkDiff(files, 400)
+
+		require.Len(t, chunks, 1)
+		assert.LessOrEqual(t, len(chunks[0].Text), 400)
+		assert.True(t, strings.HasSuffix(chunks[0].Text, truncatedHunkMarker))
+	})
+
+	t.Run("truncates a header larger than a chunk", func(t *testing.T) {
+		header := "diff --git a/gen.go b/gen.go\n" + strings.Repeat("similarity index 90%\n", 50)
+		_, files := splitDiff(header + "@@ -1 +1 @@\n" + strings.Repeat("+x\n", 50))
+		require.Greater(t, len(files[0].Header), 400)
+
+		chunks := chunkDiff(files, 400)
+
+		require.NotEmpty(t, chunks)
+		for _, chunk := range chunks {
+			assert.LessOrEqual(t, len(chunk.Text), 400)
+			assert.True(t, strings.HasPrefix(chunk.Text, "diff --git a/gen.go b/gen.go\n"))
+		}
+	})
+}
+
+func TestDiffReducer_Reduce(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	ctx := context.Background()
+	cfg := &config.Config{Language: "en"}
+
+	t.Run("diff within the budget is kept", func(t *testing.T) {
+		// Arrange
+		client := &fakeLLMClient{provider: "local", model: "llama3.1", text: "summary"}
+		reducer, err := newDiffReducer(client, cfg, "suggest-commits")
+		require.NoError(t, err)
+		diff := fileDiff("main.go", 100)
+
+		// Act
+		got, err := reducer.Reduce(ctx, diff)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, diff, got)
+		assert.Empty(t, client.requests)
+	})
+
+	t.Run("diff over the budget is summarized per chunk", func(t *testing.T) {
+		// Arrange
+		client := &fakeLLMClient{provider: "local", model: "llama3.1", text: "summary of the chunk"}
+		reducer, err := newDiffReducer(client, cfg, "suggest-commits")
+		require.NoError(t, err)
+		// 8192 tokens of window leave a 4096 token budget, about 16KB of diff for the fake counter
+		text := "PR #1\n" + fileDiff("a.go", 8000) + fileDiff("b.go", 8000) + fileDiff("c.go", 8000)
+
+		// Act
+		got, err := reducer.Reduce(ctx, text)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, client.requests, 2)
+		assert.Nil(t, client.requests[0].Schema)
+		assert.Contains(t, client.requests[0].Prompt, "part 1 of 2")
+		assert.True(t, strings.HasPrefix(got, "PR #1\n"))
+		assert.Contains(t, got, "summarized per file in 2 parts")
+		assert.Contains(t, got, "### 1/2: a.go, b.go\nsummary of the chunk")
+		assert.Contains(t, got, "### 2/2: c.go\nsummary of the chunk")
+		assert.NotContains(t, got, "diff --git")
+	})
+
+	t.Run("a configured command model sizes the budget", func(t *testing.T) {
+		// Arrange
+		client := &fakeLLMClient{provider: "local", model: "llama3.1", text: "summary"}
+		commandCfg := &config.Config{AIConfig: config.AIConfig{Commands: map[string]config.CommandAIConfig{
+			config.CommandSuggest: {Model: "qwen2.5-coder:7b"},
+		}}}
+		reducer, err := newDiffReducer(client, commandCfg, "suggest-commits")
+		require.NoError(t, err)
+		diff := fileDiff("a.go", 8000) + fileDiff("b.go", 8000) + fileDiff("c.go", 8000)
+
+		// Act
+		got, err := reducer.Reduce(ctx, diff)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, diff, got)
+		assert.Empty(t, client.requests)
+	})
+
+	t.Run("chunk errors are returned", func(t *testing.T) {
+		// Arrange
+		client := &fakeLLMClient{provider: "local", model: "llama3.1", err: errors.New("boom")}
+		reducer, err := newDiffReducer(client, cfg, "summarize-pr")
+		require.NoError(t, err)
+		diff := fileDiff("a.go", 20000)
+
+		// Act
+		_, err = reducer.Reduce(ctx, diff)
+
+		// Assert
+		assert.ErrorContains(t, err, "error summarizing diff chunk 1 of 1")
+	})
+}
@@ -0,0 +1,31 @@
+package ai
+
+import (
+	"context"
+	stdErrors "errors"
+
+	"github.com/thomas-vilte/matecommit/internal/errors"
+)
+
+// retryableErrors are the failures worth trying on another provider: quotas, 5xx and unreachable servers.
+// Bad requests, unknown models and malformed answers would fail the same way on any provider.
+var retryableErrors = []error{
+	errors.ErrAIServerError,
+	errors.ErrGeminiQuotaExceeded,
+	errors.ErrOpenAIQuotaExceeded,
+	errors.ErrAnthropicQuotaExceeded,
+	errors.ErrLocalAIUnreachable,
+}
+
+func isRetryable(err error) bool {
+	// A cancelled command wraps the error of whatever call it interrupted
+	if stdErrors.Is(err, context.Canceled) {
+		return false
+	}
+	for _, target := range retryableErrors {
+		if stdErrors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
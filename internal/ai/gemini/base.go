@@ -7,9 +7,9 @@ import (
 	"google.golang.org/genai"
 )
 
-var _ ai.CostAwareAIProvider = (*GeminiProvider)(nil)
+var _ ai.LLMClient = (*GeminiProvider)(nil)
 
-// GeminiProvider is a shared base for all Gemini services that implements the ai.CostAwareAIProvider interface
+// GeminiProvider is the Gemini adapter of the ai.LLMClient interface
 type GeminiProvider struct {
 	Client *genai.Client
 	model  string
@@ -0,0 +1,211 @@
+package gemini
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/thomas-vilte/matecommit/internal/ai"
+	"github.com/thomas-vilte/matecommit/internal/config"
+	domainErrors "github.com/thomas-vilte/matecommit/internal/errors"
+	"github.com/thomas-vilte/matecommit/internal/logger"
+	"github.com/thomas-vilte/matecommit/internal/models"
+	"google.golang.org/genai"
+)
+
+func init() {
+	ai.RegisterClientFactory(string(config.AIGemini), func(ctx context.Context, cfg *config.Config) (ai.LLMClient, error) {
+		client, err := NewClient(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	})
+}
+
+// NewClient builds the provider from the "gemini" entry of the configuration.
+func NewClient(ctx context.Context, cfg *config.Config) (*GeminiProvider, error) {
+	providerCfg, exists := cfg.AIProviders[string(config.AIGemini)]
+	if !exists || providerCfg.APIKey == "" {
+		return nil, domainErrors.ErrAPIKeyMissing
+	}
+
+	client, err := genai.NewClient(ctx, &genai.ClientConfig{
+		APIKey:  providerCfg.APIKey,
+		Backend: genai.BackendGeminiAPI,
+	})
+	if err != nil {
+		errMsg := strings.ToLower(err.Error())
+		if strings.Contains(errMsg, "invalid") ||
+			strings.Contains(errMsg, "unauthorized") ||
+			strings.Contains(errMsg, "api key") ||
+			strings.Contains(errMsg, "authentication") {
+			return nil, domainErrors.ErrGeminiAPIKeyInvalid.WithError(err)
+		}
+		return nil, domainErrors.NewAppError(domainErrors.TypeAI, "error creating AI client", err)
+	}
+
+	modelName := string(cfg.AIConfig.Models[config.AIGemini])
+	if modelName == "" {
+		modelName = providerCfg.Model
+	}
+	if modelName == "" {
+		modelName = string(config.DefaultModelForAI(config.AIGemini))
+	}
+
+	return NewGeminiProvider(client, modelName), nil
+}
+
+// Generate implements ai.LLMClient
+func (g *GeminiProvider) Generate(ctx context.Context, req ai.LLMRequest) (*ai.LLMResponse, error) {
+	log := logger.FromContext(ctx)
+
+	model := req.Model
+	if model == "" {
+		model = g.model
+	}
+
+	log.Debug("calling gemini API",
+		"model", model,
+		"prompt_length", len(req.Prompt))
+
+	resp, err := g.Client.Models.GenerateContent(ctx, model, genai.Text(req.Prompt), generateConfig(model, req))
+	if err != nil {
+		log.Error("gemini API call failed",
+			"error", err,
+			"model", model)
+		return nil, mapGenerateError(err)
+	}
+
+	usage := extractUsage(resp)
+	log.Debug("gemini API response received",
+		"candidates", len(resp.Candidates),
+		"has_usage", usage != nil)
+
+	return &ai.LLMResponse{Text: formatResponse(resp), Usage: usage}, nil
+}
+
+// GenerateStream implements ai.StreamingClient.
+// Every streamed response carries the next piece of text; the usage comes with the last one.
+func (g *GeminiProvider) GenerateStream(ctx context.Context, req ai.LLMRequest, onChunk func(text string)) (*ai.LLMResponse, error) {
+	log := logger.FromContext(ctx)
+
+	model := req.Model
+	if model == "" {
+		model = g.model
+	}
+
+	log.Debug("streaming gemini API",
+		"model", model,
+		"prompt_length", len(req.Prompt))
+
+	var (
+		text  strings.Builder
+		usage *models.TokenUsage
+	)
+	for resp, err := range g.Client.Models.GenerateContentStream(ctx, model, genai.Text(req.Prompt), generateConfig(model, req)) {
+		if err != nil {
+			log.Error("gemini API call failed",
+				"error", err,
+				"model", model)
+			return nil, mapGenerateError(err)
+		}
+		if chunk := formatResponse(resp); chunk != "" {
+			text.WriteString(chunk)
+			onChunk(chunk)
+		}
+		if chunkUsage := extractUsage(resp); chunkUsage != nil {
+			usage = chunkUsage
+		}
+	}
+
+	log.Debug("gemini API stream completed",
+		"response_length", text.Len(),
+		"has_usage", usage != nil)
+
+	return &ai.LLMResponse{Text: text.String(), Usage: usage}, nil
+}
+
+// generateConfig builds the generation settings of a request, asking for JSON when it has a schema.
+func generateConfig(model string, req ai.LLMRequest) *genai.GenerateContentConfig {
+	responseType := ""
+	if req.Schema != nil {
+		responseType = "application/json"
+	}
+	genConfig := GetGenerateConfig(model, responseType, toGenaiSchema(req.Schema))
+	if req.Temperature > 0 {
+		genConfig.Temperature = float32Ptr(req.Temperature)
+	}
+	if req.MaxTokens > 0 {
+		genConfig.MaxOutputTokens = int32(req.MaxTokens)
+	}
+	return genConfig
+}
+
+// toGenaiSchema translates a provider-neutral schema into the Gemini schema type.
+func toGenaiSchema(s *ai.Schema) *genai.Schema {
+	if s == nil {
+		return nil
+	}
+
+	out := &genai.Schema{
+		Type:        genai.Type(strings.ToUpper(string(s.Type))),
+		Description: s.Description,
+		Required:    s.Required,
+		Enum:        s.Enum,
+		Items:       toGenaiSchema(s.Items),
+	}
+	if len(s.Properties) > 0 {
+		out.Properties = make(map[string]*genai.Schema, len(s.Properties))
+		for name, property := range s.Properties {
+			out.Properties[name] = toGenaiSchema(property)
+		}
+	}
+	return out
+}
+
+// mapGenerateError converts a Gemini API error into the matching domain error.
+func mapGenerateError(err error) error {
+	var apiErr genai.APIError
+	if errors.As(err, &apiErr) && apiErr.Code >= http.StatusInternalServerError {
+		return domainErrors.ErrAIServerError.
+			WithContext("status", apiErr.Code).
+			WithError(err)
+	}
+
+	errMsg := strings.ToLower(err.Error())
+	if strings.Contains(errMsg, "quota") ||
+		strings.Contains(errMsg, "rate limit") ||
+		strings.Contains(errMsg, "resource exhausted") {
+		return domainErrors.ErrGeminiQuotaExceeded.WithError(err)
+	}
+	if strings.Contains(errMsg, "invalid") ||
+		strings.Contains(errMsg, "unauthorized") ||
+		strings.Contains(errMsg, "api key") {
+		return domainErrors.ErrGeminiAPIKeyInvalid.WithError(err)
+	}
+	return domainErrors.ErrAIGeneration.WithError(err)
+}
+
+// formatResponse formats the Gemini API response into a string, filtering out thinking parts.
+func formatResponse(resp *genai.GenerateContentResponse) string {
+	if resp == nil || len(resp.Candidates) == 0 {
+		return ""
+	}
+	var formattedContent strings.Builder
+	for _, cand := range resp.Candidates {
+		if cand.Content != nil {
+			for _, part := range cand.Content.Parts {
+				// Skip thinking parts - only get actual content
+				if part.Thought {
+					continue
+				}
+				if part.Text != "" {
+					formattedContent.WriteString(part.Text)
+				}
+			}
+		}
+	}
+	return formattedContent.String()
+}
@@ -0,0 +1,123 @@
+package gemini
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomas-vilte/matecommit/internal/ai"
+	"github.com/thomas-vilte/matecommit/internal/config"
+	domainErrors "github.com/thomas-vilte/matecommit/internal/errors"
+	"google.golang.org/genai"
+)
+
+func TestNewClient(t *testing.T) {
+	t.Run("missing API key", func(t *testing.T) {
+		client, err := NewClient(context.Background(), &config.Config{})
+
+		assert.Nil(t, client)
+		assert.ErrorIs(t, err, domainErrors.ErrAPIKeyMissing)
+	})
+
+	t.Run("model from the active models map", func(t *testing.T) {
+		cfg := &config.Config{
+			AIProviders: map[string]config.AIProviderConfig{"gemini": {APIKey: "test-api-key", Model: "gemini-2.5-flash"}},
+			AIConfig:    config.AIConfig{Models: map[config.AI]config.Model{config.AIGemini: "gemini-pro"}},
+		}
+
+		client, err := NewClient(context.Background(), cfg)
+
+		require.NoError(t, err)
+		assert.Equal(t, "gemini-pro", client.GetModelName())
+	})
+
+	t.Run("model falls back to the provider entry and the default", func(t *testing.T) {
+		cfg := &config.Config{
+			AIProviders: map[string]config.AIProviderConfig{"gemini": {APIKey: "test-api-key", Model: "gemini-2.5-flash"}},
+		}
+		client, err := NewClient(context.Background(), cfg)
+		require.NoError(t, err)
+		assert.Equal(t, "gemini-2.5-flash", client.GetModelName())
+
+		cfg.AIProviders["gemini"] = config.AIProviderConfig{APIKey: "test-api-key"}
+		client, err = NewClient(context.Background(), cfg)
+		require.NoError(t, err)
+		assert.Equal(t, string(config.DefaultModelForAI(config.AIGemini)), client.GetModelName())
+	})
+
+	t.Run("is registered with the ai package", func(t *testing.T) {
+		cfg := &config.Config{
+			AIProviders: map[string]config.AIProviderConfig{"gemini": {APIKey: "test-api-key"}},
+		}
+
+		client, err := ai.NewLLMClient(context.Background(), cfg, config.AIGemini)
+
+		require.NoError(t, err)
+		assert.Equal(t, "gemini", client.GetProviderName())
+	})
+}
+
+func TestToGenaiSchema(t *testing.T) {
+	t.Run("nil schema", func(t *testing.T) {
+		assert.Nil(t, toGenaiSchema(nil))
+	})
+
+	t.Run("translates nested types", func(t *testing.T) {
+		schema := &ai.Schema{
+			Type:     ai.SchemaObject,
+			Required: []string{"title"},
+			Properties: map[string]*ai.Schema{
+				"title":  {Type: ai.SchemaString, Description: "The title"},
+				"labels": {Type: ai.SchemaArray, Items: &ai.Schema{Type: ai.SchemaString}},
+				"status": {Type: ai.SchemaString, Enum: []string{"open", "closed"}},
+			},
+		}
+
+		out := toGenaiSchema(schema)
+
+		assert.Equal(t, genai.TypeObject, out.Type)
+		assert.Equal(t, []string{"title"}, out.Required)
+		assert.Equal(t, genai.TypeString, out.Properties["title"].Type)
+		assert.Equal(t, "The title", out.Properties["title"].Description)
+		assert.Equal(t, genai.TypeArray, out.Properties["labels"].Type)
+		assert.Equal(t, genai.TypeString, out.Properties["labels"].Items.Type)
+		assert.Equal(t, []string{"open", "closed"}, out.Properties["status"].Enum)
+	})
+}
+
+func TestFormatResponse(t *testing.T) {
+	t.Run("joins the text parts and skips thoughts", func(t *testing.T) {
+		resp := &genai.GenerateContentResponse{
+			Candidates: []*genai.Candidate{
+				{
+					Content: &genai.Content{
+						Parts: []*genai.Part{
+							{Text: "thinking", Thought: true},
+							{Text: "Part 1"},
+							{Text: " Part 2"},
+						},
+					},
+				},
+			},
+		}
+
+		assert.Equal(t, "Part 1 Part 2", formatResponse(resp))
+	})
+
+	t.Run("nil response", func(t *testing.T) {
+		assert.Equal(t, "", formatResponse(nil))
+	})
+
+	t.Run("empty candidates", func(t *testing.T) {
+		assert.Equal(t, "", formatResponse(&genai.GenerateContentResponse{Candidates: []*genai.Candidate{}}))
+	})
+}
+
+func TestMapGenerateError(t *testing.T) {
+	assert.ErrorIs(t, mapGenerateError(errors.New("Resource exhausted")), domainErrors.ErrGeminiQuotaExceeded)
+	assert.ErrorIs(t, mapGenerateError(errors.New("API key not valid")), domainErrors.ErrGeminiAPIKeyInvalid)
+	assert.ErrorIs(t, mapGenerateError(errors.New("boom")), domainErrors.ErrAIGeneration)
+	assert.ErrorIs(t, mapGenerateError(genai.APIError{Code: 503, Message: "The model is overloaded"}), domainErrors.ErrAIServerError)
+}
@@ -1,464 +0,0 @@
-package gemini
-
-import (
-	"context"
-	"encoding/json"
-	"fmt"
-	"regexp"
-	"strings"
-
-	"github.com/thomas-vilte/matecommit/internal/ai"
-	"github.com/thomas-vilte/matecommit/internal/config"
-	domainErrors "github.com/thomas-vilte/matecommit/internal/errors"
-	"github.com/thomas-vilte/matecommit/internal/logger"
-	"github.com/thomas-vilte/matecommit/internal/models"
-	"google.golang.org/genai"
-)
-
-var _ ai.CommitSummarizer = (*GeminiCommitSummarizer)(nil)
-
-type GeminiCommitSummarizer struct {
-	*GeminiProvider
-	wrapper    *ai.CostAwareWrapper
-	generateFn ai.GenerateFunc
-	config     *config.Config
-}
-
-type (
-	CommitSuggestionJSON struct {
-		Title        string            `json:"title"`
-		Desc         string            `json:"desc"`
-		Files        []string          `json:"files"`
-		Analysis     *CodeAnalysisJSON `json:"analysis,omitempty"`
-		Requirements *RequirementsJSON `json:"requirements,omitempty"`
-	}
-
-	CodeAnalysisJSON struct {
-		OverView string `json:"overview"`
-		Purpose  string `json:"purpose"`
-		Impact   string `json:"impact"`
-	}
-
-	RequirementsJSON struct {
-		Status      string   `json:"status"`
-		Missing     []string `json:"missing"`
-		Suggestions []string `json:"suggestions"`
-	}
-)
-
-// getCommitSuggestionSchema returns the JSON schema for commit suggestions
-func getCommitSuggestionSchema() *genai.Schema {
-	return &genai.Schema{
-		Type: genai.TypeArray,
-		Items: &genai.Schema{
-			Type:     genai.TypeObject,
-			Required: []string{"title", "desc", "files"},
-			Properties: map[string]*genai.Schema{
-				"title": {
-					Type:        genai.TypeString,
-					Description: "Commit title (type(scope): message)",
-				},
-				"desc": {
-					Type:        genai.TypeString,
-					Description: "Detailed explanation in first person",
-				},
-				"files": {
-					Type: genai.TypeArray,
-					Items: &genai.Schema{
-						Type: genai.TypeString,
-					},
-					Description: "Array of file paths as strings",
-				},
-				"analysis": {
-					Type:     genai.TypeObject,
-					Required: []string{"overview", "purpose", "impact"},
-					Properties: map[string]*genai.Schema{
-						"overview": {Type: genai.TypeString},
-						"purpose":  {Type: genai.TypeString},
-						"impact":   {Type: genai.TypeString},
-					},
-				},
-				"requirements": {
-					Type:     genai.TypeObject,
-					Required: []string{"status", "missing", "completed_indices", "suggestions"},
-					Properties: map[string]*genai.Schema{
-						"status": {
-							Type: genai.TypeString,
-							Enum: []string{"full_met", "partially_met", "not_met"},
-						},
-						"missing": {
-							Type: genai.TypeArray,
-							Items: &genai.Schema{
-								Type: genai.TypeString,
-							},
-						},
-						"completed_indices": {
-							Type: genai.TypeArray,
-							Items: &genai.Schema{
-								Type: genai.TypeInteger,
-							},
-						},
-						"suggestions": {
-							Type: genai.TypeArray,
-							Items: &genai.Schema{
-								Type: genai.TypeString,
-							},
-						},
-					},
-				},
-			},
-		},
-	}
-}
-
-func NewGeminiCommitSummarizer(ctx context.Context, cfg *config.Config, onConfirmation ai.ConfirmationCallback) (*GeminiCommitSummarizer, error) {
-	providerCfg, exists := cfg.AIProviders["gemini"]
-	if !exists || providerCfg.APIKey == "" {
-		return nil, domainErrors.ErrAPIKeyMissing
-	}
-
-	client, err := genai.NewClient(ctx, &genai.ClientConfig{
-		APIKey:  providerCfg.APIKey,
-		Backend: genai.BackendGeminiAPI,
-	})
-	if err != nil {
-		errMsg := strings.ToLower(err.Error())
-		if strings.Contains(errMsg, "invalid") ||
-			strings.Contains(errMsg, "unauthorized") ||
-			strings.Contains(errMsg, "api key") ||
-			strings.Contains(errMsg, "authentication") {
-			return nil, domainErrors.ErrGeminiAPIKeyInvalid.WithError(err)
-		}
-		return nil, domainErrors.NewAppError(domainErrors.TypeAI, "error creating AI client", err)
-	}
-
-	modelName := string(cfg.AIConfig.Models[config.AIGemini])
-
-	budgetDaily := 0.0
-	if cfg.AIConfig.BudgetDaily != nil {
-		budgetDaily = *cfg.AIConfig.BudgetDaily
-	}
-
-	service := &GeminiCommitSummarizer{
-		GeminiProvider: NewGeminiProvider(client, modelName),
-		config:         cfg,
-	}
-
-	wrapper, err := ai.NewCostAwareWrapper(ai.WrapperConfig{
-		Provider:              service,
-		BudgetDaily:           budgetDaily,
-		EstimatedOutputTokens: 800,
-		OnConfirmation:        onConfirmation,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error creating wrapper: %w", err)
-	}
-
-	service.wrapper = wrapper
-	service.generateFn = service.defaultGenerate
-
-	return service, nil
-}
-
-func (s *GeminiCommitSummarizer) defaultGenerate(ctx context.Context, mName string, p string) (interface{}, *models.TokenUsage, error) {
-	log := logger.FromContext(ctx)
-	log.Debug("calling gemini API",
-		"model", mName,
-		"prompt_length", len(p))
-	schema := getCommitSuggestionSchema()
-	genConfig := GetGenerateConfig(mName, "application/json", schema)
-	resp, err := s.Client.Models.GenerateContent(ctx, mName, genai.Text(p), genConfig)
-	if err != nil {
-		log.Error("gemini API call failed",
-			"error", err,
-			"model", mName,
-		)
-		errMsg := strings.ToLower(err.Error())
-		if strings.Contains(errMsg, "quota") ||
-			strings.Contains(errMsg, "rate limit") ||
-			strings.Contains(errMsg, "resource exhausted") {
-			return nil, nil, domainErrors.ErrGeminiQuotaExceeded.WithError(err)
-		}
-		if strings.Contains(errMsg, "invalid") ||
-			strings.Contains(errMsg, "unauthorized") ||
-			strings.Contains(errMsg, "api key") {
-			return nil, nil, domainErrors.ErrGeminiAPIKeyInvalid.WithError(err)
-		}
-		return nil, nil, domainErrors.ErrAIGeneration.WithError(err)
-	}
-	usage := extractUsage(resp)
-	if usage != nil {
-		log.Debug("gemini API response received",
-			"input_tokens", usage.InputTokens,
-			"output_tokens", usage.OutputTokens,
-			"candidates", len(resp.Candidates))
-	} else {
-		log.Debug("gemini API response received",
-			"candidates", len(resp.Candidates),
-			"usage", "nil")
-	}
-	return resp, usage, nil
-}
-
-func (s *GeminiCommitSummarizer) GenerateSuggestions(ctx context.Context, info models.CommitInfo, count int) ([]models.CommitSuggestion, error) {
-	log := logger.FromContext(ctx)
-
-	log.Info("generating commit suggestions via gemini",
-		"count", count,
-		"files", len(info.Files),
-		"has_issue_info", info.IssueInfo != nil,
-		"has_ticket_info", info.TicketInfo != nil)
-
-	if count <= 0 {
-		return nil, domainErrors.NewAppError(domainErrors.TypeInternal, "invalid suggestion count", nil)
-	}
-
-	if len(info.Files) == 0 {
-		return nil, domainErrors.NewAppError(domainErrors.TypeGit, "no files to summarize", nil)
-	}
-
-	prompt := s.generatePrompt(s.config.Language, info, count)
-
-	log.Debug("prompt generated",
-		"prompt_length", len(prompt),
-		"language", s.config.Language)
-
-	resp, usage, err := s.wrapper.WrapGenerate(ctx, "suggest-commits", prompt, s.generateFn)
-	if err != nil {
-		log.Error("failed to generate suggestions",
-			"error", err)
-		return nil, err
-	}
-
-	var responseText string
-	if geminiResp, ok := resp.(*genai.GenerateContentResponse); ok {
-		log.Debug("formatResponse received GenerateContentResponse",
-			"candidates_count", len(geminiResp.Candidates))
-		responseText = formatResponse(geminiResp)
-		if len(responseText) > 0 {
-			preview := responseText
-			if len(responseText) > 100 {
-				preview = responseText[:100]
-			}
-			log.Debug("formatResponse result",
-				"response_length", len(responseText),
-				"response_preview", preview)
-		} else {
-			log.Debug("formatResponse result empty")
-		}
-	} else if str, ok := resp.(string); ok {
-		responseText = str
-		log.Debug("received string response", "length", len(str))
-	} else if respMap, ok := resp.(map[string]interface{}); ok {
-		log.Debug("received map response from cache, extracting text")
-		responseText = extractTextFromMap(respMap)
-		log.Debug("extracted text from map", "length", len(responseText))
-	} else {
-		log.Warn("unexpected response type", "type", fmt.Sprintf("%T", resp))
-	}
-
-	if responseText == "" {
-		return nil, domainErrors.ErrInvalidAIOutput.
-			WithContext("reason", "empty response from AI").
-			WithContext("operation", "generate commit suggestions")
-	}
-
-	suggestions, err := s.parseSuggestionsJSON(responseText)
-	if err != nil {
-		respLen := len(responseText)
-		preview := responseText
-		if respLen > 500 {
-			preview = responseText[:500] + "..."
-		}
-		return nil, domainErrors.ErrInvalidAIOutput.
-			WithContext("reason", "failed to parse JSON").
-			WithContext("response_length", respLen).
-			WithContext("preview", preview).
-			WithError(err)
-	}
-	if len(suggestions) == 0 {
-		log.Warn("AI generated no suggestions")
-		return nil, domainErrors.ErrInvalidAIOutput.
-			WithContext("reason", "AI generated no suggestions")
-	}
-	for i := range suggestions {
-		suggestions[i].Usage = usage
-	}
-	if info.IssueInfo != nil && info.IssueInfo.Number > 0 {
-		log.Debug("ensuring issue reference in suggestions",
-			"issue_number", info.IssueInfo.Number)
-		suggestions = s.ensureIssueReference(suggestions, info.IssueInfo.Number)
-	}
-
-	log.Info("commit suggestions generated successfully",
-		"count", len(suggestions))
-
-	return suggestions, nil
-}
-
-func (s *GeminiCommitSummarizer) parseSuggestionsJSON(responseText string) ([]models.CommitSuggestion, error) {
-	if responseText == "" {
-		return nil, fmt.Errorf("empty response text from AI")
-	}
-	var jsonSuggestions []CommitSuggestionJSON
-	if err := json.Unmarshal([]byte(responseText), &jsonSuggestions); err != nil {
-		return nil, fmt.Errorf("error parsing JSON: %w", err)
-	}
-	suggestions := make([]models.CommitSuggestion, 0, len(jsonSuggestions))
-	for _, js := range jsonSuggestions {
-		suggestion := models.CommitSuggestion{
-			CommitTitle: js.Title,
-			Explanation: js.Desc,
-			Files:       js.Files,
-		}
-		if js.Analysis != nil {
-			suggestion.CodeAnalysis = models.CodeAnalysis{
-				ChangesOverview: js.Analysis.OverView,
-				PrimaryPurpose:  js.Analysis.Purpose,
-				TechnicalImpact: js.Analysis.Impact,
-			}
-		}
-		if js.Requirements != nil {
-			suggestion.RequirementsAnalysis = models.RequirementsAnalysis{
-				CriteriaStatus:         models.CriteriaStatus(js.Requirements.Status),
-				MissingCriteria:        js.Requirements.Missing,
-				ImprovementSuggestions: js.Requirements.Suggestions,
-			}
-		}
-		suggestions = append(suggestions, suggestion)
-	}
-	return suggestions, nil
-}
-
-func (s *GeminiCommitSummarizer) generatePrompt(locale string, info models.CommitInfo, count int) string {
-	promptTemplate := ai.GetCommitPromptTemplate(locale, info.TicketInfo != nil &&
-		info.TicketInfo.TicketTitle != "")
-
-	filesFormatted := formatChanges(info.Files)
-	diffFormatted := fmt.Sprintf("```diff\n%s\n```", info.Diff)
-
-	ticketInfo := ""
-	if info.TicketInfo != nil && info.TicketInfo.TicketTitle != "" {
-		var titleLabel, descLabel, criteriaLabel string
-		if locale == "es" {
-			titleLabel = "**Título:**"
-			descLabel = "**Descripción:**"
-			criteriaLabel = "**Criterios de Aceptación:**"
-		} else {
-			titleLabel = "**Title:**"
-			descLabel = "**Description:**"
-			criteriaLabel = "**Acceptance Criteria:**"
-		}
-
-		ticketInfo = fmt.Sprintf(`%s %s
-    %s %s
-    %s
-    %s`,
-			titleLabel, info.TicketInfo.TicketTitle,
-			descLabel, info.TicketInfo.TitleDesc,
-			criteriaLabel,
-			formatCriteria(info.TicketInfo.Criteria))
-	}
-
-	issueInstructions := ""
-	if info.IssueInfo != nil && info.IssueInfo.Number > 0 {
-		num := info.IssueInfo.Number
-		data := ai.PromptData{IssueNumber: num}
-		issueInstructions, _ = ai.RenderPrompt("issueInstructions", ai.GetIssueReferenceInstructions(locale), data)
-	} else {
-		issueInstructions = ai.GetNoIssueReferenceInstruction(locale)
-	}
-
-	technicalAnalysis := ""
-	if info.TicketInfo == nil || info.TicketInfo.TicketTitle == "" {
-		technicalAnalysis = ai.GetTechnicalAnalysisInstruction(locale)
-	}
-
-	data := ai.PromptData{
-		Count:         count,
-		Files:         filesFormatted,
-		Diff:          diffFormatted,
-		Ticket:        ticketInfo,
-		History:       info.RecentHistory,
-		Instructions:  issueInstructions,
-		TechnicalInfo: technicalAnalysis,
-	}
-
-	rendered, err := ai.RenderPrompt("commitPrompt", promptTemplate, data)
-	if err != nil {
-		return "" // Handle better in production, for now we fail silently or return empty
-	}
-
-	return rendered
-}
-
-func formatChanges(files []string) string {
-	if len(files) == 0 {
-		return ""
-	}
-	formattedFiles := make([]string, len(files))
-	for i, file := range files {
-		formattedFiles[i] = fmt.Sprintf("- %s", file)
-	}
-	return strings.Join(formattedFiles, "\n")
-}
-
-func formatCriteria(criteria []string) string {
-	if len(criteria) == 0 {
-		return ""
-	}
-	formattedCriteria := make([]string, len(criteria))
-	for i, criterion := range criteria {
-		formattedCriteria[i] = fmt.Sprintf("  - %s", criterion)
-	}
-	return strings.Join(formattedCriteria, "\n")
-}
-
-// formatResponse formats the Gemini API response into a string, filtering out thinking parts.
-func formatResponse(resp *genai.GenerateContentResponse) string {
-	if resp == nil || len(resp.Candidates) == 0 {
-		return ""
-	}
-	var formattedContent strings.Builder
-	for _, cand := range resp.Candidates {
-		if cand.Content != nil {
-			for _, part := range cand.Content.Parts {
-				// Skip thinking parts - only get actual content
-				if part.Thought {
-					// This is a thinking part, skip it
-					continue
-				}
-				if part.Text != "" {
-					formattedContent.WriteString(part.Text)
-				}
-			}
-		}
-	}
-	return formattedContent.String()
-}
-
-// ensureIssueReference ensures all suggestions include the correct issue reference
-func (s *GeminiCommitSummarizer) ensureIssueReference(suggestions []models.CommitSuggestion, issueNumber int) []models.CommitSuggestion {
-	issuePattern := regexp.MustCompile(`\(#\d+\)`)
-
-	for i := range suggestions {
-		title := suggestions[i].CommitTitle
-		title = strings.TrimSpace(title)
-
-		if strings.Contains(title, fmt.Sprintf("(#%d)", issueNumber)) ||
-			strings.Contains(title, fmt.Sprintf("fixes #%d", issueNumber)) ||
-			strings.Contains(title, fmt.Sprintf("closes #%d", issueNumber)) {
-			continue
-		}
-
-		if issuePattern.MatchString(title) {
-			title = issuePattern.ReplaceAllString(title, fmt.Sprintf("(#%d)", issueNumber))
-			suggestions[i].CommitTitle = title
-			continue
-		}
-
-		suggestions[i].CommitTitle = fmt.Sprintf("%s (#%d)", title, issueNumber)
-	}
-
-	return suggestions
-}
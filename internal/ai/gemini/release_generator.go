@@ -1,394 +0,0 @@
-package gemini
-
-import (
-	"context"
-	"encoding/json"
-	"fmt"
-	"strings"
-
-	"github.com/thomas-vilte/matecommit/internal/ai"
-	"github.com/thomas-vilte/matecommit/internal/config"
-	domainErrors "github.com/thomas-vilte/matecommit/internal/errors"
-	"github.com/thomas-vilte/matecommit/internal/logger"
-	"github.com/thomas-vilte/matecommit/internal/models"
-	"google.golang.org/genai"
-)
-
-var _ ai.ReleaseNotesGenerator = (*ReleaseNotesGenerator)(nil)
-
-type ReleaseNotesGenerator struct {
-	*GeminiProvider
-	wrapper    *ai.CostAwareWrapper
-	generateFn ai.GenerateFunc
-	lang       string
-	owner      string
-	repo       string
-}
-
-type ReleaseNotesJSON struct {
-	Title      string   `json:"title"`
-	Summary    string   `json:"summary"`
-	Highlights []string `json:"highlights"`
-	Sections   []struct {
-		Title string   `json:"title"`
-		Items []string `json:"items"`
-	} `json:"sections"`
-	BreakingChanges []string `json:"breaking_changes"`
-	Contributors    string   `json:"contributors"`
-}
-
-// getReleaseNotesSchema returns the JSON schema for release notes
-func getReleaseNotesSchema() *genai.Schema {
-	return &genai.Schema{
-		Type:     genai.TypeObject,
-		Required: []string{"title", "summary", "highlights", "breaking_changes", "contributors"},
-		Properties: map[string]*genai.Schema{
-			"title": {
-				Type:        genai.TypeString,
-				Description: "Concise and descriptive title",
-			},
-			"summary": {
-				Type:        genai.TypeString,
-				Description: "2-3 sentences explaining the release focus in first person plural",
-			},
-			"sections": {
-				Type: genai.TypeArray,
-				Items: &genai.Schema{
-					Type: genai.TypeObject,
-					Properties: map[string]*genai.Schema{
-						"title": {
-							Type:        genai.TypeString,
-							Description: "Section title (e.g. '🎨 UI/UX Improvements')",
-						},
-						"items": {
-							Type: genai.TypeArray,
-							Items: &genai.Schema{
-								Type: genai.TypeString,
-							},
-							Description: "List of items in this section",
-						},
-					},
-					Required: []string{"title", "items"},
-				},
-				Description: "Categorized sections of the release notes",
-			},
-			"highlights": {
-				Type: genai.TypeArray,
-				Items: &genai.Schema{
-					Type: genai.TypeString,
-				},
-				Description: "Legacy flat list of highlights (keep empty if sections are used)",
-			},
-			"breaking_changes": {
-				Type: genai.TypeArray,
-				Items: &genai.Schema{
-					Type: genai.TypeString,
-				},
-				Description: "Array of breaking changes as strings (or [] if none)",
-			},
-			"contributors": {
-				Type:        genai.TypeString,
-				Description: "Text with contributors (e.g., 'Thanks to @user1, @user2') or 'N/A'",
-			},
-		},
-	}
-}
-
-func NewReleaseNotesGenerator(ctx context.Context, cfg *config.Config, onConfirmation ai.ConfirmationCallback, owner, repo string) (*ReleaseNotesGenerator, error) {
-	providerCfg, exists := cfg.AIProviders["gemini"]
-	if !exists || providerCfg.APIKey == "" {
-		return nil, domainErrors.ErrAPIKeyMissing
-	}
-
-	client, err := genai.NewClient(ctx, &genai.ClientConfig{
-		APIKey:  providerCfg.APIKey,
-		Backend: genai.BackendGeminiAPI,
-	})
-	if err != nil {
-		errMsg := strings.ToLower(err.Error())
-		if strings.Contains(errMsg, "invalid") ||
-			strings.Contains(errMsg, "unauthorized") ||
-			strings.Contains(errMsg, "api key") ||
-			strings.Contains(errMsg, "authentication") {
-			return nil, domainErrors.ErrGeminiAPIKeyInvalid.WithError(err)
-		}
-		return nil, domainErrors.NewAppError(domainErrors.TypeAI, "error creating AI client", err)
-	}
-
-	modelName := string(cfg.AIConfig.Models[config.AIGemini])
-
-	budgetDaily := 0.0
-	if cfg.AIConfig.BudgetDaily != nil {
-		budgetDaily = *cfg.AIConfig.BudgetDaily
-	}
-
-	service := &ReleaseNotesGenerator{
-		GeminiProvider: NewGeminiProvider(client, modelName),
-		lang:           cfg.Language,
-		owner:          owner,
-		repo:           repo,
-	}
-
-	wrapper, err := ai.NewCostAwareWrapper(ai.WrapperConfig{
-		Provider:              service,
-		BudgetDaily:           budgetDaily,
-		EstimatedOutputTokens: 700,
-		OnConfirmation:        onConfirmation,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error creating wrapper: %w", err)
-	}
-
-	service.wrapper = wrapper
-	service.generateFn = service.defaultGenerate
-
-	return service, nil
-}
-
-func (g *ReleaseNotesGenerator) defaultGenerate(ctx context.Context, mName string, p string) (interface{}, *models.TokenUsage, error) {
-	schema := getReleaseNotesSchema()
-	genConfig := GetGenerateConfig(mName, "application/json", schema)
-	log := logger.FromContext(ctx)
-	resp, err := g.Client.Models.GenerateContent(ctx, mName, genai.Text(p), genConfig)
-	if err != nil {
-		log.Error("gemini API call failed",
-			"error", err,
-			"model", mName)
-		errMsg := strings.ToLower(err.Error())
-		if strings.Contains(errMsg, "quota") ||
-			strings.Contains(errMsg, "rate limit") ||
-			strings.Contains(errMsg, "resource exhausted") {
-			return nil, nil, domainErrors.ErrGeminiQuotaExceeded.WithError(err)
-		}
-		if strings.Contains(errMsg, "invalid") ||
-			strings.Contains(errMsg, "unauthorized") ||
-			strings.Contains(errMsg, "api key") {
-			return nil, nil, domainErrors.ErrGeminiAPIKeyInvalid.WithError(err)
-		}
-		return nil, nil, domainErrors.ErrAIGeneration.WithError(err)
-	}
-	usage := extractUsage(resp)
-	return resp, usage, nil
-}
-
-func (g *ReleaseNotesGenerator) GenerateNotes(ctx context.Context, release *models.Release) (*models.ReleaseNotes, error) {
-	log := logger.FromContext(ctx)
-
-	log.Info("generating release notes via gemini",
-		"version", release.Version,
-		"previous_version", release.PreviousVersion,
-		"features_count", len(release.Features),
-		"bugfixes_count", len(release.BugFixes),
-		"breaking_count", len(release.Breaking))
-
-	prompt := g.buildPrompt(release)
-
-	log.Debug("calling gemini API for release notes",
-		"prompt_length", len(prompt))
-
-	resp, usage, err := g.wrapper.WrapGenerate(ctx, "generate-release", prompt, g.generateFn)
-	if err != nil {
-		log.Error("failed to generate release notes",
-			"error", err,
-			"version", release.Version)
-		return nil, domainErrors.NewAppError(domainErrors.TypeAI, "error generating release notes", err)
-	}
-
-	var responseText string
-	if geminiResp, ok := resp.(*genai.GenerateContentResponse); ok {
-		log.Debug("formatResponse received GenerateContentResponse",
-			"candidates_count", len(geminiResp.Candidates))
-		responseText = formatResponse(geminiResp)
-	} else if str, ok := resp.(string); ok {
-		responseText = str
-		log.Debug("received string response", "length", len(str))
-	} else if respMap, ok := resp.(map[string]interface{}); ok {
-		log.Debug("received map response from cache, extracting text")
-		responseText = extractTextFromMap(respMap)
-		log.Debug("extracted text from map", "length", len(responseText))
-	} else {
-		log.Warn("unexpected response type", "type", fmt.Sprintf("%T", resp))
-	}
-
-	if responseText == "" {
-		log.Error("empty response from gemini AI")
-		return nil, domainErrors.ErrInvalidAIOutput.
-			WithContext("reason", "empty response from AI").
-			WithContext("operation", "generate release notes")
-	}
-
-	log.Debug("gemini response received",
-		"response_length", len(responseText))
-
-	notes, err := g.parseJSONResponse(responseText, release)
-	if err != nil {
-		log.Error("failed to parse release notes response",
-			"error", err)
-		return nil, domainErrors.NewAppError(domainErrors.TypeAI, "error parsing AI JSON response", err)
-	}
-
-	notes.Usage = usage
-
-	log.Info("release notes generated successfully via gemini",
-		"title", notes.Title,
-		"highlights_count", len(notes.Highlights))
-
-	return notes, nil
-}
-
-func (g *ReleaseNotesGenerator) buildPrompt(release *models.Release) string {
-	templateStr := ai.GetReleasePromptTemplate(g.lang)
-
-	changes := g.formatChangesForPrompt(release)
-
-	data := ai.PromptData{
-		RepoOwner:       g.owner,
-		RepoName:        g.repo,
-		PreviousVersion: release.PreviousVersion,
-		CurrentVersion:  release.PreviousVersion,
-		LatestVersion:   release.Version,
-		ReleaseDate:     string(release.VersionBump),
-		Changelog:       changes,
-	}
-
-	rendered, err := ai.RenderPrompt("releasePrompt", templateStr, data)
-	if err != nil {
-		return ""
-	}
-
-	return rendered
-}
-
-func (g *ReleaseNotesGenerator) formatChangesForPrompt(release *models.Release) string {
-	var sb strings.Builder
-
-	headers := ai.GetReleaseNotesSectionHeaders(g.lang)
-
-	if len(release.Breaking) > 0 {
-		sb.WriteString(fmt.Sprintf("%s\n", headers["breaking"]))
-		for _, item := range release.Breaking {
-			sb.WriteString(fmt.Sprintf("- %s: %s\n", item.Type, item.Description))
-		}
-		sb.WriteString("\n")
-	}
-
-	if len(release.Features) > 0 {
-		sb.WriteString(fmt.Sprintf("%s\n", headers["features"]))
-		for _, item := range release.Features {
-			sb.WriteString(fmt.Sprintf("- %s: %s\n", item.Type, item.Description))
-		}
-		sb.WriteString("\n")
-	}
-
-	if len(release.BugFixes) > 0 {
-		sb.WriteString(fmt.Sprintf("%s\n", headers["fixes"]))
-		for _, item := range release.BugFixes {
-			sb.WriteString(fmt.Sprintf("- %s: %s\n", item.Type, item.Description))
-		}
-		sb.WriteString("\n")
-	}
-
-	if len(release.Improvements) > 0 {
-		sb.WriteString(fmt.Sprintf("%s\n", headers["improvements"]))
-		for _, item := range release.Improvements {
-			sb.WriteString(fmt.Sprintf("- %s: %s\n", item.Type, item.Description))
-		}
-		sb.WriteString("\n")
-	}
-
-	if len(release.ClosedIssues) > 0 {
-		sb.WriteString(fmt.Sprintf("%s\n", headers["closed_issues"]))
-		for _, issue := range release.ClosedIssues {
-			sb.WriteString(fmt.Sprintf("- #%d: %s (by @%s)\n", issue.Number, issue.Title, issue.Author))
-		}
-		sb.WriteString("\n")
-	}
-
-	if len(release.MergedPRs) > 0 {
-		sb.WriteString(fmt.Sprintf("%s\n", headers["merged_prs"]))
-		for _, pr := range release.MergedPRs {
-			sb.WriteString(fmt.Sprintf("- #%d: %s (by @%s)\n", pr.Number, pr.Title, pr.Author))
-			if pr.Description != "" {
-				lines := strings.Split(pr.Description, "\n")
-				if len(lines) > 0 && lines[0] != "" {
-					sb.WriteString(fmt.Sprintf("  Description: %s\n", lines[0]))
-				}
-			}
-		}
-		sb.WriteString("\n")
-	}
-
-	if len(release.Contributors) > 0 {
-		sb.WriteString(fmt.Sprintf("%s (%d total):\n", headers["contributors"], len(release.Contributors)))
-		for _, contributor := range release.Contributors {
-			sb.WriteString(fmt.Sprintf("- @%s\n", contributor))
-		}
-		if len(release.NewContributors) > 0 {
-			sb.WriteString(fmt.Sprintf("New contributors: %s\n", strings.Join(release.NewContributors, ", ")))
-		}
-		sb.WriteString("\n")
-	}
-
-	if release.FileStats.FilesChanged > 0 {
-		sb.WriteString(fmt.Sprintf("%s\n", headers["file_stats"]))
-		sb.WriteString(fmt.Sprintf("- Files changed: %d\n", release.FileStats.FilesChanged))
-		sb.WriteString(fmt.Sprintf("- Insertions: +%d\n", release.FileStats.Insertions))
-		sb.WriteString(fmt.Sprintf("- Deletions: -%d\n", release.FileStats.Deletions))
-		if len(release.FileStats.TopFiles) > 0 {
-			sb.WriteString("Top modified files:\n")
-			for _, file := range release.FileStats.TopFiles {
-				sb.WriteString(fmt.Sprintf("  - %s (+%d/-%d)\n", file.Path, file.Additions, file.Deletions))
-			}
-		}
-		sb.WriteString("\n")
-	}
-
-	if len(release.Dependencies) > 0 {
-		sb.WriteString(fmt.Sprintf("%s\n", headers["deps"]))
-		for _, dep := range release.Dependencies {
-			switch dep.Type {
-			case "updated":
-				sb.WriteString(fmt.Sprintf("- %s: %s → %s\n", dep.Name, dep.OldVersion, dep.NewVersion))
-			case "added":
-				sb.WriteString(fmt.Sprintf("- Added: %s %s\n", dep.Name, dep.NewVersion))
-			case "removed":
-				sb.WriteString(fmt.Sprintf("- Removed: %s %s\n", dep.Name, dep.OldVersion))
-			}
-		}
-		sb.WriteString("\n")
-	}
-
-	return sb.String()
-}
-
-func (g *ReleaseNotesGenerator) parseJSONResponse(content string, release *models.Release) (*models.ReleaseNotes, error) {
-	var jsonNotes ReleaseNotesJSON
-	if err := json.Unmarshal([]byte(content), &jsonNotes); err != nil {
-		return nil, domainErrors.NewAppError(domainErrors.TypeAI, "error parsing AI JSON response", err)
-	}
-
-	notes := &models.ReleaseNotes{
-		Title:           jsonNotes.Title,
-		Summary:         jsonNotes.Summary,
-		Highlights:      jsonNotes.Highlights,
-		BreakingChanges: jsonNotes.BreakingChanges,
-		Recommended:     release.VersionBump,
-		Links:           make(map[string]string),
-	}
-
-	if len(jsonNotes.Sections) > 0 {
-		notes.Sections = make([]models.ReleaseNotesSection, len(jsonNotes.Sections))
-		for i, s := range jsonNotes.Sections {
-			notes.Sections[i] = models.ReleaseNotesSection{
-				Title: s.Title,
-				Items: s.Items,
-			}
-		}
-	}
-
-	if jsonNotes.Contributors != "" && jsonNotes.Contributors != "N/A" {
-		notes.Links["Contributors"] = jsonNotes.Contributors
-	}
-
-	return notes, nil
-}
@@ -0,0 +1,153 @@
+package ai
+
+import (
+	"context"
+	"strings"
+
+	"github.com/thomas-vilte/matecommit/internal/config"
+	domainErrors "github.com/thomas-vilte/matecommit/internal/errors"
+	"github.com/thomas-vilte/matecommit/internal/logger"
+	"github.com/thomas-vilte/matecommit/internal/models"
+)
+
+var _ IssueContentGenerator = (*IssueContentService)(nil)
+
+// IssueContentService writes issue titles, descriptions and labels with any LLM client.
+type IssueContentService struct {
+	client     LLMClient
+	wrapper    *CostAwareWrapper
+	generateFn GenerateFunc
+	reducer    *diffReducer
+	config     *config.Config
+}
+
+type issueContentJSON struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Labels      []string `json:"labels"`
+}
+
+// NewIssueContentService creates the issue content generator on top of client.
+func NewIssueContentService(client LLMClient, cfg *config.Config, onConfirmation ConfirmationCallback) (*IssueContentService, error) {
+	wrapper, err := newServiceWrapper(client, cfg, 600, onConfirmation)
+	if err != nil {
+		return nil, err
+	}
+
+	reducer, err := newDiffReducer(client, cfg, "generate-issue")
+	if err != nil {
+		return nil, err
+	}
+
+	return &IssueContentService{
+		client:     client,
+		wrapper:    wrapper,
+		generateFn: commandGenerateFunc(client, cfg, "generate-issue"),
+		reducer:    reducer,
+		config:     cfg,
+	}, nil
+}
+
+// GenerateIssueContent generates issue content using the configured AI.
+func (s *IssueContentService) GenerateIssueContent(ctx context.Context, request models.IssueGenerationRequest) (*models.IssueGenerationResult, error) {
+	log := logger.FromContext(ctx)
+
+	log.Info("generating issue content",
+		"provider", s.client.GetProviderName(),
+		"has_diff", request.Diff != "",
+		"has_description", request.Description != "",
+		"has_hint", request.Hint != "",
+		"files_count", len(request.ChangedFiles))
+
+	if request.Diff != "" {
+		diff, err := s.reducer.Reduce(ctx, request.Diff)
+		if err != nil {
+			log.Error("failed to reduce diff",
+				"error", err)
+			return nil, domainErrors.NewAppError(domainErrors.TypeAI, "error summarizing large diff", err)
+		}
+		request.Diff = diff
+	}
+
+	prompt := s.buildIssuePrompt(request)
+
+	log.Debug("calling AI for issue content",
+		"prompt_length", len(prompt))
+
+	resp, usage, err := s.wrapper.WrapGenerate(withFileCount(ctx, len(request.ChangedFiles)), "generate-issue", prompt, s.generateFn)
+	if err != nil {
+		log.Error("failed to generate issue content",
+			"error", err)
+		return nil, domainErrors.NewAppError(domainErrors.TypeAI, "error generating issue content", err)
+	}
+
+	responseText := extractResponseText(resp)
+	if responseText == "" {
+		log.Error("empty response from AI")
+		return nil, domainErrors.NewAppError(domainErrors.TypeAI, "empty response from AI", nil)
+	}
+
+	log.Debug("AI response received",
+		"response_length", len(responseText),
+		"response_text", responseText)
+
+	result, err := s.parseIssueResponse(responseText)
+	if err != nil {
+		log.Error("failed to parse issue response",
+			"error", err)
+		return nil, domainErrors.NewAppError(domainErrors.TypeAI, "error parsing AI response", err)
+	}
+
+	result.Labels = CleanLabels(result.Labels, request.AvailableLabels)
+	result.Usage = usage
+
+	log.Info("issue content generated successfully",
+		"title", result.Title,
+		"labels_count", len(result.Labels))
+
+	return result, nil
+}
+
+// buildIssuePrompt builds the prompt to generate issue content.
+func (s *IssueContentService) buildIssuePrompt(request models.IssueGenerationRequest) string {
+	return BuildIssuePrompt(request)
+}
+
+// parseIssueResponse parses the JSON answer; plain text becomes the description of a generic issue.
+func (s *IssueContentService) parseIssueResponse(content string) (*models.IssueGenerationResult, error) {
+	if content == "" {
+		logger.Error(context.Background(), "received empty response from AI", nil)
+		return nil, domainErrors.NewAppError(domainErrors.TypeAI, "empty response from AI", nil)
+	}
+
+	content = strings.TrimSpace(content)
+
+	jsonResult, err := decodeJSON[issueContentJSON](content)
+	if err != nil {
+		logger.Warn(context.Background(), "failed to unmarshal JSON, using fallback",
+			"error", err.Error(),
+			"content", content)
+		return &models.IssueGenerationResult{
+			Title:       "Generated Issue",
+			Description: content,
+			Labels:      []string{},
+		}, nil
+	}
+
+	logger.Debug(context.Background(), "successfully parsed JSON",
+		"title", jsonResult.Title,
+		"description_length", len(jsonResult.Description),
+		"labels_count", len(jsonResult.Labels))
+
+	result := &models.IssueGenerationResult{
+		Title:       strings.TrimSpace(jsonResult.Title),
+		Description: strings.TrimSpace(jsonResult.Description),
+		Labels:      jsonResult.Labels,
+	}
+
+	if result.Title == "" {
+		result.Title = "Generated Issue"
+	}
+
+	return result, nil
+}
@@ -0,0 +1,233 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/thomas-vilte/matecommit/internal/config"
+	"github.com/thomas-vilte/matecommit/internal/models"
+)
+
+// SchemaType is the JSON type of a schema node.
+type SchemaType string
+
+const (
+	SchemaObject  SchemaType = "object"
+	SchemaArray   SchemaType = "array"
+	SchemaString  SchemaType = "string"
+	SchemaInteger SchemaType = "integer"
+	SchemaNumber  SchemaType = "number"
+	SchemaBoolean SchemaType = "boolean"
+)
+
+// Schema is a provider-neutral JSON schema describing the structured output a feature expects.
+// Every LLM client translates it to the format of its backend.
+type Schema struct {
+	Type        SchemaType
+	Description string
+	Properties  map[string]*Schema
+	Items       *Schema
+	Required    []string
+	Enum        []string
+}
+
+// JSONSchema renders the schema as a standard JSON schema document.
+// In strict mode every property is required, as OpenAI Structured Outputs demand.
+func (s *Schema) JSONSchema(strict bool) map[string]interface{} {
+	if s == nil {
+		return nil
+	}
+
+	out := map[string]interface{}{"type": string(s.Type)}
+	if s.Description != "" {
+		out["description"] = s.Description
+	}
+	if len(s.Enum) > 0 {
+		out["enum"] = s.Enum
+	}
+	if s.Items != nil {
+		out["items"] = s.Items.JSONSchema(strict)
+	}
+
+	if s.Type == SchemaObject {
+		properties := make(map[string]interface{}, len(s.Properties))
+		for name, property := range s.Properties {
+			properties[name] = property.JSONSchema(strict)
+		}
+		out["properties"] = properties
+		out["additionalProperties"] = false
+
+		required := s.Required
+		if strict {
+			required = s.PropertyNames()
+		}
+		if len(required) > 0 {
+			out["required"] = required
+		}
+	}
+
+	return out
+}
+
+// PropertyNames returns the property names of an object schema in a stable order.
+func (s *Schema) PropertyNames() []string {
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LLMRequest is a single-turn generation request.
+type LLMRequest struct {
+	Model  string
+	Prompt string
+	// SchemaName identifies the structured output for backends that need a name (tools, json_schema)
+	SchemaName string
+	// Schema is the expected JSON output; nil asks for plain text
+	Schema *Schema
+	// Temperature and MaxTokens override the client settings when they are set
+	Temperature float32
+	MaxTokens   int
+}
+
+// LLMResponse is the text produced by the model plus the tokens it consumed.
+type LLMResponse struct {
+	Text  string
+	Usage *models.TokenUsage
+}
+
+// LLMClient is the adapter each AI backend implements. Feature services only talk to this interface.
+type LLMClient interface {
+	CostAwareAIProvider
+
+	// Generate sends the request and returns the raw text of the answer.
+	Generate(ctx context.Context, req LLMRequest) (*LLMResponse, error)
+}
+
+// ClientFactory builds the LLM client of a provider from the configuration.
+type ClientFactory func(ctx context.Context, cfg *config.Config) (LLMClient, error)
+
+var (
+	clientFactoriesMu sync.RWMutex
+	clientFactories   = map[string]ClientFactory{}
+)
+
+// RegisterClientFactory makes a provider available to NewLLMClient. Provider packages call it from init.
+func RegisterClientFactory(provider string, factory ClientFactory) {
+	clientFactoriesMu.Lock()
+	defer clientFactoriesMu.Unlock()
+	clientFactories[provider] = factory
+}
+
+// NewLLMClient creates the client of the given provider.
+func NewLLMClient(ctx context.Context, cfg *config.Config, provider config.AI) (LLMClient, error) {
+	clientFactoriesMu.RLock()
+	factory, ok := clientFactories[string(provider)]
+	clientFactoriesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported AI provider: %s", provider)
+	}
+	return factory(ctx, cfg)
+}
+
+// newGenerateFunc adapts a client to the GenerateFunc used by the cost-aware wrapper.
+// Every call sends base with the model and prompt chosen by the wrapper.
+// The response is the raw text, so it can be cached and replayed by any service.
+// When ctx carries a stream sink, streaming clients show their output while it is generated.
+func newGenerateFunc(client LLMClient, base LLMRequest) GenerateFunc {
+	return func(ctx context.Context, model string, prompt string) (interface{}, *models.TokenUsage, error) {
+		req := base
+		req.Model = model
+		req.Prompt = prompt
+
+		resp, err := generate(ctx, client, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.Text, resp.Usage, nil
+	}
+}
+
+// commandGenerateFunc returns the generate function of a client for one of the wrapped commands,
+// with the generation parameters configured for that command.
+func commandGenerateFunc(client LLMClient, cfg *config.Config, command string) GenerateFunc {
+	name, schema := schemaForCommand(command)
+	settings := commandSettings(cfg, command)
+	return newGenerateFunc(client, LLMRequest{
+		SchemaName:  name,
+		Schema:      schema,
+		Temperature: settings.Temperature,
+		MaxTokens:   settings.MaxTokens,
+	})
+}
+
+// commandConfigKeys maps the commands tracked by the wrapper to their key in ai_config.commands
+var commandConfigKeys = map[string]string{
+	"suggest-commits":  config.CommandSuggest,
+	"summarize-pr":     config.CommandSummarizePR,
+	"generate-release": config.CommandRelease,
+	"generate-issue":   config.CommandIssue,
+}
+
+// commandKey returns the configuration key of a wrapped command; unknown commands keep their name.
+func commandKey(command string) string {
+	if key, ok := commandConfigKeys[command]; ok {
+		return key
+	}
+	return command
+}
+
+// WrappedCommands returns the names the wrapper records for a command: those of a configuration key
+// (suggest is recorded as suggest-commits), or the name itself for any other command.
+func WrappedCommands(name string) []string {
+	var commands []string
+	for command, key := range commandConfigKeys {
+		if key == name {
+			commands = append(commands, command)
+		}
+	}
+	if len(commands) == 0 {
+		return []string{name}
+	}
+	return commands
+}
+
+// commandSettings returns the model and parameters configured for a wrapped command.
+func commandSettings(cfg *config.Config, command string) config.CommandAIConfig {
+	key, ok := commandConfigKeys[command]
+	if cfg == nil || !ok {
+		return config.CommandAIConfig{}
+	}
+	return cfg.AIConfig.CommandConfig(key)
+}
+
+type fileCountKey struct{}
+
+// withFileCount records how many files the diff of the call touches, for the routing rules.
+func withFileCount(ctx context.Context, count int) context.Context {
+	return context.WithValue(ctx, fileCountKey{}, count)
+}
+
+// fileCountFromContext returns the file count recorded by withFileCount, or 0 when it is unknown.
+func fileCountFromContext(ctx context.Context) int {
+	count, _ := ctx.Value(fileCountKey{}).(int)
+	return count
+}
+
+// extractResponseText returns the text of a wrapped response; anything but a string means there is no usable answer.
+func extractResponseText(resp interface{}) string {
+	text, _ := resp.(string)
+	return text
+}
+
+// decodeJSON decodes the text of a structured response into its typed form.
+func decodeJSON[T any](text string) (T, error) {
+	var out T
+	err := json.Unmarshal([]byte(text), &out)
+	return out, err
+}
@@ -0,0 +1,168 @@
+package ai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomas-vilte/matecommit/internal/config"
+	"github.com/thomas-vilte/matecommit/internal/models"
+)
+
+// fakeLLMClient is an LLMClient that answers every request with the same text or error.
+type fakeLLMClient struct {
+	provider string
+	model    string
+	text     string
+	usage    *models.TokenUsage
+	err      error
+	requests []LLMRequest
+}
+
+func (f *fakeLLMClient) GetProviderName() string { return f.provider }
+
+func (f *fakeLLMClient) GetModelName() string { return f.model }
+
+func (f *fakeLLMClient) CountTokens(_ context.Context, text string) (int, error) {
+	return len(text) / 4, nil
+}
+
+func (f *fakeLLMClient) Generate(_ context.Context, req LLMRequest) (*LLMResponse, error) {
+	f.requests = append(f.requests, req)
+	if f.err != nil {
+		return nil, f.err
+	}
+	usage := f.usage
+	if usage == nil {
+		usage = &models.TokenUsage{InputTokens: 10, OutputTokens: 5, TotalTokens: 15}
+	}
+	return &LLMResponse{Text: f.text, Usage: usage}, nil
+}
+
+func TestSchemaJSONSchema(t *testing.T) {
+	schema := &Schema{
+		Type:     SchemaObject,
+		Required: []string{"title"},
+		Properties: map[string]*Schema{
+			"title":  {Type: SchemaString, Description: "The title"},
+			"labels": stringArraySchema(""),
+			"status": {Type: SchemaString, Enum: []string{"open", "closed"}},
+		},
+	}
+
+	t.Run("non-strict keeps the declared required fields", func(t *testing.T) {
+		out := schema.JSONSchema(false)
+
+		assert.Equal(t, "object", out["type"])
+		assert.Equal(t, false, out["additionalProperties"])
+		assert.Equal(t, []string{"title"}, out["required"])
+
+		properties := out["properties"].(map[string]interface{})
+		assert.Equal(t, "The title", properties["title"].(map[string]interface{})["description"])
+		assert.Equal(t, []string{"open", "closed"}, properties["status"].(map[string]interface{})["enum"])
+		assert.Equal(t, map[string]interface{}{"type": "string"}, properties["labels"].(map[string]interface{})["items"])
+	})
+
+	t.Run("strict requires every property", func(t *testing.T) {
+		out := schema.JSONSchema(true)
+
+		assert.Equal(t, []string{"labels", "status", "title"}, out["required"])
+	})
+
+	t.Run("nil schema", func(t *testing.T) {
+		var nilSchema *Schema
+		assert.Nil(t, nilSchema.JSONSchema(true))
+	})
+}
+
+func TestNewLLMClient(t *testing.T) {
+	t.Run("unsupported provider", func(t *testing.T) {
+		client, err := NewLLMClient(context.Background(), &config.Config{}, "unknown")
+
+		assert.Nil(t, client)
+		assert.EqualError(t, err, "unsupported AI provider: unknown")
+	})
+
+	t.Run("registered provider", func(t *testing.T) {
+		fake := &fakeLLMClient{provider: "test-registered"}
+		RegisterClientFactory("test-registered", func(ctx context.Context, cfg *config.Config) (LLMClient, error) {
+			return fake, nil
+		})
+
+		client, err := NewLLMClient(context.Background(), &config.Config{}, "test-registered")
+
+		require.NoError(t, err)
+		assert.Same(t, fake, client)
+	})
+}
+
+func TestCommandGenerateFunc(t *testing.T) {
+	t.Run("sends the schema of the command", func(t *testing.T) {
+		// Arrange
+		fake := &fakeLLMClient{text: `{"title":"ok"}`}
+		generate := commandGenerateFunc(fake, nil, "summarize-pr")
+
+		// Act
+		resp, usage, err := generate(context.Background(), "model-x", "prompt")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, `{"title":"ok"}`, resp)
+		assert.Equal(t, 15, usage.TotalTokens)
+		require.Len(t, fake.requests, 1)
+		assert.Equal(t, "model-x", fake.requests[0].Model)
+		assert.Equal(t, "prompt", fake.requests[0].Prompt)
+		assert.Equal(t, "pr_summary", fake.requests[0].SchemaName)
+		assert.NotNil(t, fake.requests[0].Schema)
+		assert.Zero(t, fake.requests[0].Temperature)
+	})
+
+	t.Run("applies the parameters configured for the command", func(t *testing.T) {
+		// Arrange
+		fake := &fakeLLMClient{text: "{}"}
+		cfg := &config.Config{AIConfig: config.AIConfig{Commands: map[string]config.CommandAIConfig{
+			config.CommandIssue:   {Temperature: 0.7, MaxTokens: 2000},
+			config.CommandRelease: {Temperature: 0.1},
+		}}}
+		generate := commandGenerateFunc(fake, cfg, "generate-issue")
+
+		// Act
+		_, _, err := generate(context.Background(), "model-x", "prompt")
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, fake.requests, 1)
+		assert.Equal(t, float32(0.7), fake.requests[0].Temperature)
+		assert.Equal(t, 2000, fake.requests[0].MaxTokens)
+	})
+}
+
+func TestDecodeSuggestions(t *testing.T) {
+	t.Run("object envelope", func(t *testing.T) {
+		suggestions, err := decodeSuggestions(`{"suggestions":[{"title":"feat: a","desc":"d","files":["a.go"]}]}`)
+
+		require.NoError(t, err)
+		require.Len(t, suggestions, 1)
+		assert.Equal(t, "feat: a", suggestions[0].Title)
+	})
+
+	t.Run("bare array", func(t *testing.T) {
+		suggestions, err := decodeSuggestions(` [{"title":"fix: b","desc":"d","files":[]}]`)
+
+		require.NoError(t, err)
+		require.Len(t, suggestions, 1)
+		assert.Equal(t, "fix: b", suggestions[0].Title)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := decodeSuggestions("not json")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestWrappedCommands(t *testing.T) {
+	assert.Equal(t, []string{"suggest-commits"}, WrappedCommands(config.CommandSuggest))
+	assert.Equal(t, []string{"summarize-diff"}, WrappedCommands("summarize-diff"))
+}
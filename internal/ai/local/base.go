@@ -0,0 +1,128 @@
+package local
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/thomas-vilte/matecommit/internal/ai"
+	"github.com/thomas-vilte/matecommit/internal/config"
+)
+
+const (
+	defaultBaseURL     = "http://localhost:11434"
+	defaultTemperature = 0.3
+	defaultMaxTokens   = 10000
+	// Local models on consumer hardware can be slow, so the timeout is more generous than for hosted APIs.
+	requestTimeout = 5 * time.Minute
+)
+
+// apiStyle identifies the HTTP API spoken by the local server.
+type apiStyle string
+
+const (
+	// apiOllama is Ollama's native /api/chat endpoint.
+	apiOllama apiStyle = "ollama"
+	// apiOpenAICompatible is the /v1/chat/completions endpoint exposed by llama.cpp, vLLM, LM Studio and Ollama itself.
+	apiOpenAICompatible apiStyle = "openai-compatible"
+)
+
+var _ ai.LLMClient = (*LocalProvider)(nil)
+
+func init() {
+	ai.RegisterClientFactory(string(config.AILocal), func(ctx context.Context, cfg *config.Config) (ai.LLMClient, error) {
+		return NewClient(ctx, cfg), nil
+	})
+}
+
+// LocalProvider is the self-hosted model adapter of the ai.LLMClient interface
+type LocalProvider struct {
+	apiKey      string
+	baseURL     string
+	style       apiStyle
+	httpClient  *http.Client
+	model       string
+	temperature float32
+	maxTokens   int
+}
+
+// NewLocalProvider creates a new instance of LocalProvider.
+// A base URL ending in /v1 selects the OpenAI-compatible API; anything else is treated as Ollama.
+func NewLocalProvider(baseURL, apiKey, model string) *LocalProvider {
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	return &LocalProvider{
+		apiKey:      apiKey,
+		baseURL:     baseURL,
+		style:       detectAPIStyle(baseURL),
+		httpClient:  &http.Client{Timeout: requestTimeout},
+		model:       model,
+		temperature: defaultTemperature,
+		maxTokens:   defaultMaxTokens,
+	}
+}
+
+func detectAPIStyle(baseURL string) apiStyle {
+	if strings.HasSuffix(baseURL, "/v1") {
+		return apiOpenAICompatible
+	}
+	return apiOllama
+}
+
+// NewClient builds the provider from the "local" entry of the configuration.
+// Unlike hosted providers, the entry is optional: without it the default Ollama address is used.
+func NewClient(_ context.Context, cfg *config.Config) *LocalProvider {
+	providerCfg := cfg.AIProviders[string(config.AILocal)]
+
+	modelName := string(cfg.AIConfig.Models[config.AILocal])
+	if modelName == "" {
+		modelName = providerCfg.Model
+	}
+	if modelName == "" {
+		modelName = string(config.DefaultModelForAI(config.AILocal))
+	}
+
+	provider := NewLocalProvider(providerCfg.BaseURL, providerCfg.APIKey, modelName)
+	if providerCfg.Temperature > 0 {
+		provider.temperature = providerCfg.Temperature
+	}
+	if providerCfg.MaxTokens > 0 {
+		provider.maxTokens = providerCfg.MaxTokens
+	}
+
+	return provider
+}
+
+// CountTokens implements ai.CostAwareAIProvider.
+// Local servers have no common token counting endpoint, so the wrapper falls back to its local estimation.
+func (p *LocalProvider) CountTokens(_ context.Context, _ string) (int, error) {
+	return 0, fmt.Errorf("token counting not supported by local provider")
+}
+
+// GetModelName implements ai.CostAwareAIProvider
+func (p *LocalProvider) GetModelName() string {
+	return p.model
+}
+
+// GetProviderName implements ai.CostAwareAIProvider.
+// There is no pricing for "local", so every call is recorded with zero cost.
+func (p *LocalProvider) GetProviderName() string {
+	return "local"
+}
+
+// withParams returns a copy of the provider that uses the generation parameters of the request
+func (p *LocalProvider) withParams(req ai.LLMRequest) *LocalProvider {
+	provider := *p
+	if req.Temperature > 0 {
+		provider.temperature = req.Temperature
+	}
+	if req.MaxTokens > 0 {
+		provider.maxTokens = req.MaxTokens
+	}
+	return &provider
+}
@@ -0,0 +1,373 @@
+package local
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/thomas-vilte/matecommit/internal/ai"
+	domainErrors "github.com/thomas-vilte/matecommit/internal/errors"
+	"github.com/thomas-vilte/matecommit/internal/logger"
+	"github.com/thomas-vilte/matecommit/internal/models"
+)
+
+type (
+	ChatMessage struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	}
+
+	// ollamaChatRequest is the body sent to Ollama's /api/chat endpoint.
+	ollamaChatRequest struct {
+		Model    string                 `json:"model"`
+		Messages []ChatMessage          `json:"messages"`
+		Stream   bool                   `json:"stream"`
+		Format   map[string]interface{} `json:"format,omitempty"`
+		Options  map[string]interface{} `json:"options,omitempty"`
+	}
+
+	// ollamaChatResponse is the answer of /api/chat, or one line of it when streaming.
+	ollamaChatResponse struct {
+		Model           string      `json:"model"`
+		Message         ChatMessage `json:"message"`
+		Done            bool        `json:"done"`
+		PromptEvalCount int         `json:"prompt_eval_count"`
+		EvalCount       int         `json:"eval_count"`
+		Error           string      `json:"error,omitempty"`
+	}
+
+	// chatCompletionRequest is the body sent to an OpenAI-compatible /chat/completions endpoint.
+	chatCompletionRequest struct {
+		Model          string          `json:"model"`
+		Messages       []ChatMessage   `json:"messages"`
+		Temperature    float32         `json:"temperature,omitempty"`
+		MaxTokens      int             `json:"max_tokens,omitempty"`
+		ResponseFormat *responseFormat `json:"response_format,omitempty"`
+		Stream         bool            `json:"stream,omitempty"`
+		StreamOptions  *streamOptions  `json:"stream_options,omitempty"`
+	}
+
+	streamOptions struct {
+		IncludeUsage bool `json:"include_usage"`
+	}
+
+	responseFormat struct {
+		Type       string      `json:"type"`
+		JSONSchema *jsonSchema `json:"json_schema,omitempty"`
+	}
+
+	jsonSchema struct {
+		Name   string                 `json:"name"`
+		Schema map[string]interface{} `json:"schema"`
+		Strict bool                   `json:"strict"`
+	}
+
+	// chatCompletionChunk is one server-sent event of a streamed OpenAI-compatible completion.
+	chatCompletionChunk struct {
+		Model   string `json:"model"`
+		Choices []struct {
+			Delta ChatMessage `json:"delta"`
+		} `json:"choices"`
+		Usage *struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+		} `json:"usage,omitempty"`
+	}
+
+	chatCompletionResponse struct {
+		Model   string `json:"model"`
+		Choices []struct {
+			Message ChatMessage `json:"message"`
+		} `json:"choices"`
+		Usage *struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+		} `json:"usage,omitempty"`
+	}
+
+	// ChatResponse is the normalized answer of both local APIs.
+	ChatResponse struct {
+		Model        string `json:"model"`
+		Content      string `json:"content"`
+		InputTokens  int    `json:"input_tokens"`
+		OutputTokens int    `json:"output_tokens"`
+	}
+)
+
+// Generate implements ai.LLMClient.
+// Only the OpenAI-compatible API enforces strict schemas; Ollama takes the schema as its output format.
+func (p *LocalProvider) Generate(ctx context.Context, req ai.LLMRequest) (*ai.LLMResponse, error) {
+	model := req.Model
+	if model == "" {
+		model = p.model
+	}
+
+	resp, err := p.withParams(req).chat(ctx, model, req.Prompt, req.SchemaName, req.Schema.JSONSchema(p.style == apiOpenAICompatible))
+	if err != nil {
+		return nil, err
+	}
+	return &ai.LLMResponse{Text: resp.Content, Usage: extractUsage(resp)}, nil
+}
+
+// GenerateStream implements ai.StreamingClient; both local APIs stream the answer when asked to.
+func (p *LocalProvider) GenerateStream(ctx context.Context, req ai.LLMRequest, onChunk func(text string)) (*ai.LLMResponse, error) {
+	model := req.Model
+	if model == "" {
+		model = p.model
+	}
+
+	resp, err := p.withParams(req).complete(ctx, model, req.Prompt, req.SchemaName, req.Schema.JSONSchema(p.style == apiOpenAICompatible), onChunk)
+	if err != nil {
+		return nil, err
+	}
+	return &ai.LLMResponse{Text: resp.Content, Usage: extractUsage(resp)}, nil
+}
+
+// chat sends a single-turn prompt and asks for a JSON answer that matches the given schema.
+func (p *LocalProvider) chat(ctx context.Context, model, prompt, schemaName string, schema map[string]interface{}) (*ChatResponse, error) {
+	return p.complete(ctx, model, prompt, schemaName, schema, nil)
+}
+
+// complete sends the prompt to the configured API; a non-nil onChunk streams the answer through it.
+func (p *LocalProvider) complete(ctx context.Context, model, prompt, schemaName string, schema map[string]interface{}, onChunk func(text string)) (*ChatResponse, error) {
+	log := logger.FromContext(ctx)
+
+	log.Debug("calling local model",
+		"base_url", p.baseURL,
+		"api", p.style,
+		"model", model,
+		"prompt_length", len(prompt),
+		"stream", onChunk != nil)
+
+	var (
+		resp *ChatResponse
+		err  error
+	)
+	if p.style == apiOpenAICompatible {
+		resp, err = p.chatOpenAICompatible(ctx, model, prompt, schemaName, schema, onChunk)
+	} else {
+		resp, err = p.chatOllama(ctx, model, prompt, schema, onChunk)
+	}
+	if err != nil {
+		log.Error("local model call failed",
+			"error", err,
+			"base_url", p.baseURL,
+			"model", model)
+		return nil, err
+	}
+
+	log.Debug("local model response received",
+		"response_length", len(resp.Content),
+		"input_tokens", resp.InputTokens,
+		"output_tokens", resp.OutputTokens)
+
+	return resp, nil
+}
+
+func (p *LocalProvider) chatOllama(ctx context.Context, model, prompt string, schema map[string]interface{}, onChunk func(text string)) (*ChatResponse, error) {
+	httpResp, err := p.send(ctx, "/api/chat", ollamaChatRequest{
+		Model:    model,
+		Messages: []ChatMessage{{Role: "user", Content: prompt}},
+		Stream:   onChunk != nil,
+		Format:   schema,
+		Options: map[string]interface{}{
+			"temperature": p.temperature,
+			"num_predict": p.maxTokens,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = httpResp.Body.Close()
+	}()
+
+	// Without streaming the body is a single line, so both cases are read the same way.
+	resp := &ChatResponse{Model: model}
+	var content strings.Builder
+	err = ai.ReadJSONLines(httpResp.Body, func(line []byte) error {
+		var ollamaResp ollamaChatResponse
+		if err := json.Unmarshal(line, &ollamaResp); err != nil {
+			return domainErrors.ErrInvalidAIOutput.WithError(fmt.Errorf("error decoding response: %w", err))
+		}
+		if ollamaResp.Error != "" {
+			return domainErrors.ErrAIGeneration.WithError(fmt.Errorf("local model server failed: %s", ollamaResp.Error))
+		}
+		if ollamaResp.Model != "" {
+			resp.Model = ollamaResp.Model
+		}
+		if ollamaResp.Message.Content != "" {
+			content.WriteString(ollamaResp.Message.Content)
+			if onChunk != nil {
+				onChunk(ollamaResp.Message.Content)
+			}
+		}
+		if ollamaResp.Done || onChunk == nil {
+			resp.InputTokens = ollamaResp.PromptEvalCount
+			resp.OutputTokens = ollamaResp.EvalCount
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Content = content.String()
+	return resp, nil
+}
+
+func (p *LocalProvider) chatOpenAICompatible(ctx context.Context, model, prompt, schemaName string, schema map[string]interface{}, onChunk func(text string)) (*ChatResponse, error) {
+	reqBody := chatCompletionRequest{
+		Model:       model,
+		Messages:    []ChatMessage{{Role: "user", Content: prompt}},
+		Temperature: p.temperature,
+		MaxTokens:   p.maxTokens,
+	}
+	if schema != nil {
+		reqBody.ResponseFormat = &responseFormat{
+			Type:       "json_schema",
+			JSONSchema: &jsonSchema{Name: schemaName, Schema: schema, Strict: true},
+		}
+	}
+	if onChunk != nil {
+		return p.streamOpenAICompatible(ctx, reqBody, onChunk)
+	}
+
+	body, err := p.post(ctx, "/chat/completions", reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var chatResp chatCompletionResponse
+	if err := json.Unmarshal(body, &chatResp); err != nil {
+		return nil, domainErrors.ErrInvalidAIOutput.WithError(fmt.Errorf("error decoding response: %w", err))
+	}
+
+	resp := &ChatResponse{Model: chatResp.Model}
+	if len(chatResp.Choices) > 0 {
+		resp.Content = chatResp.Choices[0].Message.Content
+	}
+	if chatResp.Usage != nil {
+		resp.InputTokens = chatResp.Usage.PromptTokens
+		resp.OutputTokens = chatResp.Usage.CompletionTokens
+	}
+	return resp, nil
+}
+
+// streamOpenAICompatible sends reqBody as a streamed completion and joins the deltas.
+// Servers that ignore stream_options simply report no usage.
+func (p *LocalProvider) streamOpenAICompatible(ctx context.Context, reqBody chatCompletionRequest, onChunk func(text string)) (*ChatResponse, error) {
+	reqBody.Stream = true
+	reqBody.StreamOptions = &streamOptions{IncludeUsage: true}
+
+	httpResp, err := p.send(ctx, "/chat/completions", reqBody)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = httpResp.Body.Close()
+	}()
+
+	resp := &ChatResponse{Model: reqBody.Model}
+	var content strings.Builder
+	err = ai.ReadEventStream(httpResp.Body, func(data []byte) error {
+		var chunk chatCompletionChunk
+		if err := json.Unmarshal(data, &chunk); err != nil {
+			return domainErrors.ErrInvalidAIOutput.WithError(fmt.Errorf("error decoding stream chunk: %w", err))
+		}
+		if chunk.Model != "" {
+			resp.Model = chunk.Model
+		}
+		if chunk.Usage != nil {
+			resp.InputTokens = chunk.Usage.PromptTokens
+			resp.OutputTokens = chunk.Usage.CompletionTokens
+		}
+		if len(chunk.Choices) > 0 && chunk.Choices[0].Delta.Content != "" {
+			content.WriteString(chunk.Choices[0].Delta.Content)
+			onChunk(chunk.Choices[0].Delta.Content)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Content = content.String()
+	return resp, nil
+}
+
+func (p *LocalProvider) post(ctx context.Context, path string, payload interface{}) ([]byte, error) {
+	resp, err := p.send(ctx, path, payload)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, domainErrors.ErrAIGeneration.WithError(fmt.Errorf("error reading response: %w", err))
+	}
+	return body, nil
+}
+
+// send posts payload to path and returns the response when the server accepted it.
+// The caller closes the body.
+func (p *LocalProvider) send(ctx context.Context, path string, payload interface{}) (*http.Response, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, domainErrors.ErrAIGeneration.WithError(fmt.Errorf("error encoding request: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return nil, domainErrors.ErrAIGeneration.WithError(fmt.Errorf("error creating request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, domainErrors.ErrLocalAIUnreachable.
+			WithContext("base_url", p.baseURL).
+			WithError(err)
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, domainErrors.ErrAIGeneration.WithError(fmt.Errorf("error reading response: %w", err))
+	}
+	appErr := domainErrors.ErrAIGeneration
+	if resp.StatusCode >= http.StatusInternalServerError {
+		appErr = domainErrors.ErrAIServerError
+	}
+	return nil, appErr.
+		WithContext("status", resp.StatusCode).
+		WithError(fmt.Errorf("local model server returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body))))
+}
+
+// extractUsage extracts usage metadata from the local model response.
+// It is returned even when the server reports no counts, so the call still shows up in the history.
+func extractUsage(resp *ChatResponse) *models.TokenUsage {
+	if resp == nil {
+		return nil
+	}
+	return &models.TokenUsage{
+		InputTokens:  resp.InputTokens,
+		OutputTokens: resp.OutputTokens,
+		TotalTokens:  resp.InputTokens + resp.OutputTokens,
+	}
+}
@@ -0,0 +1,314 @@
+package local
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomas-vilte/matecommit/internal/ai"
+	"github.com/thomas-vilte/matecommit/internal/config"
+	domainErrors "github.com/thomas-vilte/matecommit/internal/errors"
+)
+
+// newOllamaServer stands in for Ollama's /api/chat endpoint and answers with content.
+func newOllamaServer(t *testing.T, content string, received *ollamaChatRequest) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/chat", r.URL.Path)
+		if received != nil {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(received))
+		}
+		_ = json.NewEncoder(w).Encode(ollamaChatResponse{
+			Model:           "llama3.1",
+			Message:         ChatMessage{Role: "assistant", Content: content},
+			PromptEvalCount: 120,
+			EvalCount:       30,
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDetectAPIStyle(t *testing.T) {
+	assert.Equal(t, apiOllama, detectAPIStyle("http://localhost:11434"))
+	assert.Equal(t, apiOpenAICompatible, detectAPIStyle("http://localhost:8080/v1"))
+	assert.Equal(t, apiOpenAICompatible, NewLocalProvider("http://localhost:1234/v1/", "", "m").style)
+	assert.Equal(t, defaultBaseURL, NewLocalProvider("", "", "m").baseURL)
+}
+
+func TestChat(t *testing.T) {
+	t.Run("ollama API", func(t *testing.T) {
+		// Arrange
+		var received ollamaChatRequest
+		server := newOllamaServer(t, `{"title":"ok"}`, &received)
+		provider := NewLocalProvider(server.URL, "", "llama3.1")
+
+		// Act
+		resp, err := provider.chat(context.Background(), "llama3.1", "hello", "pr_summary", map[string]interface{}{"type": "object"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, `{"title":"ok"}`, resp.Content)
+		assert.Equal(t, "llama3.1", received.Model)
+		assert.False(t, received.Stream)
+		assert.NotNil(t, received.Format)
+
+		usage := extractUsage(resp)
+		assert.Equal(t, 120, usage.InputTokens)
+		assert.Equal(t, 30, usage.OutputTokens)
+	})
+
+	t.Run("OpenAI-compatible API", func(t *testing.T) {
+		// Arrange
+		var received chatCompletionRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			_, _ = w.Write([]byte(`{"model":"qwen","choices":[{"message":{"role":"assistant","content":"{}"}}],"usage":{"prompt_tokens":7,"completion_tokens":3}}`))
+		}))
+		defer server.Close()
+		provider := NewLocalProvider(server.URL+"/v1", "secret", "qwen")
+
+		// Act
+		resp, err := provider.chat(context.Background(), "qwen", "hello", "issue_content", map[string]interface{}{"type": "object"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "{}", resp.Content)
+		assert.Equal(t, 7, resp.InputTokens)
+		require.NotNil(t, received.ResponseFormat)
+		assert.Equal(t, "issue_content", received.ResponseFormat.JSONSchema.Name)
+	})
+
+	t.Run("server not reachable", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.NotFoundHandler())
+		url := server.URL
+		server.Close()
+		provider := NewLocalProvider(url, "", "llama3.1")
+
+		// Act
+		_, err := provider.chat(context.Background(), "llama3.1", "hello", "", nil)
+
+		// Assert
+		var appErr *domainErrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, domainErrors.ErrLocalAIUnreachable.Message, appErr.Message)
+	})
+
+	t.Run("unknown model", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"model 'nope' not found"}`))
+		}))
+		defer server.Close()
+		provider := NewLocalProvider(server.URL, "", "nope")
+
+		// Act
+		_, err := provider.chat(context.Background(), "nope", "hello", "", nil)
+
+		// Assert
+		var appErr *domainErrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, domainErrors.ErrAIGeneration.Message, appErr.Message)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":"out of memory"}`))
+		}))
+		defer server.Close()
+		provider := NewLocalProvider(server.URL, "", "llama3.1")
+
+		// Act
+		_, err := provider.chat(context.Background(), "llama3.1", "hello", "", nil)
+
+		// Assert
+		var appErr *domainErrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, domainErrors.ErrAIServerError.Message, appErr.Message)
+	})
+}
+
+func TestGenerate(t *testing.T) {
+	schema := &ai.Schema{
+		Type:     ai.SchemaObject,
+		Required: []string{"title"},
+		Properties: map[string]*ai.Schema{
+			"title":  {Type: ai.SchemaString},
+			"labels": {Type: ai.SchemaArray, Items: &ai.Schema{Type: ai.SchemaString}},
+		},
+	}
+
+	t.Run("ollama keeps the required fields of the schema", func(t *testing.T) {
+		// Arrange
+		var received ollamaChatRequest
+		server := newOllamaServer(t, `{"title":"ok"}`, &received)
+		provider := NewLocalProvider(server.URL, "", "llama3.1")
+
+		// Act
+		resp, err := provider.Generate(context.Background(), ai.LLMRequest{Prompt: "hello", SchemaName: "pr_summary", Schema: schema})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, `{"title":"ok"}`, resp.Text)
+		assert.Equal(t, 150, resp.Usage.TotalTokens)
+		assert.Equal(t, []interface{}{"title"}, received.Format["required"])
+	})
+
+	t.Run("OpenAI-compatible servers get a strict schema", func(t *testing.T) {
+		// Arrange
+		var received chatCompletionRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			_, _ = w.Write([]byte(`{"model":"qwen","choices":[{"message":{"role":"assistant","content":"{}"}}]}`))
+		}))
+		defer server.Close()
+		provider := NewLocalProvider(server.URL+"/v1", "", "qwen")
+
+		// Act
+		resp, err := provider.Generate(context.Background(), ai.LLMRequest{Prompt: "hello", SchemaName: "pr_summary", Schema: schema})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "{}", resp.Text)
+		require.NotNil(t, received.ResponseFormat)
+		assert.Equal(t, []interface{}{"labels", "title"}, received.ResponseFormat.JSONSchema.Schema["required"])
+	})
+
+	t.Run("request parameters override the provider settings", func(t *testing.T) {
+		// Arrange
+		var received ollamaChatRequest
+		server := newOllamaServer(t, "ok", &received)
+		provider := NewLocalProvider(server.URL, "", "llama3.1")
+
+		// Act
+		_, err := provider.Generate(context.Background(), ai.LLMRequest{Prompt: "hello", Temperature: 0.8, MaxTokens: 256})
+
+		// Assert
+		require.NoError(t, err)
+		assert.InDelta(t, 0.8, received.Options["temperature"], 0.001)
+		assert.Equal(t, float64(256), received.Options["num_predict"])
+	})
+
+	t.Run("plain text request sends no format", func(t *testing.T) {
+		// Arrange
+		var received ollamaChatRequest
+		server := newOllamaServer(t, "plain", &received)
+		provider := NewLocalProvider(server.URL, "", "llama3.1")
+
+		// Act
+		resp, err := provider.Generate(context.Background(), ai.LLMRequest{Prompt: "hello"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "plain", resp.Text)
+		assert.Nil(t, received.Format)
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("defaults to the local Ollama server", func(t *testing.T) {
+		provider := NewClient(context.Background(), &config.Config{})
+
+		assert.Equal(t, defaultBaseURL, provider.baseURL)
+		assert.Equal(t, string(config.DefaultModelForAI(config.AILocal)), provider.GetModelName())
+	})
+
+	t.Run("uses the configured entry", func(t *testing.T) {
+		cfg := &config.Config{
+			AIConfig: config.AIConfig{Models: map[config.AI]config.Model{config.AILocal: "qwen2.5-coder"}},
+			AIProviders: map[string]config.AIProviderConfig{
+				"local": {BaseURL: "http://localhost:8080/v1", Model: "ignored", Temperature: 0.1},
+			},
+		}
+
+		provider := NewClient(context.Background(), cfg)
+
+		assert.Equal(t, "qwen2.5-coder", provider.GetModelName())
+		assert.Equal(t, apiOpenAICompatible, provider.style)
+		assert.Equal(t, float32(0.1), provider.temperature)
+	})
+
+	t.Run("is registered with the ai package", func(t *testing.T) {
+		client, err := ai.NewLLMClient(context.Background(), &config.Config{}, config.AILocal)
+
+		require.NoError(t, err)
+		assert.Equal(t, "local", client.GetProviderName())
+	})
+}
+
+func TestGenerateStream(t *testing.T) {
+	t.Run("ollama API", func(t *testing.T) {
+		// Arrange
+		var received ollamaChatRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			_, _ = io.WriteString(w, `{"model":"llama3.1","message":{"role":"assistant","content":"{\"title\":"},"done":false}
+{"model":"llama3.1","message":{"role":"assistant","content":"\"ok\"}"},"done":false}
+{"model":"llama3.1","message":{"role":"assistant","content":""},"done":true,"prompt_eval_count":120,"eval_count":30}
+`)
+		}))
+		defer server.Close()
+
+		provider := NewLocalProvider(server.URL, "", "llama3.1")
+		var chunks []string
+
+		// Act
+		resp, err := provider.GenerateStream(context.Background(), ai.LLMRequest{Prompt: "hello"}, func(text string) {
+			chunks = append(chunks, text)
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, `{"title":"ok"}`, resp.Text)
+		assert.Equal(t, []string{`{"title":`, `"ok"}`}, chunks)
+		assert.Equal(t, 150, resp.Usage.TotalTokens)
+		assert.True(t, received.Stream)
+	})
+
+	t.Run("OpenAI-compatible API", func(t *testing.T) {
+		// Arrange
+		var received chatCompletionRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			_, _ = io.WriteString(w, `data: {"model":"qwen","choices":[{"delta":{"content":"{\"title\":"}}]}
+
+data: {"model":"qwen","choices":[{"delta":{"content":"\"ok\"}"}}]}
+
+data: {"model":"qwen","choices":[],"usage":{"prompt_tokens":12,"completion_tokens":4}}
+
+data: [DONE]
+
+`)
+		}))
+		defer server.Close()
+
+		provider := NewLocalProvider(server.URL+"/v1", "", "qwen")
+		var chunks []string
+
+		// Act
+		resp, err := provider.GenerateStream(context.Background(), ai.LLMRequest{Prompt: "hello"}, func(text string) {
+			chunks = append(chunks, text)
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, `{"title":"ok"}`, resp.Text)
+		assert.Equal(t, []string{`{"title":`, `"ok"}`}, chunks)
+		assert.Equal(t, 16, resp.Usage.TotalTokens)
+		assert.True(t, received.Stream)
+		require.NotNil(t, received.StreamOptions)
+	})
+}
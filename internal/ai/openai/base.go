@@ -0,0 +1,107 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/thomas-vilte/matecommit/internal/ai"
+	"github.com/thomas-vilte/matecommit/internal/config"
+	domainErrors "github.com/thomas-vilte/matecommit/internal/errors"
+)
+
+const (
+	defaultBaseURL     = "https://api.openai.com/v1"
+	defaultTemperature = 0.3
+	defaultMaxTokens   = 10000
+	requestTimeout     = 120 * time.Second
+)
+
+var _ ai.LLMClient = (*OpenAIProvider)(nil)
+
+func init() {
+	ai.RegisterClientFactory(string(config.AIOpenAI), func(ctx context.Context, cfg *config.Config) (ai.LLMClient, error) {
+		client, err := NewClient(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	})
+}
+
+// OpenAIProvider is the OpenAI adapter of the ai.LLMClient interface
+type OpenAIProvider struct {
+	apiKey      string
+	baseURL     string
+	httpClient  *http.Client
+	model       string
+	temperature float32
+	maxTokens   int
+}
+
+// NewOpenAIProvider creates a new instance of OpenAIProvider
+func NewOpenAIProvider(apiKey, model string) *OpenAIProvider {
+	return &OpenAIProvider{
+		apiKey:      apiKey,
+		baseURL:     defaultBaseURL,
+		httpClient:  &http.Client{Timeout: requestTimeout},
+		model:       model,
+		temperature: defaultTemperature,
+		maxTokens:   defaultMaxTokens,
+	}
+}
+
+// NewClient builds the provider from the "openai" entry of the configuration.
+func NewClient(_ context.Context, cfg *config.Config) (*OpenAIProvider, error) {
+	providerCfg, exists := cfg.AIProviders[string(config.AIOpenAI)]
+	if !exists || providerCfg.APIKey == "" {
+		return nil, domainErrors.ErrAPIKeyMissing
+	}
+
+	modelName := string(cfg.AIConfig.Models[config.AIOpenAI])
+	if modelName == "" {
+		modelName = providerCfg.Model
+	}
+	if modelName == "" {
+		modelName = string(config.DefaultModelForAI(config.AIOpenAI))
+	}
+
+	provider := NewOpenAIProvider(providerCfg.APIKey, modelName)
+	if providerCfg.Temperature > 0 {
+		provider.temperature = providerCfg.Temperature
+	}
+	if providerCfg.MaxTokens > 0 {
+		provider.maxTokens = providerCfg.MaxTokens
+	}
+
+	return provider, nil
+}
+
+// CountTokens implements ai.CostAwareAIProvider.
+// The OpenAI API has no token counting endpoint, so the wrapper falls back to its local estimation.
+func (p *OpenAIProvider) CountTokens(_ context.Context, _ string) (int, error) {
+	return 0, fmt.Errorf("token counting not supported by openai API")
+}
+
+// GetModelName implements ai.CostAwareAIProvider
+func (p *OpenAIProvider) GetModelName() string {
+	return p.model
+}
+
+// GetProviderName implements ai.CostAwareAIProvider
+func (p *OpenAIProvider) GetProviderName() string {
+	return "openai"
+}
+
+// withParams returns a copy of the provider that uses the generation parameters of the request
+func (p *OpenAIProvider) withParams(req ai.LLMRequest) *OpenAIProvider {
+	provider := *p
+	if req.Temperature > 0 {
+		provider.temperature = req.Temperature
+	}
+	if req.MaxTokens > 0 {
+		provider.maxTokens = req.MaxTokens
+	}
+	return &provider
+}
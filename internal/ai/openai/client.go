@@ -0,0 +1,363 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/thomas-vilte/matecommit/internal/ai"
+	domainErrors "github.com/thomas-vilte/matecommit/internal/errors"
+	"github.com/thomas-vilte/matecommit/internal/logger"
+	"github.com/thomas-vilte/matecommit/internal/models"
+)
+
+type (
+	// ChatCompletionRequest is the body sent to the /chat/completions endpoint.
+	ChatCompletionRequest struct {
+		Model               string          `json:"model"`
+		Messages            []ChatMessage   `json:"messages"`
+		Temperature         float32         `json:"temperature,omitempty"`
+		MaxCompletionTokens int             `json:"max_completion_tokens,omitempty"`
+		ResponseFormat      *ResponseFormat `json:"response_format,omitempty"`
+		Stream              bool            `json:"stream,omitempty"`
+		StreamOptions       *StreamOptions  `json:"stream_options,omitempty"`
+	}
+
+	// StreamOptions asks for a final chunk with the token usage of a streamed completion.
+	StreamOptions struct {
+		IncludeUsage bool `json:"include_usage"`
+	}
+
+	ChatMessage struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	}
+
+	// ResponseFormat enables Structured Outputs so the model answers with JSON that matches the schema.
+	ResponseFormat struct {
+		Type       string      `json:"type"`
+		JSONSchema *JSONSchema `json:"json_schema,omitempty"`
+	}
+
+	JSONSchema struct {
+		Name   string                 `json:"name"`
+		Schema map[string]interface{} `json:"schema"`
+		Strict bool                   `json:"strict"`
+	}
+
+	// ChatCompletionResponse is the subset of the /chat/completions response used by matecommit.
+	ChatCompletionResponse struct {
+		ID      string       `json:"id"`
+		Model   string       `json:"model"`
+		Choices []ChatChoice `json:"choices"`
+		Usage   *ChatUsage   `json:"usage,omitempty"`
+	}
+
+	ChatChoice struct {
+		Index        int         `json:"index"`
+		Message      ChatMessage `json:"message"`
+		FinishReason string      `json:"finish_reason"`
+	}
+
+	// ChatCompletionChunk is one server-sent event of a streamed completion.
+	ChatCompletionChunk struct {
+		ID      string `json:"id"`
+		Model   string `json:"model"`
+		Choices []struct {
+			Index        int         `json:"index"`
+			Delta        ChatMessage `json:"delta"`
+			FinishReason string      `json:"finish_reason"`
+		} `json:"choices"`
+		Usage *ChatUsage `json:"usage,omitempty"`
+	}
+
+	ChatUsage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	}
+
+	// modelsResponse is the subset of the /models response used by matecommit.
+	modelsResponse struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+
+	apiErrorResponse struct {
+		Error struct {
+			Message string `json:"message"`
+			Type    string `json:"type"`
+			Code    string `json:"code"`
+		} `json:"error"`
+	}
+)
+
+// Generate implements ai.LLMClient using Structured Outputs
+func (p *OpenAIProvider) Generate(ctx context.Context, req ai.LLMRequest) (*ai.LLMResponse, error) {
+	model := req.Model
+	if model == "" {
+		model = p.model
+	}
+
+	resp, err := p.withParams(req).createChatCompletion(ctx, model, req.Prompt, req.SchemaName, req.Schema.JSONSchema(true))
+	if err != nil {
+		return nil, err
+	}
+	return &ai.LLMResponse{Text: formatResponse(resp), Usage: extractUsage(resp)}, nil
+}
+
+// GenerateStream implements ai.StreamingClient by streaming the completion and calling onChunk with every delta.
+func (p *OpenAIProvider) GenerateStream(ctx context.Context, req ai.LLMRequest, onChunk func(text string)) (*ai.LLMResponse, error) {
+	model := req.Model
+	if model == "" {
+		model = p.model
+	}
+
+	resp, err := p.withParams(req).streamChatCompletion(ctx, model, req.Prompt, req.SchemaName, req.Schema.JSONSchema(true), onChunk)
+	if err != nil {
+		return nil, err
+	}
+	return &ai.LLMResponse{Text: formatResponse(resp), Usage: extractUsage(resp)}, nil
+}
+
+// createChatCompletion sends a single-turn prompt and asks for a JSON answer that matches the given schema.
+func (p *OpenAIProvider) createChatCompletion(ctx context.Context, model, prompt, schemaName string, schema map[string]interface{}) (*ChatCompletionResponse, error) {
+	log := logger.FromContext(ctx)
+
+	log.Debug("calling openai API",
+		"model", model,
+		"prompt_length", len(prompt))
+
+	resp, err := p.send(ctx, p.newChatRequest(model, prompt, schemaName, schema))
+	if err != nil {
+		log.Error("openai API call failed",
+			"error", err,
+			"model", model)
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, domainErrors.ErrAIGeneration.WithError(fmt.Errorf("error reading response: %w", err))
+	}
+
+	var chatResp ChatCompletionResponse
+	if err := json.Unmarshal(body, &chatResp); err != nil {
+		return nil, domainErrors.ErrInvalidAIOutput.WithError(fmt.Errorf("error decoding response: %w", err))
+	}
+
+	log.Debug("openai API response received",
+		"choices", len(chatResp.Choices),
+		"has_usage", chatResp.Usage != nil)
+
+	return &chatResp, nil
+}
+
+// streamChatCompletion is createChatCompletion with a streamed answer.
+// The deltas are joined into a regular response, with the usage sent in the last chunk.
+func (p *OpenAIProvider) streamChatCompletion(ctx context.Context, model, prompt, schemaName string, schema map[string]interface{}, onChunk func(text string)) (*ChatCompletionResponse, error) {
+	log := logger.FromContext(ctx)
+
+	reqBody := p.newChatRequest(model, prompt, schemaName, schema)
+	reqBody.Stream = true
+	reqBody.StreamOptions = &StreamOptions{IncludeUsage: true}
+
+	log.Debug("streaming openai API",
+		"model", model,
+		"prompt_length", len(prompt))
+
+	resp, err := p.send(ctx, reqBody)
+	if err != nil {
+		log.Error("openai API call failed",
+			"error", err,
+			"model", model)
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	chatResp := &ChatCompletionResponse{Model: model}
+	var content strings.Builder
+	err = ai.ReadEventStream(resp.Body, func(data []byte) error {
+		var chunk ChatCompletionChunk
+		if err := json.Unmarshal(data, &chunk); err != nil {
+			return domainErrors.ErrInvalidAIOutput.WithError(fmt.Errorf("error decoding stream chunk: %w", err))
+		}
+		if chunk.ID != "" {
+			chatResp.ID = chunk.ID
+		}
+		if chunk.Model != "" {
+			chatResp.Model = chunk.Model
+		}
+		if chunk.Usage != nil {
+			chatResp.Usage = chunk.Usage
+		}
+		for _, choice := range chunk.Choices {
+			if choice.Index != 0 || choice.Delta.Content == "" {
+				continue
+			}
+			content.WriteString(choice.Delta.Content)
+			onChunk(choice.Delta.Content)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	chatResp.Choices = []ChatChoice{{Message: ChatMessage{Role: "assistant", Content: content.String()}}}
+
+	log.Debug("openai API stream completed",
+		"response_length", content.Len(),
+		"has_usage", chatResp.Usage != nil)
+
+	return chatResp, nil
+}
+
+// newChatRequest builds the body of a single-turn completion, with Structured Outputs when a schema is given.
+func (p *OpenAIProvider) newChatRequest(model, prompt, schemaName string, schema map[string]interface{}) ChatCompletionRequest {
+	reqBody := ChatCompletionRequest{
+		Model: model,
+		Messages: []ChatMessage{
+			{Role: "user", Content: prompt},
+		},
+		Temperature:         p.temperature,
+		MaxCompletionTokens: p.maxTokens,
+	}
+	if schema != nil {
+		reqBody.ResponseFormat = &ResponseFormat{
+			Type: "json_schema",
+			JSONSchema: &JSONSchema{
+				Name:   schemaName,
+				Schema: schema,
+				Strict: true,
+			},
+		}
+	}
+	return reqBody
+}
+
+// send posts a completion request and returns the response when the API accepted it.
+// The caller closes the body.
+func (p *OpenAIProvider) send(ctx context.Context, reqBody ChatCompletionRequest) (*http.Response, error) {
+	payload, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, domainErrors.ErrAIGeneration.WithError(fmt.Errorf("error encoding request: %w", err))
+	}
+
+	url := strings.TrimSuffix(p.baseURL, "/") + "/chat/completions"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, domainErrors.ErrAIGeneration.WithError(fmt.Errorf("error creating request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, domainErrors.ErrAIGeneration.WithError(err)
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp, nil
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, domainErrors.ErrAIGeneration.WithError(fmt.Errorf("error reading response: %w", err))
+	}
+	return nil, parseAPIError(resp.StatusCode, body)
+}
+
+// ListModels returns the IDs of the models available to the API key. It costs nothing and fails
+// on an invalid key, so config init uses it to check the key.
+func (p *OpenAIProvider) ListModels(ctx context.Context) ([]string, error) {
+	url := strings.TrimSuffix(p.baseURL, "/") + "/models"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, domainErrors.ErrAIGeneration.WithError(fmt.Errorf("error creating request: %w", err))
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, domainErrors.ErrAIGeneration.WithError(err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, domainErrors.ErrAIGeneration.WithError(fmt.Errorf("error reading response: %w", err))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseAPIError(resp.StatusCode, body)
+	}
+
+	var models modelsResponse
+	if err := json.Unmarshal(body, &models); err != nil {
+		return nil, domainErrors.ErrAIGeneration.WithError(fmt.Errorf("error decoding response: %w", err))
+	}
+	ids := make([]string, 0, len(models.Data))
+	for _, model := range models.Data {
+		ids = append(ids, model.ID)
+	}
+	return ids, nil
+}
+
+// parseAPIError maps an OpenAI error response to a domain error.
+func parseAPIError(statusCode int, body []byte) error {
+	var apiErr apiErrorResponse
+	message := strings.TrimSpace(string(body))
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Error.Message != "" {
+		message = apiErr.Error.Message
+	}
+	cause := fmt.Errorf("openai API returned status %d: %s", statusCode, message)
+
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return domainErrors.ErrOpenAIAPIKeyInvalid.WithError(cause)
+	case statusCode == http.StatusTooManyRequests || apiErr.Error.Code == "insufficient_quota":
+		return domainErrors.ErrOpenAIQuotaExceeded.WithError(cause)
+	case statusCode >= http.StatusInternalServerError:
+		return domainErrors.ErrAIServerError.
+			WithContext("status", statusCode).
+			WithError(cause)
+	default:
+		return domainErrors.ErrAIGeneration.
+			WithContext("status", statusCode).
+			WithError(cause)
+	}
+}
+
+// extractUsage extracts usage metadata from the OpenAI response
+func extractUsage(resp *ChatCompletionResponse) *models.TokenUsage {
+	if resp == nil || resp.Usage == nil {
+		return nil
+	}
+	return &models.TokenUsage{
+		InputTokens:  resp.Usage.PromptTokens,
+		OutputTokens: resp.Usage.CompletionTokens,
+		TotalTokens:  resp.Usage.TotalTokens,
+	}
+}
+
+// formatResponse returns the text content of the first choice.
+func formatResponse(resp *ChatCompletionResponse) string {
+	if resp == nil || len(resp.Choices) == 0 {
+		return ""
+	}
+	return resp.Choices[0].Message.Content
+}
@@ -0,0 +1,338 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomas-vilte/matecommit/internal/ai"
+	"github.com/thomas-vilte/matecommit/internal/config"
+	domainErrors "github.com/thomas-vilte/matecommit/internal/errors"
+)
+
+// newChatResponse builds a response with a single choice holding content.
+func newChatResponse(content string) *ChatCompletionResponse {
+	return &ChatCompletionResponse{
+		Choices: []ChatChoice{
+			{Message: ChatMessage{Role: "assistant", Content: content}},
+		},
+		Usage: &ChatUsage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15},
+	}
+}
+
+func TestCreateChatCompletion(t *testing.T) {
+	t.Run("sends structured output request and decodes response", func(t *testing.T) {
+		// Arrange
+		var received ChatCompletionRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/chat/completions", r.URL.Path)
+			assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(newChatResponse(`{"title":"ok"}`))
+		}))
+		defer server.Close()
+
+		provider := NewOpenAIProvider("test-key", "gpt-4o-mini")
+		provider.baseURL = server.URL
+
+		// Act
+		resp, err := provider.createChatCompletion(context.Background(), "gpt-4o-mini", "hello", "pr_summary", map[string]interface{}{"type": "object"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, `{"title":"ok"}`, formatResponse(resp))
+		assert.Equal(t, "gpt-4o-mini", received.Model)
+		require.Len(t, received.Messages, 1)
+		assert.Equal(t, "hello", received.Messages[0].Content)
+		require.NotNil(t, received.ResponseFormat)
+		assert.Equal(t, "json_schema", received.ResponseFormat.Type)
+		assert.Equal(t, "pr_summary", received.ResponseFormat.JSONSchema.Name)
+		assert.True(t, received.ResponseFormat.JSONSchema.Strict)
+
+		usage := extractUsage(resp)
+		require.NotNil(t, usage)
+		assert.Equal(t, 10, usage.InputTokens)
+		assert.Equal(t, 5, usage.OutputTokens)
+	})
+
+	t.Run("maps API errors to domain errors", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			status int
+			body   string
+			want   *domainErrors.AppError
+		}{
+			{
+				name:   "invalid key",
+				status: http.StatusUnauthorized,
+				body:   `{"error":{"message":"Incorrect API key provided","code":"invalid_api_key"}}`,
+				want:   domainErrors.ErrOpenAIAPIKeyInvalid,
+			},
+			{
+				name:   "rate limit",
+				status: http.StatusTooManyRequests,
+				body:   `{"error":{"message":"Rate limit reached"}}`,
+				want:   domainErrors.ErrOpenAIQuotaExceeded,
+			},
+			{
+				name:   "bad request",
+				status: http.StatusBadRequest,
+				body:   `{"error":{"message":"The model does not exist"}}`,
+				want:   domainErrors.ErrAIGeneration,
+			},
+			{
+				name:   "server error",
+				status: http.StatusInternalServerError,
+				body:   `oops`,
+				want:   domainErrors.ErrAIServerError,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				// Arrange
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(tt.status)
+					_, _ = w.Write([]byte(tt.body))
+				}))
+				defer server.Close()
+
+				provider := NewOpenAIProvider("test-key", "gpt-4o-mini")
+				provider.baseURL = server.URL
+
+				// Act
+				resp, err := provider.createChatCompletion(context.Background(), "gpt-4o-mini", "hello", "", nil)
+
+				// Assert
+				assert.Nil(t, resp)
+				var appErr *domainErrors.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.want.Message, appErr.Message)
+			})
+		}
+	})
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("translates the neutral schema into a strict json_schema", func(t *testing.T) {
+		// Arrange
+		var received ChatCompletionRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			_ = json.NewEncoder(w).Encode(newChatResponse(`{"title":"ok"}`))
+		}))
+		defer server.Close()
+
+		provider := NewOpenAIProvider("test-key", "gpt-4o-mini")
+		provider.baseURL = server.URL
+
+		// Act
+		resp, err := provider.Generate(context.Background(), ai.LLMRequest{
+			Prompt:     "hello",
+			SchemaName: "pr_summary",
+			Schema: &ai.Schema{
+				Type:     ai.SchemaObject,
+				Required: []string{"title"},
+				Properties: map[string]*ai.Schema{
+					"title":  {Type: ai.SchemaString},
+					"labels": {Type: ai.SchemaArray, Items: &ai.Schema{Type: ai.SchemaString}},
+				},
+			},
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, `{"title":"ok"}`, resp.Text)
+		assert.Equal(t, 15, resp.Usage.TotalTokens)
+		assert.Equal(t, "gpt-4o-mini", received.Model, "an empty request model uses the configured one")
+		require.NotNil(t, received.ResponseFormat)
+		assert.ElementsMatch(t, []interface{}{"labels", "title"}, received.ResponseFormat.JSONSchema.Schema["required"])
+		assert.Equal(t, false, received.ResponseFormat.JSONSchema.Schema["additionalProperties"])
+	})
+
+	t.Run("request parameters override the provider settings", func(t *testing.T) {
+		// Arrange
+		var received ChatCompletionRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			_ = json.NewEncoder(w).Encode(newChatResponse("ok"))
+		}))
+		defer server.Close()
+
+		provider := NewOpenAIProvider("test-key", "gpt-4o-mini")
+		provider.baseURL = server.URL
+
+		// Act
+		_, err := provider.Generate(context.Background(), ai.LLMRequest{Model: "gpt-4o", Prompt: "hello", Temperature: 0.8, MaxTokens: 500})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "gpt-4o", received.Model)
+		assert.Equal(t, float32(0.8), received.Temperature)
+		assert.Equal(t, 500, received.MaxCompletionTokens)
+		assert.Equal(t, float32(defaultTemperature), provider.temperature, "the provider keeps its own settings")
+	})
+
+	t.Run("plain text request has no response format", func(t *testing.T) {
+		// Arrange
+		var received ChatCompletionRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			_ = json.NewEncoder(w).Encode(newChatResponse("plain"))
+		}))
+		defer server.Close()
+
+		provider := NewOpenAIProvider("test-key", "gpt-4o-mini")
+		provider.baseURL = server.URL
+
+		// Act
+		resp, err := provider.Generate(context.Background(), ai.LLMRequest{Model: "gpt-4o", Prompt: "hello"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "plain", resp.Text)
+		assert.Equal(t, "gpt-4o", received.Model)
+		assert.Nil(t, received.ResponseFormat)
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("missing API key", func(t *testing.T) {
+		client, err := NewClient(context.Background(), &config.Config{})
+
+		assert.Nil(t, client)
+		assert.ErrorIs(t, err, domainErrors.ErrAPIKeyMissing)
+	})
+
+	t.Run("model from ai_config overrides the provider entry", func(t *testing.T) {
+		cfg := &config.Config{
+			AIProviders: map[string]config.AIProviderConfig{
+				"openai": {APIKey: "key", Model: "gpt-4o-mini", Temperature: 0.7},
+			},
+			AIConfig: config.AIConfig{Models: map[config.AI]config.Model{config.AIOpenAI: config.ModelGPTV4o}},
+		}
+
+		client, err := NewClient(context.Background(), cfg)
+
+		require.NoError(t, err)
+		assert.Equal(t, "gpt-4o", client.GetModelName())
+		assert.Equal(t, "openai", client.GetProviderName())
+		assert.Equal(t, float32(0.7), client.temperature)
+	})
+
+	t.Run("registered as LLM client", func(t *testing.T) {
+		cfg := &config.Config{AIProviders: map[string]config.AIProviderConfig{"openai": {APIKey: "key"}}}
+
+		client, err := ai.NewLLMClient(context.Background(), cfg, config.AIOpenAI)
+
+		require.NoError(t, err)
+		assert.Equal(t, string(config.DefaultModelForAI(config.AIOpenAI)), client.GetModelName())
+	})
+}
+
+func TestListModels(t *testing.T) {
+	t.Run("returns the model IDs", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/models", r.URL.Path)
+			assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"data":[{"id":"gpt-4o"},{"id":"gpt-4o-mini"}]}`))
+		}))
+		defer server.Close()
+		provider := NewOpenAIProvider("test-key", "gpt-4o")
+		provider.baseURL = server.URL
+
+		// Act
+		models, err := provider.ListModels(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"gpt-4o", "gpt-4o-mini"}, models)
+	})
+
+	t.Run("invalid API key", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"message":"Incorrect API key provided","code":"invalid_api_key"}}`))
+		}))
+		defer server.Close()
+		provider := NewOpenAIProvider("bad-key", "gpt-4o")
+		provider.baseURL = server.URL
+
+		// Act
+		_, err := provider.ListModels(context.Background())
+
+		// Assert
+		assert.ErrorIs(t, err, domainErrors.ErrOpenAIAPIKeyInvalid)
+	})
+}
+
+func TestGenerateStream(t *testing.T) {
+	t.Run("joins the deltas and reads the usage chunk", func(t *testing.T) {
+		// Arrange
+		var received ChatCompletionRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = io.WriteString(w, `data: {"id":"c1","model":"gpt-4o-mini","choices":[{"index":0,"delta":{"role":"assistant","content":""}}]}
+
+data: {"id":"c1","choices":[{"index":0,"delta":{"content":"{\"title\":"}}]}
+
+data: {"id":"c1","choices":[{"index":0,"delta":{"content":"\"ok\"}"},"finish_reason":"stop"}]}
+
+data: {"id":"c1","choices":[],"usage":{"prompt_tokens":10,"completion_tokens":5,"total_tokens":15}}
+
+data: [DONE]
+
+`)
+		}))
+		defer server.Close()
+
+		provider := NewOpenAIProvider("test-key", "gpt-4o-mini")
+		provider.baseURL = server.URL
+		var chunks []string
+
+		// Act
+		resp, err := provider.GenerateStream(context.Background(), ai.LLMRequest{Prompt: "hello"}, func(text string) {
+			chunks = append(chunks, text)
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, `{"title":"ok"}`, resp.Text)
+		assert.Equal(t, []string{`{"title":`, `"ok"}`}, chunks)
+		require.NotNil(t, resp.Usage)
+		assert.Equal(t, 15, resp.Usage.TotalTokens)
+		assert.True(t, received.Stream)
+		require.NotNil(t, received.StreamOptions)
+		assert.True(t, received.StreamOptions.IncludeUsage)
+	})
+
+	t.Run("maps API errors before the stream starts", func(t *testing.T) {
+		// Arrange
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"message":"bad key"}}`))
+		}))
+		defer server.Close()
+
+		provider := NewOpenAIProvider("test-key", "gpt-4o-mini")
+		provider.baseURL = server.URL
+
+		// Act
+		_, err := provider.GenerateStream(context.Background(), ai.LLMRequest{Prompt: "hello"}, func(string) {})
+
+		// Assert
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domainErrors.ErrOpenAIAPIKeyInvalid)
+	})
+}
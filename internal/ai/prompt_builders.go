@@ -0,0 +1,550 @@
+package ai
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/thomas-vilte/matecommit/internal/models"
+)
+
+// BuildCommitPrompt renders the commit suggestion prompt for the given commit context.
+// It is provider-agnostic so every AI backend sends the same instructions.
+func BuildCommitPrompt(locale string, info models.CommitInfo, count int) string {
+	templateName := TemplateSuggest
+	if info.TicketInfo != nil && info.TicketInfo.TicketTitle != "" {
+		templateName = TemplateSuggestTicket
+	}
+	promptTemplate := resolvePromptTemplate(templateName, locale)
+
+	filesFormatted := formatChanges(info.Files)
+	diffFormatted := fmt.Sprintf("```diff\n%s\n```", info.Diff)
+
+	ticketInfo := ""
+	if info.TicketInfo != nil && info.TicketInfo.TicketTitle != "" {
+		labels := getPromptLabels(locale)
+		ticketInfo = fmt.Sprintf(`%s %s
+    %s %s
+    %s
+    %s`,
+			labels.ticketTitle, info.TicketInfo.TicketTitle,
+			labels.ticketDescription, info.TicketInfo.TitleDesc,
+			labels.ticketCriteria,
+			formatCriteria(info.TicketInfo.Criteria))
+	}
+
+	issueInstructions := ""
+	if info.IssueInfo != nil && info.IssueInfo.Number > 0 {
+		num := info.IssueInfo.Number
+		data := PromptData{IssueNumber: num}
+		issueInstructions, _ = RenderPrompt("issueInstructions", GetIssueReferenceInstructions(locale), data)
+	} else {
+		issueInstructions = GetNoIssueReferenceInstruction(locale)
+	}
+
+	technicalAnalysis := ""
+	if info.TicketInfo == nil || info.TicketInfo.TicketTitle == "" {
+		technicalAnalysis = GetTechnicalAnalysisInstruction(locale)
+	}
+
+	data := PromptData{
+		Count:         count,
+		Files:         filesFormatted,
+		Diff:          diffFormatted,
+		Ticket:        ticketInfo,
+		History:       info.RecentHistory,
+		Instructions:  issueInstructions,
+		TechnicalInfo: technicalAnalysis,
+		Style:         FormatCommitStyle(locale, info.Style),
+	}
+
+	rendered, err := RenderPrompt("commitPrompt", promptTemplate, data)
+	if err != nil {
+		return ""
+	}
+
+	return rendered
+}
+
+// BuildPRPrompt renders the PR summary prompt and appends the allowed labels, if any.
+func BuildPRPrompt(lang string, prContent string, availableLabels []string) string {
+	templateStr := resolvePromptTemplate(TemplateSummarizePR, lang)
+	data := PromptData{
+		PRContent: prContent,
+	}
+
+	rendered, err := RenderPrompt("prPrompt", templateStr, data)
+	if err != nil {
+		return ""
+	}
+
+	if len(availableLabels) > 0 {
+		rendered += fmt.Sprintf("\n\nAvailable Labels (Select ONLY from this list):\n%s", strings.Join(availableLabels, ", "))
+	}
+
+	return rendered
+}
+
+// BuildIssuePrompt builds the prompt to generate issue content.
+func BuildIssuePrompt(request models.IssueGenerationRequest) string {
+	if request.Description != "" && request.Diff == "" && request.Hint == "" &&
+		request.Template == nil && len(request.ChangedFiles) == 0 && len(request.AvailableLabels) == 0 {
+		return request.Description
+	}
+
+	var sb strings.Builder
+
+	if request.Description != "" {
+		sb.WriteString(fmt.Sprintf("Global Description: %s\n\n", request.Description))
+	}
+
+	if request.Diff != "" {
+		sb.WriteString("Code Changes (git diff):\n\n")
+		sb.WriteString("```diff\n")
+		sb.WriteString(request.Diff)
+		sb.WriteString("\n```\n\n")
+
+		if len(request.ChangedFiles) > 0 {
+			sb.WriteString("Changed files:\n")
+			for _, file := range request.ChangedFiles {
+				sb.WriteString(fmt.Sprintf("- %s\n", file))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if request.Hint != "" {
+		sb.WriteString(fmt.Sprintf("User Hint: %s\n\n", request.Hint))
+	}
+
+	if request.Template != nil {
+		lang := request.Language
+		if lang == "" {
+			lang = "en"
+		}
+		sb.WriteString(FormatTemplateForPrompt(request.Template, lang, "issue"))
+	} else {
+		sb.WriteString(GetIssueDefaultStructure(request.Language))
+	}
+
+	templateStr := resolvePromptTemplate(TemplateIssue, request.Language)
+	data := PromptData{
+		IssueInfo: sb.String(),
+	}
+
+	rendered, err := RenderPrompt("issuePrompt", templateStr, data)
+	if err != nil {
+		return ""
+	}
+
+	if len(request.AvailableLabels) > 0 {
+		rendered += fmt.Sprintf("\n\nAvailable Labels (Select ONLY from this list):\n%s", strings.Join(request.AvailableLabels, ", "))
+	}
+
+	return rendered
+}
+
+// BuildReleasePrompt renders the release notes prompt for the given release.
+func BuildReleasePrompt(lang, owner, repo string, release *models.Release) string {
+	templateStr := resolvePromptTemplate(TemplateRelease, lang)
+
+	data := PromptData{
+		RepoOwner:       owner,
+		RepoName:        repo,
+		PreviousVersion: release.PreviousVersion,
+		CurrentVersion:  release.PreviousVersion,
+		LatestVersion:   release.Version,
+		ReleaseDate:     string(release.VersionBump),
+		Changelog:       FormatReleaseChanges(lang, release),
+	}
+
+	rendered, err := RenderPrompt("releasePrompt", templateStr, data)
+	if err != nil {
+		return ""
+	}
+
+	return rendered
+}
+
+// FormatReleaseChanges formats the collected release context as plain text for the prompt.
+func FormatReleaseChanges(lang string, release *models.Release) string {
+	var sb strings.Builder
+
+	headers := GetReleaseNotesSectionHeaders(lang)
+
+	if len(release.Breaking) > 0 {
+		sb.WriteString(fmt.Sprintf("%s\n", headers["breaking"]))
+		for _, item := range release.Breaking {
+			sb.WriteString(fmt.Sprintf("- %s: %s\n", item.Type, item.Description))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(release.Features) > 0 {
+		sb.WriteString(fmt.Sprintf("%s\n", headers["features"]))
+		for _, item := range release.Features {
+			sb.WriteString(fmt.Sprintf("- %s: %s\n", item.Type, item.Description))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(release.BugFixes) > 0 {
+		sb.WriteString(fmt.Sprintf("%s\n", headers["fixes"]))
+		for _, item := range release.BugFixes {
+			sb.WriteString(fmt.Sprintf("- %s: %s\n", item.Type, item.Description))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(release.Improvements) > 0 {
+		sb.WriteString(fmt.Sprintf("%s\n", headers["improvements"]))
+		for _, item := range release.Improvements {
+			sb.WriteString(fmt.Sprintf("- %s: %s\n", item.Type, item.Description))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(release.ClosedIssues) > 0 {
+		sb.WriteString(fmt.Sprintf("%s\n", headers["closed_issues"]))
+		for _, issue := range release.ClosedIssues {
+			sb.WriteString(fmt.Sprintf("- #%d: %s (by @%s)\n", issue.Number, issue.Title, issue.Author))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(release.MergedPRs) > 0 {
+		sb.WriteString(fmt.Sprintf("%s\n", headers["merged_prs"]))
+		for _, pr := range release.MergedPRs {
+			sb.WriteString(fmt.Sprintf("- #%d: %s (by @%s)\n", pr.Number, pr.Title, pr.Author))
+			if pr.Description != "" {
+				lines := strings.Split(pr.Description, "\n")
+				if len(lines) > 0 && lines[0] != "" {
+					sb.WriteString(fmt.Sprintf("  Description: %s\n", lines[0]))
+				}
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(release.Contributors) > 0 {
+		sb.WriteString(fmt.Sprintf("%s (%d total):\n", headers["contributors"], len(release.Contributors)))
+		for _, contributor := range release.Contributors {
+			sb.WriteString(fmt.Sprintf("- @%s\n", contributor))
+		}
+		if len(release.NewContributors) > 0 {
+			sb.WriteString(fmt.Sprintf("New contributors: %s\n", strings.Join(release.NewContributors, ", ")))
+		}
+		sb.WriteString("\n")
+	}
+
+	if release.FileStats.FilesChanged > 0 {
+		sb.WriteString(fmt.Sprintf("%s\n", headers["file_stats"]))
+		sb.WriteString(fmt.Sprintf("- Files changed: %d\n", release.FileStats.FilesChanged))
+		sb.WriteString(fmt.Sprintf("- Insertions: +%d\n", release.FileStats.Insertions))
+		sb.WriteString(fmt.Sprintf("- Deletions: -%d\n", release.FileStats.Deletions))
+		if len(release.FileStats.TopFiles) > 0 {
+			sb.WriteString("Top modified files:\n")
+			for _, file := range release.FileStats.TopFiles {
+				sb.WriteString(fmt.Sprintf("  - %s (+%d/-%d)\n", file.Path, file.Additions, file.Deletions))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(release.Dependencies) > 0 {
+		sb.WriteString(fmt.Sprintf("%s\n", headers["deps"]))
+		for _, dep := range release.Dependencies {
+			switch dep.Type {
+			case "updated":
+				sb.WriteString(fmt.Sprintf("- %s: %s → %s\n", dep.Name, dep.OldVersion, dep.NewVersion))
+			case "added":
+				sb.WriteString(fmt.Sprintf("- Added: %s %s\n", dep.Name, dep.NewVersion))
+			case "removed":
+				sb.WriteString(fmt.Sprintf("- Removed: %s %s\n", dep.Name, dep.OldVersion))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// CleanLabels cleans and validates labels, keeping only the allowed ones.
+// It accepts a list of labels to clean and a list of available labels from the repository.
+// If availableLabels is empty, it falls back to a default list of common labels.
+func CleanLabels(labels []string, availableLabels []string) []string {
+	allowedLabels := make(map[string]bool)
+
+	if len(availableLabels) > 0 {
+		for _, l := range availableLabels {
+			allowedLabels[strings.ToLower(l)] = true
+		}
+	} else {
+		// Fallback to default list if no repo labels provided
+		defaultLabels := []string{
+			"feature", "fix", "refactor", "docs", "test", "infra",
+			"enhancement", "bug", "good first issue", "help wanted",
+			"chore", "performance", "security", "tech-debt", "breaking-change",
+		}
+		for _, l := range defaultLabels {
+			allowedLabels[l] = true
+		}
+	}
+
+	cleaned := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, label := range labels {
+		trimmed := strings.TrimSpace(strings.ToLower(label))
+		if trimmed != "" && allowedLabels[trimmed] && !seen[trimmed] {
+			cleaned = append(cleaned, trimmed)
+			seen[trimmed] = true
+		}
+	}
+
+	return cleaned
+}
+
+// EnsureIssueReference ensures all suggestions include the correct issue reference
+func EnsureIssueReference(suggestions []models.CommitSuggestion, issueNumber int) []models.CommitSuggestion {
+	issuePattern := regexp.MustCompile(`\(#\d+\)`)
+
+	for i := range suggestions {
+		title := suggestions[i].CommitTitle
+		title = strings.TrimSpace(title)
+
+		if strings.Contains(title, fmt.Sprintf("(#%d)", issueNumber)) ||
+			strings.Contains(title, fmt.Sprintf("fixes #%d", issueNumber)) ||
+			strings.Contains(title, fmt.Sprintf("closes #%d", issueNumber)) {
+			continue
+		}
+
+		if issuePattern.MatchString(title) {
+			title = issuePattern.ReplaceAllString(title, fmt.Sprintf("(#%d)", issueNumber))
+			suggestions[i].CommitTitle = title
+			continue
+		}
+
+		suggestions[i].CommitTitle = fmt.Sprintf("%s (#%d)", title, issueNumber)
+	}
+
+	return suggestions
+}
+
+// commitStyleLabels are the sentences of the commit style section in one language
+type commitStyleLabels struct {
+	title, intro, conventional, freeForm, types, scopes, emoji, noEmoji string
+	lower, upper, ticketPrefix, ticketScope, ticketSuffix, body, noBody string
+	subjectLength, examples                                             string
+}
+
+var commitStyleLabelsByLang = map[string]commitStyleLabels{
+	"en": {
+		title:         "# Repository Commit Style",
+		intro:         "Learned from the last %d commits of this repository. These conventions take precedence over the generic guidelines above.",
+		conventional:  "- Format: type(scope): description, used in %.0f%% of the commits.",
+		freeForm:      "- Format: free-form titles; the repository does not follow Conventional Commits.",
+		types:         "- Types in use (most used first): %s.",
+		scopes:        "- Common scopes: %s. Reuse them instead of inventing new ones.",
+		emoji:         "- Start the title with an emoji, like %.0f%% of the commits.",
+		noEmoji:       "- Do not use emojis.",
+		lower:         "- Start the description in lowercase.",
+		upper:         "- Start the description with an uppercase letter.",
+		ticketPrefix:  "- Put the ticket reference at the start of the title (e.g. %s).",
+		ticketScope:   "- Put the ticket reference as the scope (e.g. %s).",
+		ticketSuffix:  "- Put the ticket reference at the end of the title (e.g. %s).",
+		body:          "- %.0f%% of the commits have a body, about %d lines long.",
+		noBody:        "- Commits rarely have a body; keep the description short.",
+		subjectLength: "- Titles are about %d characters long.",
+		examples:      "Representative titles:",
+	},
+	"es": {
+		title:         "# Estilo de Commits del Repositorio",
+		intro:         "Aprendido de los últimos %d commits de este repositorio. Estas convenciones tienen prioridad sobre las pautas genéricas de arriba.",
+		conventional:  "- Formato: tipo(scope): descripción, usado en el %.0f%% de los commits.",
+		freeForm:      "- Formato: títulos libres; el repositorio no sigue Conventional Commits.",
+		types:         "- Tipos en uso (los más usados primero): %s.",
+		scopes:        "- Scopes comunes: %s. Reutilizalos en vez de inventar nuevos.",
+		emoji:         "- Empezá el título con un emoji, como el %.0f%% de los commits.",
+		noEmoji:       "- No uses emojis.",
+		lower:         "- Empezá la descripción en minúscula.",
+		upper:         "- Empezá la descripción con mayúscula.",
+		ticketPrefix:  "- Poné la referencia al ticket al principio del título (ej: %s).",
+		ticketScope:   "- Poné la referencia al ticket como scope (ej: %s).",
+		ticketSuffix:  "- Poné la referencia al ticket al final del título (ej: %s).",
+		body:          "- El %.0f%% de los commits tiene cuerpo, de unas %d líneas.",
+		noBody:        "- Los commits casi nunca tienen cuerpo; mantené la descripción corta.",
+		subjectLength: "- Los títulos tienen unos %d caracteres.",
+		examples:      "Títulos representativos:",
+	},
+	"pt": {
+		title:         "# Estilo de Commits do Repositório",
+		intro:         "Aprendido dos últimos %d commits deste repositório. Estas convenções têm prioridade sobre as diretrizes genéricas acima.",
+		conventional:  "- Formato: tipo(scope): descrição, usado em %.0f%% dos commits.",
+		freeForm:      "- Formato: títulos livres; o repositório não segue Conventional Commits.",
+		types:         "- Tipos em uso (os mais usados primeiro): %s.",
+		scopes:        "- Scopes comuns: %s. Reutilize-os em vez de inventar novos.",
+		emoji:         "- Comece o título com um emoji, como %.0f%% dos commits.",
+		noEmoji:       "- Não use emojis.",
+		lower:         "- Comece a descrição com letra minúscula.",
+		upper:         "- Comece a descrição com letra maiúscula.",
+		ticketPrefix:  "- Coloque a referência ao ticket no início do título (ex: %s).",
+		ticketScope:   "- Coloque a referência ao ticket como scope (ex: %s).",
+		ticketSuffix:  "- Coloque a referência ao ticket no final do título (ex: %s).",
+		body:          "- %.0f%% dos commits têm corpo, com cerca de %d linhas.",
+		noBody:        "- Os commits raramente têm corpo; mantenha a descrição curta.",
+		subjectLength: "- Os títulos têm cerca de %d caracteres.",
+		examples:      "Títulos representativos:",
+	},
+	"fr": {
+		title:         "# Style de Commits du Dépôt",
+		intro:         "Appris des %d derniers commits de ce dépôt. Ces conventions priment sur les consignes génériques ci-dessus.",
+		conventional:  "- Format : type(scope): description, utilisé dans %.0f%% des commits.",
+		freeForm:      "- Format : titres libres ; le dépôt ne suit pas Conventional Commits.",
+		types:         "- Types utilisés (les plus fréquents d'abord) : %s.",
+		scopes:        "- Scopes courants : %s. Réutilise-les au lieu d'en inventer de nouveaux.",
+		emoji:         "- Commence le titre par un emoji, comme %.0f%% des commits.",
+		noEmoji:       "- N'utilise pas d'emojis.",
+		lower:         "- Commence la description par une minuscule.",
+		upper:         "- Commence la description par une majuscule.",
+		ticketPrefix:  "- Place la référence du ticket au début du titre (ex : %s).",
+		ticketScope:   "- Place la référence du ticket dans le scope (ex : %s).",
+		ticketSuffix:  "- Place la référence du ticket à la fin du titre (ex : %s).",
+		body:          "- %.0f%% des commits ont un corps, d'environ %d lignes.",
+		noBody:        "- Les commits ont rarement un corps ; garde la description courte.",
+		subjectLength: "- Les titres font environ %d caractères.",
+		examples:      "Titres représentatifs :",
+	},
+	"de": {
+		title:         "# Commit-Stil des Repositorys",
+		intro:         "Gelernt aus den letzten %d Commits dieses Repositorys. Diese Konventionen haben Vorrang vor den allgemeinen Richtlinien oben.",
+		conventional:  "- Format: typ(scope): beschreibung, verwendet in %.0f%% der Commits.",
+		freeForm:      "- Format: freie Titel; das Repository folgt nicht Conventional Commits.",
+		types:         "- Verwendete Typen (häufigste zuerst): %s.",
+		scopes:        "- Übliche Scopes: %s. Verwende sie wieder, statt neue zu erfinden.",
+		emoji:         "- Beginne den Titel mit einem Emoji, wie %.0f%% der Commits.",
+		noEmoji:       "- Verwende keine Emojis.",
+		lower:         "- Beginne die Beschreibung mit einem Kleinbuchstaben.",
+		upper:         "- Beginne die Beschreibung mit einem Großbuchstaben.",
+		ticketPrefix:  "- Setze die Ticket-Referenz an den Anfang des Titels (z. B. %s).",
+		ticketScope:   "- Setze die Ticket-Referenz als Scope (z. B. %s).",
+		ticketSuffix:  "- Setze die Ticket-Referenz an das Ende des Titels (z. B. %s).",
+		body:          "- %.0f%% der Commits haben einen Body mit etwa %d Zeilen.",
+		noBody:        "- Commits haben selten einen Body; halte die Beschreibung kurz.",
+		subjectLength: "- Titel sind etwa %d Zeichen lang.",
+		examples:      "Repräsentative Titel:",
+	},
+	"it": {
+		title:         "# Stile dei Commit del Repository",
+		intro:         "Appreso dagli ultimi %d commit di questo repository. Queste convenzioni hanno la precedenza sulle linee guida generiche qui sopra.",
+		conventional:  "- Formato: tipo(scope): descrizione, usato nel %.0f%% dei commit.",
+		freeForm:      "- Formato: titoli liberi; il repository non segue Conventional Commits.",
+		types:         "- Tipi in uso (i più usati per primi): %s.",
+		scopes:        "- Scope comuni: %s. Riutilizzali invece di inventarne di nuovi.",
+		emoji:         "- Inizia il titolo con un emoji, come il %.0f%% dei commit.",
+		noEmoji:       "- Non usare emoji.",
+		lower:         "- Inizia la descrizione con la lettera minuscola.",
+		upper:         "- Inizia la descrizione con la lettera maiuscola.",
+		ticketPrefix:  "- Metti il riferimento al ticket all'inizio del titolo (es: %s).",
+		ticketScope:   "- Metti il riferimento al ticket come scope (es: %s).",
+		ticketSuffix:  "- Metti il riferimento al ticket alla fine del titolo (es: %s).",
+		body:          "- Il %.0f%% dei commit ha un corpo, di circa %d righe.",
+		noBody:        "- I commit raramente hanno un corpo; mantieni la descrizione breve.",
+		subjectLength: "- I titoli sono lunghi circa %d caratteri.",
+		examples:      "Titoli rappresentativi:",
+	},
+}
+
+// FormatCommitStyle renders the conventions learned from the history as prompt rules plus few-shot examples.
+// A nil style renders nothing, so the generic guidelines of the template apply.
+func FormatCommitStyle(locale string, style *models.CommitStyle) string {
+	if style == nil {
+		return ""
+	}
+	labels := commitStyleLabelsByLang[promptLanguage(locale)]
+
+	lines := []string{labels.title, fmt.Sprintf(labels.intro, style.CommitCount)}
+	if style.Conventional >= 0.5 {
+		lines = append(lines, fmt.Sprintf(labels.conventional, style.Conventional*100))
+		if len(style.Types) > 0 {
+			lines = append(lines, fmt.Sprintf(labels.types, formatStyleCounts(style.Types)))
+		}
+		if len(style.Scopes) > 0 {
+			lines = append(lines, fmt.Sprintf(labels.scopes, formatStyleCounts(style.Scopes)))
+		}
+	} else {
+		lines = append(lines, labels.freeForm)
+	}
+
+	switch {
+	case style.Emoji >= 0.5:
+		lines = append(lines, fmt.Sprintf(labels.emoji, style.Emoji*100))
+	case style.Emoji < 0.1:
+		lines = append(lines, labels.noEmoji)
+	}
+
+	switch style.Casing {
+	case models.CasingLower:
+		lines = append(lines, labels.lower)
+	case models.CasingUpper:
+		lines = append(lines, labels.upper)
+	}
+
+	switch style.TicketPlacement {
+	case models.TicketPlacementPrefix:
+		lines = append(lines, fmt.Sprintf(labels.ticketPrefix, style.TicketExample))
+	case models.TicketPlacementScope:
+		lines = append(lines, fmt.Sprintf(labels.ticketScope, style.TicketExample))
+	case models.TicketPlacementSuffix:
+		lines = append(lines, fmt.Sprintf(labels.ticketSuffix, style.TicketExample))
+	}
+
+	if style.BodyShare >= 0.3 {
+		lines = append(lines, fmt.Sprintf(labels.body, style.BodyShare*100, style.AverageBodyLines))
+	} else {
+		lines = append(lines, labels.noBody)
+	}
+	if style.AverageSubject > 0 {
+		lines = append(lines, fmt.Sprintf(labels.subjectLength, style.AverageSubject))
+	}
+
+	if len(style.Examples) > 0 {
+		lines = append(lines, labels.examples)
+		for _, example := range style.Examples {
+			lines = append(lines, "  - "+example)
+		}
+	}
+
+	return strings.Join(lines, "\n  ")
+}
+
+// formatStyleCounts lists the names of counted types or scopes
+func formatStyleCounts(counts []models.StyleCount) string {
+	names := make([]string, len(counts))
+	for i, c := range counts {
+		names[i] = c.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+func formatChanges(files []string) string {
+	if len(files) == 0 {
+		return ""
+	}
+	formattedFiles := make([]string, len(files))
+	for i, file := range files {
+		formattedFiles[i] = fmt.Sprintf("- %s", file)
+	}
+	return strings.Join(formattedFiles, "\n")
+}
+
+func formatCriteria(criteria []string) string {
+	if len(criteria) == 0 {
+		return ""
+	}
+	formattedCriteria := make([]string, len(criteria))
+	for i, criterion := range criteria {
+		formattedCriteria[i] = fmt.Sprintf("  - %s", criterion)
+	}
+	return strings.Join(formattedCriteria, "\n")
+}
@@ -0,0 +1,179 @@
+package ai
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/thomas-vilte/matecommit/internal/config"
+)
+
+// PromptsDir is the directory of template overrides, inside .matecommit and the global configuration directory
+const PromptsDir = "prompts"
+
+// Names of the overridable templates, after the commands that use them.
+// The commit prompt has a second template for commits linked to a ticket.
+const (
+	TemplateSuggest       = config.CommandSuggest
+	TemplateSuggestTicket = "suggest-ticket"
+	TemplateSummarizePR   = config.CommandSummarizePR
+	TemplateRelease       = config.CommandRelease
+	TemplateIssue         = config.CommandIssue
+)
+
+// Where a prompt template comes from
+const (
+	PromptSourceRepo    = "repo"
+	PromptSourceUser    = "user"
+	PromptSourceBuiltin = "builtin"
+)
+
+// PromptTemplate is the effective template of a command in a language.
+type PromptTemplate struct {
+	Name   string
+	Lang   string
+	Source string
+	// Path is the override file; empty for the built-in templates
+	Path string
+	Text string
+}
+
+// promptDirs returns the override directory of each source; the repository one wins over the user one.
+// It is a variable so tests can point it to temporary directories.
+var promptDirs = defaultPromptDirs
+
+func defaultPromptDirs() map[string]string {
+	dirs := make(map[string]string)
+	if repoConfig := config.GetRepoConfigPath(); repoConfig != "" {
+		dirs[PromptSourceRepo] = filepath.Join(filepath.Dir(repoConfig), PromptsDir)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs[PromptSourceUser] = filepath.Join(home, ".config", "matecommit", PromptsDir)
+	}
+	return dirs
+}
+
+// PromptTemplateNames returns the names of the templates that can be overridden.
+func PromptTemplateNames() []string {
+	return []string{
+		TemplateSuggest,
+		TemplateSuggestTicket,
+		TemplateSummarizePR,
+		TemplateRelease,
+		TemplateIssue,
+	}
+}
+
+// PromptDir returns the override directory of a source (PromptSourceRepo or PromptSourceUser),
+// or "" when it is not available, e.g. the repository one outside a repository.
+func PromptDir(source string) string {
+	return promptDirs()[source]
+}
+
+// PromptTemplateFile returns the file name of an override: <name>.<lang>.tmpl.
+func PromptTemplateFile(name, lang string) string {
+	return fmt.Sprintf("%s.%s.tmpl", name, promptLanguage(lang))
+}
+
+// BuiltinPromptTemplate returns the template shipped with matecommit.
+func BuiltinPromptTemplate(name, lang string) (string, bool) {
+	switch name {
+	case TemplateSuggest:
+		return GetCommitPromptTemplate(lang, false), true
+	case TemplateSuggestTicket:
+		return GetCommitPromptTemplate(lang, true), true
+	case TemplateSummarizePR:
+		return GetPRPromptTemplate(lang), true
+	case TemplateRelease:
+		return GetReleasePromptTemplate(lang), true
+	case TemplateIssue:
+		return GetIssuePromptTemplate(lang), true
+	default:
+		return "", false
+	}
+}
+
+// LoadPromptTemplate finds the template of a command in .matecommit/prompts of the repository,
+// then in ~/.config/matecommit/prompts, and falls back to the built-in one.
+func LoadPromptTemplate(name, lang string) (PromptTemplate, error) {
+	builtin, ok := BuiltinPromptTemplate(name, lang)
+	if !ok {
+		return PromptTemplate{}, fmt.Errorf("unknown prompt template: %s", name)
+	}
+	lang = promptLanguage(lang)
+
+	dirs := promptDirs()
+	for _, source := range []string{PromptSourceRepo, PromptSourceUser} {
+		dir, ok := dirs[source]
+		if !ok {
+			continue
+		}
+		path := filepath.Join(dir, PromptTemplateFile(name, lang))
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return PromptTemplate{}, fmt.Errorf("error reading prompt template %s: %w", path, err)
+		}
+		return PromptTemplate{Name: name, Lang: lang, Source: source, Path: path, Text: string(data)}, nil
+	}
+
+	return PromptTemplate{Name: name, Lang: lang, Source: PromptSourceBuiltin, Text: builtin}, nil
+}
+
+// ValidatePromptTemplate renders a template against SamplePromptData, so syntax errors and
+// references to fields PromptData does not have are caught before a real call.
+func ValidatePromptTemplate(tmpl PromptTemplate) (string, error) {
+	return RenderPrompt(tmpl.Name, tmpl.Text, SamplePromptData())
+}
+
+// resolvePromptTemplate returns the text of the effective template for the prompt builders.
+// An override that cannot be read or rendered is reported and the built-in template is used.
+func resolvePromptTemplate(name, lang string) string {
+	builtin, _ := BuiltinPromptTemplate(name, lang)
+
+	tmpl, err := LoadPromptTemplate(name, lang)
+	if err != nil {
+		slog.Warn("using the built-in prompt template", "template", name, "error", err)
+		return builtin
+	}
+	if tmpl.Source == PromptSourceBuiltin {
+		return builtin
+	}
+	if _, err := ValidatePromptTemplate(tmpl); err != nil {
+		slog.Warn("using the built-in prompt template", "template", name, "path", tmpl.Path, "error", err)
+		return builtin
+	}
+
+	slog.Debug("using prompt template override", "template", name, "path", tmpl.Path)
+	return tmpl.Text
+}
+
+// SamplePromptData returns PromptData filled with placeholder values to preview templates.
+func SamplePromptData() PromptData {
+	return PromptData{
+		Count:           3,
+		Files:           "- internal/auth/login.go\n- internal/auth/login_test.go",
+		Diff:            "```diff\n--- a/internal/auth/login.go\n+++ b/internal/auth/login.go\n@@ -10,3 +10,5 @@\n+\tif user == nil {\n+\t\treturn ErrUnknownUser\n+\t}\n```",
+		Ticket:          "**Title:** PROJ-123 Reject unknown users\n    **Description:** Login must fail for unknown users",
+		History:         "feat(auth): add login endpoint\nfix(auth): trim the user name",
+		Instructions:    "There is an associated issue (#42), you MUST include the reference in the commit title.",
+		IssueNumber:     42,
+		RelatedIssues:   "#42 Reject unknown users",
+		IssueInfo:       "Global Description: Login accepts users that do not exist",
+		RepoOwner:       "acme",
+		RepoName:        "webapp",
+		PreviousVersion: "v1.2.0",
+		CurrentVersion:  "v1.2.0",
+		LatestVersion:   "v1.3.0",
+		ReleaseDate:     "minor",
+		Changelog:       "- feat(auth): reject unknown users (#42)",
+		PRContent:       "Title: Reject unknown users\n\nCommits:\n- fix(auth): reject unknown users",
+		TechnicalInfo:   "Explain the technical impact of the change.",
+		Part:            1,
+		Parts:           2,
+		Style:           "Follow Conventional Commits with lowercase descriptions.",
+	}
+}
@@ -0,0 +1,124 @@
+package ai
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// usePromptDirs points the override lookup to temporary repository and user directories
+func usePromptDirs(t *testing.T) (repoDir, userDir string) {
+	t.Helper()
+	repoDir, userDir = t.TempDir(), t.TempDir()
+	previous := promptDirs
+	promptDirs = func() map[string]string {
+		return map[string]string{PromptSourceRepo: repoDir, PromptSourceUser: userDir}
+	}
+	t.Cleanup(func() { promptDirs = previous })
+	return repoDir, userDir
+}
+
+func writePromptOverride(t *testing.T, dir, file, text string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(text), 0644))
+}
+
+func TestLoadPromptTemplate(t *testing.T) {
+	t.Run("falls back to the built-in template", func(t *testing.T) {
+		// Arrange
+		usePromptDirs(t)
+
+		// Act
+		tmpl, err := LoadPromptTemplate(TemplateRelease, "es")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, PromptSourceBuiltin, tmpl.Source)
+		assert.Equal(t, GetReleasePromptTemplate("es"), tmpl.Text)
+	})
+
+	t.Run("the repository wins over the user", func(t *testing.T) {
+		// Arrange
+		repoDir, userDir := usePromptDirs(t)
+		writePromptOverride(t, userDir, "suggest.en.tmpl", "user rules")
+		writePromptOverride(t, repoDir, "suggest.en.tmpl", "repo rules")
+
+		// Act
+		tmpl, err := LoadPromptTemplate(TemplateSuggest, "en")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, PromptSourceRepo, tmpl.Source)
+		assert.Equal(t, "repo rules", tmpl.Text)
+		assert.Equal(t, filepath.Join(repoDir, "suggest.en.tmpl"), tmpl.Path)
+	})
+
+	t.Run("overrides are per language", func(t *testing.T) {
+		// Arrange
+		_, userDir := usePromptDirs(t)
+		writePromptOverride(t, userDir, "issue.es.tmpl", "reglas")
+
+		// Act
+		english, errEN := LoadPromptTemplate(TemplateIssue, "en")
+		spanish, errES := LoadPromptTemplate(TemplateIssue, "es")
+
+		// Assert
+		require.NoError(t, errEN)
+		require.NoError(t, errES)
+		assert.Equal(t, PromptSourceBuiltin, english.Source)
+		assert.Equal(t, PromptSourceUser, spanish.Source)
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		_, err := LoadPromptTemplate("deploy", "en")
+
+		assert.ErrorContains(t, err, "unknown prompt template")
+	})
+}
+
+func TestPromptBuilders_UseOverrides(t *testing.T) {
+	t.Run("renders the override", func(t *testing.T) {
+		// Arrange
+		repoDir, _ := usePromptDirs(t)
+		writePromptOverride(t, repoDir, "summarize-pr.en.tmpl", "Always mention the migration ID.\n{{.PRContent}}")
+
+		// Act
+		prompt := BuildPRPrompt("en", "PR body", nil)
+
+		// Assert
+		assert.Equal(t, "Always mention the migration ID.\nPR body", prompt)
+	})
+
+	t.Run("an invalid override falls back to the built-in template", func(t *testing.T) {
+		// Arrange
+		repoDir, _ := usePromptDirs(t)
+		writePromptOverride(t, repoDir, "summarize-pr.en.tmpl", "{{.MigrationID}}")
+
+		// Act
+		prompt := BuildPRPrompt("en", "PR body", nil)
+
+		// Assert
+		want, err := RenderPrompt("prPrompt", GetPRPromptTemplate("en"), PromptData{PRContent: "PR body"})
+		require.NoError(t, err)
+		assert.Equal(t, want, prompt)
+	})
+}
+
+func TestValidatePromptTemplate(t *testing.T) {
+	for _, name := range PromptTemplateNames() {
+		for _, lang := range []string{"en", "es"} {
+			builtin, ok := BuiltinPromptTemplate(name, lang)
+			require.True(t, ok)
+
+			_, err := ValidatePromptTemplate(PromptTemplate{Name: name, Lang: lang, Text: builtin})
+
+			assert.NoError(t, err, "built-in template %s.%s", name, lang)
+		}
+	}
+
+	_, err := ValidatePromptTemplate(PromptTemplate{Name: "broken", Text: "{{.Diff"})
+	assert.Error(t, err)
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/thomas-vilte/matecommit/internal/config"
 	"github.com/thomas-vilte/matecommit/internal/models"
 )
 
@@ -49,6 +50,12 @@ type PromptData struct {
 	Changelog       string
 	PRContent       string
 	TechnicalInfo   string
+	Part            int
+	Parts           int
+	Prompt          string
+	Response        string
+	Errors          string
+	Style           string
 }
 
 // RenderPrompt renders a prompt template with the provided data
@@ -119,6 +126,7 @@ const (
      - If recent history shows something was implemented in previous commits, do NOT mark it as missing.
      - If you see file names or function names in the diff indicating prior implementation (e.g., "stats.go", "CountTokens"), assume it exists.
      - Focus on what's missing NOW in the current commit context, not in the entire project.
+  {{.Style}}
   Generate {{.Count}} suggestions now.`
 
 	promptTemplateWithTicketES = `# Tarea
@@ -144,6 +152,7 @@ const (
      - Si el historial reciente muestra que algo ya se implementó en commits anteriores, NO lo marques como faltante.
      - Si ves nombres de archivos o funciones en el diff que indican implementación previa (ej: "stats.go", "CountTokens"), asume que ya existe.
      - Enfocate en lo que falta AHORA en el contexto del commit actual, no en el proyecto completo.
+  {{.Style}}
   Genera {{.Count}} sugerencias ahora.`
 )
 
@@ -170,6 +179,7 @@ const (
   - ❌ "se corrigió el error" (Voz pasiva, muy robótico)
   - ✅ "fix(cli): corrijo panic al no tener config" (Bien)
   {{.TechnicalInfo}}
+  {{.Style}}
   Genera {{.Count}} sugerencias ahora.`
 
 	promptTemplateWithoutTicketEN = `# Task
@@ -194,6 +204,7 @@ const (
   - ❌ "error was fixed" (Passive voice)
   - ✅ "fix(cli): handle panic when config is missing" (Perfect)
   {{.TechnicalInfo}}
+  {{.Style}}
   Generate {{.Count}} suggestions now.`
 )
 
@@ -291,9 +302,17 @@ Generate the release notes now using the JSON schema with semantic sections.`
 
 // GetPRPromptTemplate returns the appropriate template based on the language
 func GetPRPromptTemplate(lang string) string {
-	switch lang {
+	switch promptLanguage(lang) {
 	case "es":
 		return prPromptTemplateES
+	case "pt":
+		return prPromptTemplatePT
+	case "fr":
+		return prPromptTemplateFR
+	case "de":
+		return prPromptTemplateDE
+	case "it":
+		return prPromptTemplateIT
 	default:
 		return prPromptTemplateEN
 	}
@@ -301,24 +320,52 @@ func GetPRPromptTemplate(lang string) string {
 
 // GetCommitPromptTemplate returns the commit template based on language and whether there is a ticket
 func GetCommitPromptTemplate(lang string, hasTicket bool) string {
-	if lang == "es" {
-		if hasTicket {
+	if hasTicket {
+		switch promptLanguage(lang) {
+		case "es":
 			return promptTemplateWithTicketES
+		case "pt":
+			return promptTemplateWithTicketPT
+		case "fr":
+			return promptTemplateWithTicketFR
+		case "de":
+			return promptTemplateWithTicketDE
+		case "it":
+			return promptTemplateWithTicketIT
+		default:
+			return promptTemplateWithTicketEN
 		}
-		return promptTemplateWithoutTicketES
 	}
 
-	if hasTicket {
-		return promptTemplateWithTicketEN
+	switch promptLanguage(lang) {
+	case "es":
+		return promptTemplateWithoutTicketES
+	case "pt":
+		return promptTemplateWithoutTicketPT
+	case "fr":
+		return promptTemplateWithoutTicketFR
+	case "de":
+		return promptTemplateWithoutTicketDE
+	case "it":
+		return promptTemplateWithoutTicketIT
+	default:
+		return promptTemplateWithoutTicketEN
 	}
-	return promptTemplateWithoutTicketEN
 }
 
 // GetReleasePromptTemplate returns the release template based on the language
 func GetReleasePromptTemplate(lang string) string {
-	switch lang {
+	switch promptLanguage(lang) {
 	case "es":
 		return releasePromptTemplateES
+	case "pt":
+		return releasePromptTemplatePT
+	case "fr":
+		return releasePromptTemplateFR
+	case "de":
+		return releasePromptTemplateDE
+	case "it":
+		return releasePromptTemplateIT
 	default:
 		return releasePromptTemplateEN
 	}
@@ -326,9 +373,17 @@ func GetReleasePromptTemplate(lang string) string {
 
 // GetIssueReferenceInstructions returns issue reference instructions based on the language
 func GetIssueReferenceInstructions(lang string) string {
-	switch lang {
+	switch promptLanguage(lang) {
 	case "es":
 		return issueReferenceInstructionsES
+	case "pt":
+		return issueReferenceInstructionsPT
+	case "fr":
+		return issueReferenceInstructionsFR
+	case "de":
+		return issueReferenceInstructionsDE
+	case "it":
+		return issueReferenceInstructionsIT
 	default:
 		return issueReferenceInstructionsEN
 	}
@@ -358,9 +413,17 @@ IMPORTANT: Generate the PR description following the structure and format shown
 
 // GetTemplateInstructions returns template instructions based on the language
 func GetTemplateInstructions(lang string) string {
-	switch lang {
+	switch promptLanguage(lang) {
 	case "es":
 		return templateInstructionsES
+	case "pt":
+		return templateInstructionsPT
+	case "fr":
+		return templateInstructionsFR
+	case "de":
+		return templateInstructionsDE
+	case "it":
+		return templateInstructionsIT
 	default:
 		return templateInstructionsEN
 	}
@@ -368,14 +431,140 @@ func GetTemplateInstructions(lang string) string {
 
 // GetPRTemplateInstructions returns PR template instructions based on the language
 func GetPRTemplateInstructions(lang string) string {
-	switch lang {
+	switch promptLanguage(lang) {
 	case "es":
 		return prTemplateInstructionsES
+	case "pt":
+		return prTemplateInstructionsPT
+	case "fr":
+		return prTemplateInstructionsFR
+	case "de":
+		return prTemplateInstructionsDE
+	case "it":
+		return prTemplateInstructionsIT
 	default:
 		return prTemplateInstructionsEN
 	}
 }
 
+// promptLabels are the short texts the prompt builders write around project data, in one language
+type promptLabels struct {
+	issueTemplateTitle, issueTemplateIntro, prTemplateTitle, prTemplateIntro string
+	templateName, templateDescription, templateStructure                     string
+	issueFormType, prFormType, formIntro                                     string
+	issueDescription, ticketTitle, ticketDescription, ticketCriteria         string
+}
+
+var promptLabelsByLang = map[string]promptLabels{
+	"en": {
+		issueTemplateTitle:  "## Project Issue Template",
+		issueTemplateIntro:  "The project has a specific issue template. You MUST follow its structure and format when generating the issue content.",
+		prTemplateTitle:     "## Project PR Template",
+		prTemplateIntro:     "The project has a specific PR template. You MUST follow its structure and format when generating the PR description.",
+		templateName:        "Template Name",
+		templateDescription: "Template Description",
+		templateStructure:   "Template Structure",
+		issueFormType:       "Template Type: GitHub Issue Form (YAML)",
+		prFormType:          "Template Type: GitHub PR Template (YAML/Markdown)",
+		formIntro:           "The template defines specific fields. Below is the structure you MUST complete:",
+		issueDescription:    "Description",
+		ticketTitle:         "**Title:**",
+		ticketDescription:   "**Description:**",
+		ticketCriteria:      "**Acceptance Criteria:**",
+	},
+	"es": {
+		issueTemplateTitle:  "## Template de Issue del Proyecto",
+		issueTemplateIntro:  "El proyecto tiene un template específico de issue. DEBES seguir su estructura y formato al generar el contenido del issue.",
+		prTemplateTitle:     "## Template de PR del Proyecto",
+		prTemplateIntro:     "El proyecto tiene un template específico de PR. DEBES seguir su estructura y formato al generar la descripción del PR.",
+		templateName:        "Nombre del Template",
+		templateDescription: "Descripción del Template",
+		templateStructure:   "Estructura del Template",
+		issueFormType:       "Tipo de Template: GitHub Issue Form (YAML)",
+		prFormType:          "Tipo de Template: GitHub PR Template (YAML/Markdown)",
+		formIntro:           "El template define campos específicos. A continuación la estructura que DEBES completar:",
+		issueDescription:    "Descripción",
+		ticketTitle:         "**Título:**",
+		ticketDescription:   "**Descripción:**",
+		ticketCriteria:      "**Criterios de Aceptación:**",
+	},
+	"pt": {
+		issueTemplateTitle:  "## Template de Issue do Projeto",
+		issueTemplateIntro:  "O projeto tem um template específico de issue. Você DEVE seguir sua estrutura e formato ao gerar o conteúdo da issue.",
+		prTemplateTitle:     "## Template de PR do Projeto",
+		prTemplateIntro:     "O projeto tem um template específico de PR. Você DEVE seguir sua estrutura e formato ao gerar a descrição do PR.",
+		templateName:        "Nome do Template",
+		templateDescription: "Descrição do Template",
+		templateStructure:   "Estrutura do Template",
+		issueFormType:       "Tipo de Template: GitHub Issue Form (YAML)",
+		prFormType:          "Tipo de Template: GitHub PR Template (YAML/Markdown)",
+		formIntro:           "O template define campos específicos. Abaixo está a estrutura que você DEVE preencher:",
+		issueDescription:    "Descrição",
+		ticketTitle:         "**Título:**",
+		ticketDescription:   "**Descrição:**",
+		ticketCriteria:      "**Critérios de Aceitação:**",
+	},
+	"fr": {
+		issueTemplateTitle:  "## Modèle d'Issue du Projet",
+		issueTemplateIntro:  "Le projet a un modèle d'issue spécifique. Tu DOIS suivre sa structure et son format pour générer le contenu de l'issue.",
+		prTemplateTitle:     "## Modèle de PR du Projet",
+		prTemplateIntro:     "Le projet a un modèle de PR spécifique. Tu DOIS suivre sa structure et son format pour générer la description de la PR.",
+		templateName:        "Nom du modèle",
+		templateDescription: "Description du modèle",
+		templateStructure:   "Structure du modèle",
+		issueFormType:       "Type de modèle : GitHub Issue Form (YAML)",
+		prFormType:          "Type de modèle : GitHub PR Template (YAML/Markdown)",
+		formIntro:           "Le modèle définit des champs spécifiques. Voici la structure que tu DOIS compléter :",
+		issueDescription:    "Description",
+		ticketTitle:         "**Titre :**",
+		ticketDescription:   "**Description :**",
+		ticketCriteria:      "**Critères d'acceptation :**",
+	},
+	"de": {
+		issueTemplateTitle:  "## Issue-Vorlage des Projekts",
+		issueTemplateIntro:  "Das Projekt hat eine eigene Issue-Vorlage. Du MUSST ihrer Struktur und ihrem Format folgen, wenn du den Inhalt des Issues erstellst.",
+		prTemplateTitle:     "## PR-Vorlage des Projekts",
+		prTemplateIntro:     "Das Projekt hat eine eigene PR-Vorlage. Du MUSST ihrer Struktur und ihrem Format folgen, wenn du die PR-Beschreibung erstellst.",
+		templateName:        "Name der Vorlage",
+		templateDescription: "Beschreibung der Vorlage",
+		templateStructure:   "Struktur der Vorlage",
+		issueFormType:       "Vorlagentyp: GitHub Issue Form (YAML)",
+		prFormType:          "Vorlagentyp: GitHub PR Template (YAML/Markdown)",
+		formIntro:           "Die Vorlage definiert bestimmte Felder. Das ist die Struktur, die du ausfüllen MUSST:",
+		issueDescription:    "Beschreibung",
+		ticketTitle:         "**Titel:**",
+		ticketDescription:   "**Beschreibung:**",
+		ticketCriteria:      "**Akzeptanzkriterien:**",
+	},
+	"it": {
+		issueTemplateTitle:  "## Template di Issue del Progetto",
+		issueTemplateIntro:  "Il progetto ha un template di issue specifico. DEVI seguirne la struttura e il formato quando generi il contenuto della issue.",
+		prTemplateTitle:     "## Template di PR del Progetto",
+		prTemplateIntro:     "Il progetto ha un template di PR specifico. DEVI seguirne la struttura e il formato quando generi la descrizione della PR.",
+		templateName:        "Nome del template",
+		templateDescription: "Descrizione del template",
+		templateStructure:   "Struttura del template",
+		issueFormType:       "Tipo di template: GitHub Issue Form (YAML)",
+		prFormType:          "Tipo di template: GitHub PR Template (YAML/Markdown)",
+		formIntro:           "Il template definisce campi specifici. Di seguito la struttura che DEVI completare:",
+		issueDescription:    "Descrizione",
+		ticketTitle:         "**Titolo:**",
+		ticketDescription:   "**Descrizione:**",
+		ticketCriteria:      "**Criteri di accettazione:**",
+	},
+}
+
+// getPromptLabels returns the labels of a language, English for the unsupported ones
+func getPromptLabels(lang string) promptLabels {
+	return promptLabelsByLang[promptLanguage(lang)]
+}
+
+// promptLanguage returns the language of the prompts for a language tag: pt-BR uses the pt prompts
+// and languages without prompts use the English ones.
+func promptLanguage(lang string) string {
+	return config.BaseLanguage(lang)
+}
+
 // FormatTemplateForPrompt formats a template for inclusion in an AI prompt.
 // It handles both Issue and PR templates with proper language support.
 func FormatTemplateForPrompt(template *models.IssueTemplate, lang string, templateType string) string {
@@ -383,53 +572,28 @@ func FormatTemplateForPrompt(template *models.IssueTemplate, lang string, templa
 		return ""
 	}
 
-	if lang == "" {
-		lang = "en"
-	}
-
+	labels := getPromptLabels(lang)
 	var sb strings.Builder
 	isIssue := templateType == "issue"
 
-	if lang == "es" {
-		if isIssue {
-			sb.WriteString("## Template de Issue del Proyecto\n\n")
-			sb.WriteString("El proyecto tiene un template específico de issue. DEBES seguir su estructura y formato al generar el contenido del issue.\n\n")
-		} else {
-			sb.WriteString("## Template de PR del Proyecto\n\n")
-			sb.WriteString("El proyecto tiene un template específico de PR. DEBES seguir su estructura y formato al generar la descripción del PR.\n\n")
-		}
+	if isIssue {
+		sb.WriteString(labels.issueTemplateTitle + "\n\n")
+		sb.WriteString(labels.issueTemplateIntro + "\n\n")
 	} else {
-		if isIssue {
-			sb.WriteString("## Project Issue Template\n\n")
-			sb.WriteString("The project has a specific issue template. You MUST follow its structure and format when generating the issue content.\n\n")
-		} else {
-			sb.WriteString("## Project PR Template\n\n")
-			sb.WriteString("The project has a specific PR template. You MUST follow its structure and format when generating the PR description.\n\n")
-		}
+		sb.WriteString(labels.prTemplateTitle + "\n\n")
+		sb.WriteString(labels.prTemplateIntro + "\n\n")
 	}
 
 	if template.Name != "" {
-		if lang == "es" {
-			sb.WriteString(fmt.Sprintf("Nombre del Template: %s\n", template.Name))
-		} else {
-			sb.WriteString(fmt.Sprintf("Template Name: %s\n", template.Name))
-		}
+		sb.WriteString(fmt.Sprintf("%s: %s\n", labels.templateName, template.Name))
 	}
 
 	if template.GetAbout() != "" {
-		if lang == "es" {
-			sb.WriteString(fmt.Sprintf("Descripción del Template: %s\n", template.GetAbout()))
-		} else {
-			sb.WriteString(fmt.Sprintf("Template Description: %s\n", template.GetAbout()))
-		}
+		sb.WriteString(fmt.Sprintf("%s: %s\n", labels.templateDescription, template.GetAbout()))
 	}
 
 	if template.BodyContent != "" {
-		if lang == "es" {
-			sb.WriteString("\nEstructura del Template:\n```markdown\n")
-		} else {
-			sb.WriteString("\nTemplate Structure:\n```markdown\n")
-		}
+		sb.WriteString(fmt.Sprintf("\n%s:\n```markdown\n", labels.templateStructure))
 		sb.WriteString(template.BodyContent)
 		sb.WriteString("\n```\n\n")
 		if isIssue {
@@ -439,21 +603,12 @@ func FormatTemplateForPrompt(template *models.IssueTemplate, lang string, templa
 		}
 		sb.WriteString("\n\n")
 	} else if len(template.Body) > 0 {
-		if lang == "es" {
-			if isIssue {
-				sb.WriteString("\nTipo de Template: GitHub Issue Form (YAML)\n")
-			} else {
-				sb.WriteString("\nTipo de Template: GitHub PR Template (YAML/Markdown)\n")
-			}
-			sb.WriteString("El template define campos específicos. A continuación la estructura que DEBES completar:\n\n")
+		if isIssue {
+			sb.WriteString("\n" + labels.issueFormType + "\n")
 		} else {
-			if isIssue {
-				sb.WriteString("\nTemplate Type: GitHub Issue Form (YAML)\n")
-			} else {
-				sb.WriteString("\nTemplate Type: GitHub PR Template (YAML/Markdown)\n")
-			}
-			sb.WriteString("The template defines specific fields. Below is the structure you MUST complete:\n\n")
+			sb.WriteString("\n" + labels.prFormType + "\n")
 		}
+		sb.WriteString(labels.formIntro + "\n\n")
 
 		for _, item := range template.Body {
 			if item.Type == "markdown" {
@@ -530,10 +685,20 @@ const (
 
 // GetPRIssueContextInstructions returns issue context instructions for PRs
 func GetPRIssueContextInstructions(locale string) string {
-	if locale == "es" {
+	switch promptLanguage(locale) {
+	case "es":
 		return prIssueContextInstructionsES
+	case "pt":
+		return prIssueContextInstructionsPT
+	case "fr":
+		return prIssueContextInstructionsFR
+	case "de":
+		return prIssueContextInstructionsDE
+	case "it":
+		return prIssueContextInstructionsIT
+	default:
+		return prIssueContextInstructionsEN
 	}
-	return prIssueContextInstructionsEN
 }
 
 // FormatIssuesForPrompt formats the issue list to be included in the prompt
@@ -542,26 +707,16 @@ func FormatIssuesForPrompt(issues []models.Issue, locale string) string {
 		return ""
 	}
 
+	labels := getPromptLabels(locale)
 	var result strings.Builder
 	for _, issue := range issues {
-		if locale == "es" {
-			result.WriteString(fmt.Sprintf("- Issue #%d: %s\n", issue.Number, issue.Title))
-			if issue.Description != "" {
-				desc := issue.Description
-				if len(desc) > 200 {
-					desc = desc[:200] + "..."
-				}
-				result.WriteString(fmt.Sprintf("  Descripción: %s\n", desc))
-			}
-		} else {
-			result.WriteString(fmt.Sprintf("- Issue #%d: %s\n", issue.Number, issue.Title))
-			if issue.Description != "" {
-				desc := issue.Description
-				if len(desc) > 200 {
-					desc = desc[:200] + "..."
-				}
-				result.WriteString(fmt.Sprintf("  Description: %s\n", desc))
+		result.WriteString(fmt.Sprintf("- Issue #%d: %s\n", issue.Number, issue.Title))
+		if issue.Description != "" {
+			desc := issue.Description
+			if len(desc) > 200 {
+				desc = desc[:200] + "..."
 			}
+			result.WriteString(fmt.Sprintf("  %s: %s\n", labels.issueDescription, desc))
 		}
 	}
 
@@ -574,10 +729,20 @@ const (
 )
 
 func GetTechnicalAnalysisInstruction(locale string) string {
-	if locale == "es" {
+	switch promptLanguage(locale) {
+	case "es":
 		return technicalAnalysisES
+	case "pt":
+		return technicalAnalysisPT
+	case "fr":
+		return technicalAnalysisFR
+	case "de":
+		return technicalAnalysisDE
+	case "it":
+		return technicalAnalysisIT
+	default:
+		return technicalAnalysisEN
 	}
-	return technicalAnalysisEN
 }
 
 const (
@@ -586,10 +751,20 @@ const (
 )
 
 func GetNoIssueReferenceInstruction(locale string) string {
-	if locale == "es" {
+	switch promptLanguage(locale) {
+	case "es":
 		return noIssueReferenceES
+	case "pt":
+		return noIssueReferencePT
+	case "fr":
+		return noIssueReferenceFR
+	case "de":
+		return noIssueReferenceDE
+	case "it":
+		return noIssueReferenceIT
+	default:
+		return noIssueReferenceEN
 	}
-	return noIssueReferenceEN
 }
 
 // Release Note Headers
@@ -620,10 +795,20 @@ var (
 )
 
 func GetReleaseNotesSectionHeaders(locale string) map[string]string {
-	if locale == "es" {
+	switch promptLanguage(locale) {
+	case "es":
 		return releaseHeadersES
+	case "pt":
+		return releaseHeadersPT
+	case "fr":
+		return releaseHeadersFR
+	case "de":
+		return releaseHeadersDE
+	case "it":
+		return releaseHeadersIT
+	default:
+		return releaseHeadersEN
 	}
-	return releaseHeadersEN
 }
 
 const (
@@ -676,9 +861,17 @@ const (
 
 // GetIssuePromptTemplate returns the appropriate issue generation template based on language
 func GetIssuePromptTemplate(lang string) string {
-	switch lang {
+	switch promptLanguage(lang) {
 	case "es":
 		return issuePromptTemplateES
+	case "pt":
+		return issuePromptTemplatePT
+	case "fr":
+		return issuePromptTemplateFR
+	case "de":
+		return issuePromptTemplateDE
+	case "it":
+		return issuePromptTemplateIT
 	default:
 		return issuePromptTemplateEN
 	}
@@ -686,10 +879,135 @@ func GetIssuePromptTemplate(lang string) string {
 
 // GetIssueDefaultStructure returns the default structure for issues when no template is provided
 func GetIssueDefaultStructure(lang string) string {
-	switch lang {
+	switch promptLanguage(lang) {
 	case "es":
 		return issueDefaultStructureES
+	case "pt":
+		return issueDefaultStructurePT
+	case "fr":
+		return issueDefaultStructureFR
+	case "de":
+		return issueDefaultStructureDE
+	case "it":
+		return issueDefaultStructureIT
 	default:
 		return issueDefaultStructureEN
 	}
 }
+
+const (
+	diffChunkPromptTemplateEN = `# Task
+  Summarize part {{.Part}} of {{.Parts}} of a git diff that is too large to send in one request.
+  Your summary replaces this part of the diff in a later prompt that writes commit messages, PR summaries or issues.
+  # Files
+  {{.Files}}
+  # Diff
+  {{.Diff}}
+  # Instructions
+  1. Write one section per file, starting with its path.
+  2. List what changed: added, removed or renamed functions, types, flags, configuration and tests.
+  3. Call out behavior changes, bug fixes and breaking changes explicitly.
+  4. Skip formatting-only changes. Do not invent anything that is not in the diff.
+  5. Answer in plain text, without introduction or conclusion.`
+
+	diffChunkPromptTemplateES = `# Tarea
+  Resumí la parte {{.Part}} de {{.Parts}} de un git diff que es demasiado grande para enviarlo en un solo pedido.
+  Tu resumen reemplaza esta parte del diff en un prompt posterior que escribe commits, resúmenes de PR o issues.
+  # Archivos
+  {{.Files}}
+  # Diff
+  {{.Diff}}
+  # Instrucciones
+  1. Escribí una sección por archivo, empezando por su ruta.
+  2. Listá qué cambió: funciones, tipos, flags, configuración y tests agregados, eliminados o renombrados.
+  3. Marcá explícitamente los cambios de comportamiento, bugs corregidos y cambios que rompen compatibilidad.
+  4. Ignorá los cambios de formato. No inventes nada que no esté en el diff.
+  5. Respondé en texto plano, sin introducción ni conclusión. Responde en ESPAÑOL.`
+
+	reducedDiffHeaderEN = `The diff is too large for the context window of the model, so it was summarized per file in {{.Parts}} parts. Treat these summaries as the diff.`
+	reducedDiffHeaderES = `El diff es demasiado grande para la ventana de contexto del modelo, así que se resumió por archivo en {{.Parts}} partes. Tratá estos resúmenes como el diff.`
+)
+
+// GetDiffChunkPromptTemplate returns the template that summarizes one chunk of a large diff
+func GetDiffChunkPromptTemplate(lang string) string {
+	switch promptLanguage(lang) {
+	case "es":
+		return diffChunkPromptTemplateES
+	case "pt":
+		return diffChunkPromptTemplatePT
+	case "fr":
+		return diffChunkPromptTemplateFR
+	case "de":
+		return diffChunkPromptTemplateDE
+	case "it":
+		return diffChunkPromptTemplateIT
+	default:
+		return diffChunkPromptTemplateEN
+	}
+}
+
+// GetReducedDiffHeader returns the note that introduces the summaries of a reduced diff
+func GetReducedDiffHeader(lang string) string {
+	switch promptLanguage(lang) {
+	case "es":
+		return reducedDiffHeaderES
+	case "pt":
+		return reducedDiffHeaderPT
+	case "fr":
+		return reducedDiffHeaderFR
+	case "de":
+		return reducedDiffHeaderDE
+	case "it":
+		return reducedDiffHeaderIT
+	default:
+		return reducedDiffHeaderEN
+	}
+}
+
+const (
+	repairPromptTemplateEN = `# Task
+  Your previous answer does not match the JSON schema required by the request below. Fix it.
+  # Original request
+  {{.Prompt}}
+  # Your previous answer
+  {{.Response}}
+  # Validation errors
+  {{.Errors}}
+  # Instructions
+  1. Return the complete corrected answer, not only the fixed fields.
+  2. Keep the content of the previous answer wherever it is valid.
+  3. Fill every required field and use only the allowed values.
+  4. Answer with the JSON object only, without markdown fences or comments.`
+
+	repairPromptTemplateES = `# Tarea
+  Tu respuesta anterior no cumple el esquema JSON que pide el pedido de abajo. Corregila.
+  # Pedido original
+  {{.Prompt}}
+  # Tu respuesta anterior
+  {{.Response}}
+  # Errores de validación
+  {{.Errors}}
+  # Instrucciones
+  1. Devolvé la respuesta completa corregida, no solo los campos arreglados.
+  2. Mantené el contenido de la respuesta anterior donde sea válido.
+  3. Completá todos los campos obligatorios y usá solo los valores permitidos.
+  4. Respondé solo con el objeto JSON, sin bloques de markdown ni comentarios.`
+)
+
+// GetRepairPromptTemplate returns the template that asks the model to fix an answer that failed validation
+func GetRepairPromptTemplate(lang string) string {
+	switch promptLanguage(lang) {
+	case "es":
+		return repairPromptTemplateES
+	case "pt":
+		return repairPromptTemplatePT
+	case "fr":
+		return repairPromptTemplateFR
+	case "de":
+		return repairPromptTemplateDE
+	case "it":
+		return repairPromptTemplateIT
+	default:
+		return repairPromptTemplateEN
+	}
+}
@@ -0,0 +1,232 @@
+package ai
+
+// German prompts, used for de and its regional variants like de-AT
+const (
+	issueReferenceInstructionsDE = `Es gibt ein zugehöriges Issue (#{{.IssueNumber}}), du MUSST die Referenz in den Commit-Titel aufnehmen:
+       - Für Features/Verbesserungen: "typ: nachricht (#{{.IssueNumber}})"
+       - Für Bugs: "fix: nachricht (#{{.IssueNumber}})" oder "fix(scope): nachricht (fixes #{{.IssueNumber}})"
+       - Gültige Beispiele:
+         ✅ feat: Unterstützung für Dark Mode hinzufügen (#{{.IssueNumber}})
+         ✅ fix: Authentifizierungsfehler beheben (fixes #{{.IssueNumber}})
+         ✅ feat(api): Caching-Schicht implementieren (#{{.IssueNumber}})
+       - Lass die Referenz auf Issue #{{.IssueNumber}} NIEMALS weg.`
+
+	prPromptTemplateDE = `# Aufgabe
+  Handle als Senior Tech Lead und erstelle eine Zusammenfassung des Pull Requests.
+  # Inhalt des PR
+  {{.PRContent}}
+  # Goldene Regeln (Vorgaben)
+  1. **Keine Halluzinationen:** Was nicht im Diff steht, darfst du NICHT erfinden.
+  2. **Ton:** Professionell, direkt, technisch. Schreibe in der ersten Person ("Ich habe implementiert", "Ich habe hinzugefügt").
+  # Anweisungen
+  1. Titel: Prägnant, aber aussagekräftig (max. 80 Zeichen).
+  2. Wichtigste Änderungen: Filtere das Rauschen. Erkläre die *technische Auswirkung*, nicht nur die Codeänderung.
+  3. Labels: Wähle mit Bedacht (feature, fix, refactor, docs, infra, test, breaking-change).
+
+  WICHTIG: Antworte auf DEUTSCH. Der gesamte Inhalt des JSON muss auf Deutsch sein.`
+
+	promptTemplateWithTicketDE = `# Aufgabe
+  Handle als Git-Spezialist und erstelle {{.Count}} Vorschläge für Commit-Nachrichten.
+  # Kontext
+  - Geänderte Dateien: {{.Files}}
+  - Diff: {{.Diff}}
+  - Ticket/Issue: {{.Ticket}}
+  - Letzte Historie: {{.History}}
+  - Issue-Anweisungen: {{.Instructions}}
+  # Qualitätsrichtlinien
+  1. **Conventional Commits:** Halte dich strikt an ` + "`typ(scope): beschreibung`" + `.
+     - Typen: feat, fix, refactor, perf, test, docs, chore, build, ci.
+  2. **Präzision:**
+     - ❌ SCHLECHT: "fix: diverse Korrekturen im Login" (Zu vage)
+     - ✅ GUT: "fix(auth): Fehler bei leerem Token behandeln (#42)" (Präzise)
+  3. **Scope:** Wenn du 'ui'-Dateien geändert hast, ist der Scope (ui). Bei 'api' ist er (api). Bei vielen Bereichen lass den Scope weg.
+  4. **Stil:**
+     - Titel: Imperativ ("hinzufügen", nicht "hinzugefügt").
+     - Beschreibung: Erste Person, professioneller Ton ("Ich habe die Abfrage optimiert, um die Antwortzeit zu verbessern").
+  5. **Prüfung der Anforderungen (WICHTIG):**
+     - Analysiere NUR die Änderungen des aktuellen Diffs gegen die Kriterien des Tickets.
+     - Markiere NUR Anforderungen als "missing", die im Diff NICHT sichtbar sind.
+     - Wenn die letzte Historie zeigt, dass etwas bereits in früheren Commits umgesetzt wurde, markiere es NICHT als fehlend.
+     - Wenn du im Diff Datei- oder Funktionsnamen siehst, die auf eine frühere Umsetzung hindeuten (z. B. "stats.go", "CountTokens"), gehe davon aus, dass sie existiert.
+     - Konzentriere dich darauf, was JETZT im Kontext des aktuellen Commits fehlt, nicht im gesamten Projekt.
+  {{.Style}}
+  Erstelle jetzt {{.Count}} Vorschläge.`
+
+	promptTemplateWithoutTicketDE = `# Aufgabe
+  Handle als Git-Spezialist und erstelle {{.Count}} Vorschläge für Commit-Nachrichten auf Basis der Codeänderungen.
+  # Eingaben
+  - Geänderte Dateien: {{.Files}}
+  - Codeänderungen (Diff): {{.Diff}}
+  - Issue-Anweisungen: {{.Instructions}}
+  - Historie: {{.History}}
+  # Vorgehen
+  1. **Diff analysieren:** Erkenne, welche Logik sich wirklich geändert hat. Ignoriere reine Formatierungs- und Leerzeichenänderungen.
+  2. **Einordnen:**
+     - Neues Feature? -> feat
+     - Bugfix? -> fix
+     - Codeänderung ohne Logikänderung? -> refactor
+     - Nur Dokumentation? -> docs
+  3. **Formulieren:**
+     - Titel: Imperativ, möglichst max. 50 Zeichen (z. B. "Validierung hinzufügen", nicht "Validierung wird hinzugefügt").
+     - Beschreibung: Erste Person, professioneller und natürlicher Ton. "Ich habe diese Validierung hinzugefügt, um Fehler X zu vermeiden".
+  # Stilbeispiele
+  - ❌ "update main.go" (Furchtbar, sagt nichts aus)
+  - ❌ "Fehler wurde behoben" (Passiv, zu roboterhaft)
+  - ✅ "fix(cli): Panic bei fehlender Konfiguration behandeln" (Perfekt)
+  {{.TechnicalInfo}}
+  {{.Style}}
+  Erstelle jetzt {{.Count}} Vorschläge.`
+
+	releasePromptTemplateDE = `# Aufgabe
+Erstelle professionelle Release Notes für eine CHANGELOG.md nach dem Standard "Keep a Changelog".
+# Release-Informationen
+- Repository: {{.RepoOwner}}/{{.RepoName}}
+- Versionen: {{.CurrentVersion}} -> {{.LatestVersion}} ({{.ReleaseDate}})
+# Changelog (Diff)
+{{.Changelog}}
+# Wichtige Anweisungen
+## 1. TECHNISCHES RAUSCHEN FILTERN
+**IGNORIERE** interne Wartung, Tippfehler und interne Dokumentation.
+**NIMM AUF**: Features, UX-/Performance-Verbesserungen, Bugfixes und Breaking Changes.
+## 2. SEMANTISCHE GRUPPIERUNG (ABSCHNITTE) - SEHR WICHTIG
+Du **MUSST** die Änderungen über das Feld "sections" des JSON-Schemas in thematische Abschnitte gruppieren.
+**Jeder Abschnitt** muss enthalten:
+- Einen aussagekräftigen und ansprechenden Titel (darf ein Emoji enthalten)
+- Eine Liste zusammengehöriger Einträge
+
+**Beispiele für gute Abschnittstitel:**
+- "✨ Verbesserungen bei KI und Generierung" - für Verbesserungen der KI-Generierung
+- "🛠️ Vorlagen und Konfiguration" - für Änderungen an Vorlagen und Konfiguration
+- "🛡️ Stabilität und Performance" - für Stabilitätsverbesserungen
+- "🎨 Benutzeroberfläche" - für visuelle Änderungen
+- "🚀 Performance" - für Optimierungen
+- "🔒 Sicherheit" - für Sicherheitsfixes
+- "📚 Dokumentation" - für Änderungen an der Dokumentation
+- "🔧 Developer Experience" - für DX-Verbesserungen
+
+**Wann welcher Typ:**
+- Gruppiere zusammengehörige Änderungen nach Funktionsbereich (z. B. KI, Vorlagen, CLI)
+- Gibt es viele kleine Änderungen eines Typs, fasse sie zusammen (z. B. "Bugfixes")
+- Verwende höchstens 5-6 Abschnitte, damit es übersichtlich bleibt
+
+## 3. STIL UND ERZÄHLWEISE (WICHTIG)
+- **Stimme:** Verwende "Wir haben hinzugefügt/Wir haben verbessert" (1. Person Plural). Vermeide das Passiv.
+- **Fokus:** Stelle den NUTZEN für die Anwender in den Mittelpunkt, nicht die technische Umsetzung.
+- **Format der Einträge:** Jeder Eintrag sollte ein vollständiger, beschreibender Satz sein.
+
+## 4. QUALITÄTSBEISPIELE (GOLDSTANDARD)
+❌ SCHLECHT: "feat: update user schema" (Zu technisch)
+✅ GUT: "Wir haben das Benutzerprofil erweitert, sodass es mehrere Adressen unterstützt."
+❌ SCHLECHT: "fix: fix crash in login" (Vage)
+✅ GUT: "Wir haben einen Absturz bei der Anmeldung über Google behoben."
+
+Erstelle die Release Notes jetzt mit dem JSON-Schema und semantischen Abschnitten. Antworte auf DEUTSCH.`
+)
+
+const (
+	templateInstructionsDE = `## Projektvorlage
+
+ Das Projekt hat eine eigene Vorlage. Du MUSST ihrer Struktur und ihrem Format folgen, wenn du den Inhalt erstellst.`
+
+	prTemplateInstructionsDE = `## PR-Vorlage des Projekts
+
+Das Projekt hat eine eigene PR-Vorlage. Du MUSST ihrer Struktur und ihrem Format folgen, wenn du die PR-Beschreibung erstellst.
+
+WICHTIG: Erstelle die PR-Beschreibung nach der Struktur und dem Format der obigen Vorlage. Fülle jeden Abschnitt auf Basis der Codeänderungen und des bereitgestellten Kontexts aus.`
+
+	prIssueContextInstructionsDE = `
+  **WICHTIG - Kontext der Issues/Tickets:**
+  Dieser PR bezieht sich auf folgende Issues:
+  {{.RelatedIssues}}
+
+  **VERPFLICHTENDE ANWEISUNGEN:**
+  1. Du MUSST am ANFANG der Zusammenfassung (erste Zeilen) die schließenden Referenzen angeben:
+     - Bei Bugfixes: "Fixes #N"
+     - Bei neuen Features: "Closes #N"
+     - Bei bloßem Bezug: "Relates to #N"
+     - Format: "Closes #39, Fixes #41" (durch Kommas getrennt)
+
+  2. Erwähne im Abschnitt der wichtigsten Änderungen ausdrücklich, wie jede Änderung das Issue adressiert.
+
+  3. Verwende das korrekte Format, damit GitHub die Issues automatisch verknüpft.
+
+  **Beispiel für das korrekte Format:**
+  Closes #39
+
+  - **Erste wichtige Änderung:**
+    - Zweck: Das in #39 gemeldete Problem beheben...
+    - Technische Auswirkung: ...
+  `
+
+	technicalAnalysisDE = `Liefere eine ausführliche technische Analyse mit: angewandten Best Practices, Auswirkungen auf Performance/Wartbarkeit und gegebenenfalls Sicherheitsaspekten.`
+	noIssueReferenceDE  = `Nimm keine Issue-Referenzen in den Titel auf.`
+)
+
+var releaseHeadersDE = map[string]string{
+	"breaking":      "BREAKING CHANGES:",
+	"features":      "NEUE FUNKTIONEN:",
+	"fixes":         "FEHLERBEHEBUNGEN:",
+	"improvements":  "VERBESSERUNGEN:",
+	"closed_issues": "GESCHLOSSENE ISSUES:",
+	"merged_prs":    "GEMERGTE PULL REQUESTS:",
+	"contributors":  "MITWIRKENDE",
+	"file_stats":    "DATEISTATISTIKEN:",
+	"deps":          "AKTUALISIERTE ABHÄNGIGKEITEN:",
+}
+
+const (
+	issuePromptTemplateDE = `# Aufgabe
+  Handle als Senior Tech Lead und erstelle auf Basis der Eingaben ein hochwertiges GitHub-Issue.
+
+  # Eingaben
+  {{.IssueInfo}}
+
+  # Goldene Regeln (Vorgaben)
+  1. **Aktive Stimme:** Schreibe in der ERSTEN PERSON ("Ich habe implementiert", "Ich habe hinzugefügt", "Wir haben refaktoriert"). Vermeide Passivformen wie "Es wurde implementiert".
+  2. **Kontext zuerst:** Erkläre das WARUM vor dem WAS.
+  3. **Genaue Einordnung:** Wähle immer mindestens eine Hauptkategorie: 'feature', 'fix' oder 'refactor'. Verwende 'fix' NUR für Fehlerbehebungen, 'refactor' für Codeverbesserungen ohne Logikänderung und 'feature' für neue Funktionen.
+  4. **Keine Emojis:** Verwende weder im Titel noch in der Beschreibung Emojis. Bleib rein textlich und professionell.
+  5. **Ausgewogene Labels:** Ziel sind 2 bis 4 passende Labels. Nimm die Hauptkategorie und gegebenenfalls dateibezogene Labels wie 'test', 'docs' oder 'infra' auf.
+
+  Erstelle das Issue jetzt. Antworte auf DEUTSCH.`
+
+	issueDefaultStructureDE = `
+  # Struktur der Beschreibung
+  Das Feld "description" muss Markdown sein und dieser Struktur folgen:
+  - ### Kontext (Motivation)
+  - ### Technische Details (Architekturänderungen, neue Modelle usw.)
+  - ### Auswirkungen (Nutzen)
+`
+)
+
+const (
+	diffChunkPromptTemplateDE = `# Aufgabe
+  Fasse Teil {{.Part}} von {{.Parts}} eines git diffs zusammen, der zu groß ist, um ihn in einer einzigen Anfrage zu senden.
+  Deine Zusammenfassung ersetzt diesen Teil des Diffs in einem späteren Prompt, der Commit-Nachrichten, PR-Zusammenfassungen oder Issues schreibt.
+  # Dateien
+  {{.Files}}
+  # Diff
+  {{.Diff}}
+  # Anweisungen
+  1. Schreibe einen Abschnitt pro Datei und beginne mit ihrem Pfad.
+  2. Liste auf, was sich geändert hat: hinzugefügte, entfernte oder umbenannte Funktionen, Typen, Flags, Konfiguration und Tests.
+  3. Nenne Verhaltensänderungen, Bugfixes und Breaking Changes ausdrücklich.
+  4. Überspringe reine Formatierungsänderungen. Erfinde nichts, was nicht im Diff steht.
+  5. Antworte in reinem Text, ohne Einleitung oder Fazit. Antworte auf DEUTSCH.`
+
+	reducedDiffHeaderDE = `Der Diff ist zu groß für das Kontextfenster des Modells, daher wurde er pro Datei in {{.Parts}} Teilen zusammengefasst. Behandle diese Zusammenfassungen als den Diff.`
+
+	repairPromptTemplateDE = `# Aufgabe
+  Deine vorherige Antwort entspricht nicht dem JSON-Schema, das die folgende Anfrage verlangt. Korrigiere sie.
+  # Ursprüngliche Anfrage
+  {{.Prompt}}
+  # Deine vorherige Antwort
+  {{.Response}}
+  # Validierungsfehler
+  {{.Errors}}
+  # Anweisungen
+  1. Gib die vollständige korrigierte Antwort zurück, nicht nur die korrigierten Felder.
+  2. Behalte den Inhalt der vorherigen Antwort bei, wo er gültig ist.
+  3. Fülle alle Pflichtfelder aus und verwende nur die erlaubten Werte.
+  4. Antworte nur mit dem JSON-Objekt, ohne Markdown-Blöcke oder Kommentare.`
+)
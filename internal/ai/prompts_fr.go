@@ -0,0 +1,232 @@
+package ai
+
+// French prompts, used for fr and its regional variants like fr-CA
+const (
+	issueReferenceInstructionsFR = `Il y a une issue associée (#{{.IssueNumber}}), tu DOIS inclure la référence dans le titre du commit :
+       - Pour les features/améliorations : "type: message (#{{.IssueNumber}})"
+       - Pour les bugs : "fix: message (#{{.IssueNumber}})" ou "fix(scope): message (fixes #{{.IssueNumber}})"
+       - Exemples valides :
+         ✅ feat: ajoute le support du mode sombre (#{{.IssueNumber}})
+         ✅ fix: corrige l'erreur d'authentification (fixes #{{.IssueNumber}})
+         ✅ feat(api): implémente une couche de cache (#{{.IssueNumber}})
+       - N'omets JAMAIS la référence à l'issue #{{.IssueNumber}}.`
+
+	prPromptTemplateFR = `# Tâche
+  Agis comme un Tech Lead Senior et génère un résumé de la Pull Request.
+  # Contenu de la PR
+  {{.PRContent}}
+  # Règles d'Or (Contraintes)
+  1. **Zéro hallucination :** Si ce n'est pas dans le diff, ne l'invente PAS.
+  2. **Ton :** Professionnel, direct, technique. Utilise la première personne ("J'ai implémenté", "J'ai ajouté").
+  # Instructions
+  1. Titre : Accrocheur mais descriptif (80 caractères max).
+  2. Changements Clés : Filtre le bruit. Explique l'*impact technique*, pas seulement le changement de code.
+  3. Labels : Choisis avec soin (feature, fix, refactor, docs, infra, test, breaking-change).
+
+  IMPORTANT : Réponds en FRANÇAIS. Tout le contenu du JSON doit être en français.`
+
+	promptTemplateWithTicketFR = `# Tâche
+  Agis comme un spécialiste Git et génère {{.Count}} suggestions de messages de commit.
+  # Contexte
+  - Fichiers modifiés : {{.Files}}
+  - Diff : {{.Diff}}
+  - Ticket/Issue : {{.Ticket}}
+  - Historique récent : {{.History}}
+  - Instructions de l'issue : {{.Instructions}}
+  # Critères de Qualité
+  1. **Conventional Commits :** Respecte strictement ` + "`type(scope): description`" + `.
+     - Types : feat, fix, refactor, perf, test, docs, chore, build, ci.
+  2. **Précision :**
+     - ❌ MAUVAIS : "fix: corrections diverses dans le login" (Trop vague)
+     - ✅ BON : "fix(auth): gère l'erreur de jeton nul (#42)" (Précis)
+  3. **Scope :** Si tu as modifié des fichiers 'ui', le scope est (ui). Si c'est 'api', alors (api). S'il y en a beaucoup, n'utilise pas de scope.
+  4. **Style :**
+     - Titre : À l'impératif ou au présent ("ajoute", pas "ajouté").
+     - Description : Première personne, ton professionnel ("J'ai optimisé la requête pour améliorer le temps de réponse").
+  5. **Validation des Exigences (IMPORTANT) :**
+     - Analyse UNIQUEMENT les changements du diff actuel par rapport aux critères du ticket.
+     - Marque comme "missing" SEULEMENT les exigences qui NE sont PAS visibles dans le diff.
+     - Si l'historique récent montre que quelque chose a déjà été implémenté dans des commits précédents, ne le marque PAS comme manquant.
+     - Si tu vois dans le diff des noms de fichiers ou de fonctions qui indiquent une implémentation antérieure (ex : "stats.go", "CountTokens"), considère qu'elle existe.
+     - Concentre-toi sur ce qui manque MAINTENANT dans le contexte du commit actuel, pas dans tout le projet.
+  {{.Style}}
+  Génère {{.Count}} suggestions maintenant.`
+
+	promptTemplateWithoutTicketFR = `# Tâche
+  Agis comme un spécialiste Git et génère {{.Count}} suggestions de messages de commit à partir des changements de code.
+  # Entrées
+  - Fichiers modifiés : {{.Files}}
+  - Changements (Diff) : {{.Diff}}
+  - Instructions des issues : {{.Instructions}}
+  - Historique : {{.History}}
+  # Stratégie de Génération
+  1. **Analyse le Diff :** Identifie la logique qui a vraiment changé. Ignore les changements de formatage/espaces.
+  2. **Catégorise :**
+     - Nouvelle feature ? -> feat
+     - Correction de bug ? -> fix
+     - Changement de code sans changement de logique ? -> refactor
+     - Seulement de la documentation ? -> docs
+  3. **Rédige :**
+     - Titre : À l'impératif, 50 caractères max si possible (ex : "ajoute une validation", pas "ajout en cours").
+     - Description : Première personne, ton professionnel et naturel. "J'ai ajouté cette validation pour éviter l'erreur X".
+  # Exemples de Style
+  - ❌ "update main.go" (Terrible, ne dit rien)
+  - ❌ "l'erreur a été corrigée" (Voix passive, trop robotique)
+  - ✅ "fix(cli): gère le panic quand la config est absente" (Parfait)
+  {{.TechnicalInfo}}
+  {{.Style}}
+  Génère {{.Count}} suggestions maintenant.`
+
+	releasePromptTemplateFR = `# Tâche
+Génère des release notes professionnelles pour un CHANGELOG.md en suivant le standard "Keep a Changelog".
+# Informations du Release
+- Dépôt : {{.RepoOwner}}/{{.RepoName}}
+- Versions : {{.CurrentVersion}} -> {{.LatestVersion}} ({{.ReleaseDate}})
+# Changelog (Diff)
+{{.Changelog}}
+# Instructions Critiques
+## 1. FILTRAGE DU BRUIT TECHNIQUE
+**IGNORE** la maintenance interne, les typos et la documentation interne.
+**INCLUS** les features, les améliorations d'UX/Performance, les corrections de bugs et les breaking changes.
+## 2. REGROUPEMENT SÉMANTIQUE (SECTIONS) - TRÈS IMPORTANT
+Tu **DOIS** regrouper les changements en sections thématiques en utilisant le champ "sections" du schéma JSON.
+**Chaque section** doit avoir :
+- Un titre descriptif et engageant (peut inclure un emoji)
+- Une liste d'éléments liés
+
+**Exemples de bons titres de section :**
+- "✨ Améliorations de l'IA et de la Génération" - pour les améliorations de la génération par IA
+- "🛠️ Modèles et Configuration" - pour les changements de modèles et de config
+- "🛡️ Stabilité et Performance" - pour les améliorations de stabilité
+- "🎨 Interface Utilisateur" - pour les changements visuels
+- "🚀 Performance" - pour les optimisations
+- "🔒 Sécurité" - pour les correctifs de sécurité
+- "📚 Documentation" - pour les changements de documentation
+- "🔧 Expérience Développeur" - pour les améliorations de DX
+
+**Quand utiliser chaque type :**
+- Regroupe les changements liés par domaine fonctionnel (ex : IA, Modèles, CLI)
+- S'il y a beaucoup de petits changements d'un même type, regroupe-les (ex : "Corrections de Bugs")
+- Utilise au maximum 5-6 sections pour rester clair
+
+## 3. STYLE ET NARRATION (IMPORTANT)
+- **Voix :** Utilise "Nous avons ajouté/Nous avons amélioré" (1re personne du pluriel). Évite la voix passive.
+- **Focus :** Mets l'accent sur le BÉNÉFICE pour l'utilisateur, pas sur l'implémentation technique.
+- **Format des éléments :** Chaque élément doit être une phrase complète et descriptive.
+
+## 4. EXEMPLES DE QUALITÉ (STANDARD D'OR)
+❌ MAUVAIS : "feat: update user schema" (Trop technique)
+✅ BON : "Nous avons amélioré le profil utilisateur pour prendre en charge plusieurs adresses."
+❌ MAUVAIS : "fix: fix crash in login" (Vague)
+✅ BON : "Nous avons corrigé un plantage lors de la connexion avec Google."
+
+Génère les release notes maintenant en utilisant le schéma JSON avec des sections sémantiques. Réponds en FRANÇAIS.`
+)
+
+const (
+	templateInstructionsFR = `## Modèle du Projet
+
+ Le projet a un modèle spécifique. Tu DOIS suivre sa structure et son format pour générer le contenu.`
+
+	prTemplateInstructionsFR = `## Modèle de PR du Projet
+
+Le projet a un modèle de PR spécifique. Tu DOIS suivre sa structure et son format pour générer la description de la PR.
+
+IMPORTANT : Génère la description de la PR en suivant la structure et le format du modèle ci-dessus. Remplis chaque section à partir des changements de code et du contexte fourni.`
+
+	prIssueContextInstructionsFR = `
+  **IMPORTANT - Contexte des Issues/Tickets :**
+  Cette PR est liée aux issues suivantes :
+  {{.RelatedIssues}}
+
+  **INSTRUCTIONS OBLIGATOIRES :**
+  1. Tu DOIS inclure AU DÉBUT du résumé (premières lignes) les références de fermeture :
+     - Si elle corrige des bugs : "Fixes #N"
+     - Si elle implémente des features : "Closes #N"
+     - Si elle est seulement liée : "Relates to #N"
+     - Format : "Closes #39, Fixes #41" (séparées par des virgules)
+
+  2. Dans la section des changements clés, mentionne explicitement comment chaque changement répond à l'issue.
+
+  3. Utilise le bon format pour que GitHub lie automatiquement les issues.
+
+  **Exemple de format correct :**
+  Closes #39
+
+  - **Premier changement clé :**
+    - Objectif : Résoudre le problème signalé dans #39...
+    - Impact technique : ...
+  `
+
+	technicalAnalysisFR = `Fournis une analyse technique détaillée incluant : les bonnes pratiques appliquées, l'impact sur la performance/maintenabilité et les considérations de sécurité le cas échéant.`
+	noIssueReferenceFR  = `N'inclus pas de références d'issues dans le titre.`
+)
+
+var releaseHeadersFR = map[string]string{
+	"breaking":      "CHANGEMENTS INCOMPATIBLES :",
+	"features":      "NOUVELLES FONCTIONNALITÉS :",
+	"fixes":         "CORRECTIONS DE BUGS :",
+	"improvements":  "AMÉLIORATIONS :",
+	"closed_issues": "ISSUES FERMÉES :",
+	"merged_prs":    "PULL REQUESTS FUSIONNÉES :",
+	"contributors":  "CONTRIBUTEURS",
+	"file_stats":    "STATISTIQUES DES FICHIERS :",
+	"deps":          "MISES À JOUR DES DÉPENDANCES :",
+}
+
+const (
+	issuePromptTemplateFR = `# Tâche
+  Agis comme un Tech Lead Senior et génère une issue GitHub de haute qualité à partir des entrées fournies.
+
+  # Entrées
+  {{.IssueInfo}}
+
+  # Règles d'Or (Contraintes)
+  1. **Voix Active :** Écris à la PREMIÈRE PERSONNE ("J'ai implémenté", "J'ai ajouté", "Nous avons refactorisé"). Évite la voix passive comme "Il a été implémenté".
+  2. **Le Contexte d'Abord :** Explique le POURQUOI avant le QUOI.
+  3. **Catégorisation Précise :** Choisis toujours au moins une catégorie principale : 'feature', 'fix' ou 'refactor'. Utilise 'fix' UNIQUEMENT pour les corrections de bugs. Utilise 'refactor' pour les améliorations de code sans changement de logique. Utilise 'feature' pour les nouvelles fonctionnalités.
+  4. **Pas d'Emojis :** N'utilise pas d'emojis dans le titre ni dans la description. Reste purement textuel et professionnel.
+  5. **Labels Équilibrés :** Vise 2 à 4 labels pertinents. Inclus la catégorie principale plus les labels liés aux fichiers comme 'test', 'docs' ou 'infra' si c'est pertinent.
+
+  Génère l'issue maintenant. Réponds en FRANÇAIS.`
+
+	issueDefaultStructureFR = `
+  # Structure de la Description
+  Le champ "description" doit être en Markdown et suivre cette structure :
+  - ### Contexte (Motivation)
+  - ### Détails Techniques (Changements d'architecture, nouveaux modèles, etc.)
+  - ### Impact (Bénéfices)
+`
+)
+
+const (
+	diffChunkPromptTemplateFR = `# Tâche
+  Résume la partie {{.Part}} sur {{.Parts}} d'un git diff trop volumineux pour être envoyé en une seule requête.
+  Ton résumé remplace cette partie du diff dans un prompt ultérieur qui écrit des messages de commit, des résumés de PR ou des issues.
+  # Fichiers
+  {{.Files}}
+  # Diff
+  {{.Diff}}
+  # Instructions
+  1. Écris une section par fichier, en commençant par son chemin.
+  2. Liste ce qui a changé : fonctions, types, flags, configuration et tests ajoutés, supprimés ou renommés.
+  3. Signale explicitement les changements de comportement, les bugs corrigés et les changements incompatibles.
+  4. Ignore les changements de formatage. N'invente rien qui ne soit pas dans le diff.
+  5. Réponds en texte brut, sans introduction ni conclusion. Réponds en FRANÇAIS.`
+
+	reducedDiffHeaderFR = `Le diff est trop volumineux pour la fenêtre de contexte du modèle, il a donc été résumé par fichier en {{.Parts}} parties. Traite ces résumés comme le diff.`
+
+	repairPromptTemplateFR = `# Tâche
+  Ta réponse précédente ne respecte pas le schéma JSON exigé par la requête ci-dessous. Corrige-la.
+  # Requête originale
+  {{.Prompt}}
+  # Ta réponse précédente
+  {{.Response}}
+  # Erreurs de validation
+  {{.Errors}}
+  # Instructions
+  1. Renvoie la réponse complète corrigée, pas seulement les champs corrigés.
+  2. Conserve le contenu de la réponse précédente là où il est valide.
+  3. Remplis tous les champs obligatoires et n'utilise que les valeurs autorisées.
+  4. Réponds uniquement avec l'objet JSON, sans blocs markdown ni commentaires.`
+)
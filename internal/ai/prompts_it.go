@@ -0,0 +1,232 @@
+package ai
+
+// Italian prompts, used for it and its regional variants like it-CH
+const (
+	issueReferenceInstructionsIT = `C'è una issue associata (#{{.IssueNumber}}), DEVI includere il riferimento nel titolo del commit:
+       - Per feature/miglioramenti: "tipo: messaggio (#{{.IssueNumber}})"
+       - Per i bug: "fix: messaggio (#{{.IssueNumber}})" oppure "fix(scope): messaggio (fixes #{{.IssueNumber}})"
+       - Esempi validi:
+         ✅ feat: aggiunge il supporto alla modalità scura (#{{.IssueNumber}})
+         ✅ fix: risolve l'errore di autenticazione (fixes #{{.IssueNumber}})
+         ✅ feat(api): implementa un livello di cache (#{{.IssueNumber}})
+       - Non omettere MAI il riferimento alla issue #{{.IssueNumber}}.`
+
+	prPromptTemplateIT = `# Compito
+  Agisci come un Tech Lead Senior e genera un riepilogo della Pull Request.
+  # Contenuto della PR
+  {{.PRContent}}
+  # Regole d'Oro (Vincoli)
+  1. **Zero allucinazioni:** Se non è nel diff, NON inventarlo.
+  2. **Tono:** Professionale, diretto, tecnico. Usa la prima persona ("Ho implementato", "Ho aggiunto").
+  # Istruzioni
+  1. Titolo: Accattivante ma descrittivo (max 80 caratteri).
+  2. Modifiche Principali: Filtra il rumore. Spiega l'*impatto tecnico*, non solo la modifica al codice.
+  3. Etichette: Scegli con criterio (feature, fix, refactor, docs, infra, test, breaking-change).
+
+  IMPORTANTE: Rispondi in ITALIANO. Tutto il contenuto del JSON deve essere in italiano.`
+
+	promptTemplateWithTicketIT = `# Compito
+  Agisci come uno specialista Git e genera {{.Count}} suggerimenti di messaggi di commit.
+  # Contesto
+  - File modificati: {{.Files}}
+  - Diff: {{.Diff}}
+  - Ticket/Issue: {{.Ticket}}
+  - Cronologia recente: {{.History}}
+  - Istruzioni della issue: {{.Instructions}}
+  # Criteri di Qualità
+  1. **Conventional Commits:** Rispetta rigorosamente ` + "`tipo(scope): descrizione`" + `.
+     - Tipi: feat, fix, refactor, perf, test, docs, chore, build, ci.
+  2. **Precisione:**
+     - ❌ MALE: "fix: correzioni varie nel login" (Troppo vago)
+     - ✅ BENE: "fix(auth): gestisce l'errore di token nullo (#42)" (Preciso)
+  3. **Scope:** Se hai modificato file di 'ui', lo scope è (ui). Se è 'api', è (api). Se sono molti, non usare lo scope.
+  4. **Stile:**
+     - Titolo: Modo imperativo o presente ("aggiunge", non "aggiunto").
+     - Descrizione: Prima persona, tono professionale ("Ho ottimizzato la query per migliorare il tempo di risposta").
+  5. **Verifica dei Requisiti (IMPORTANTE):**
+     - Analizza SOLO le modifiche del diff attuale rispetto ai criteri del ticket.
+     - Segna come "missing" SOLO i requisiti che NON sono visibili nel diff.
+     - Se la cronologia recente mostra che qualcosa è già stato implementato in commit precedenti, NON segnarlo come mancante.
+     - Se nel diff vedi nomi di file o funzioni che indicano un'implementazione precedente (es: "stats.go", "CountTokens"), considera che esista già.
+     - Concentrati su ciò che manca ORA nel contesto del commit attuale, non nell'intero progetto.
+  {{.Style}}
+  Genera {{.Count}} suggerimenti ora.`
+
+	promptTemplateWithoutTicketIT = `# Compito
+  Agisci come uno specialista Git e genera {{.Count}} suggerimenti di messaggi di commit basati sulle modifiche al codice.
+  # Input
+  - File modificati: {{.Files}}
+  - Modifiche (Diff): {{.Diff}}
+  - Istruzioni delle issue: {{.Instructions}}
+  - Cronologia: {{.History}}
+  # Strategia di Generazione
+  1. **Analizza il Diff:** Individua quale logica è cambiata davvero. Ignora le modifiche di formattazione/spazi.
+  2. **Classifica:**
+     - Nuova feature? -> feat
+     - Correzione di un bug? -> fix
+     - Modifica del codice senza cambiare la logica? -> refactor
+     - Solo documentazione? -> docs
+  3. **Scrivi:**
+     - Titolo: Imperativo, max 50 caratteri se possibile (es: "aggiunge validazione", non "aggiungendo").
+     - Descrizione: Prima persona, tono professionale e naturale. "Ho aggiunto questa validazione per evitare l'errore X".
+  # Esempi di Stile
+  - ❌ "update main.go" (Pessimo, non dice niente)
+  - ❌ "l'errore è stato corretto" (Forma passiva, troppo robotico)
+  - ✅ "fix(cli): gestisce il panic quando manca la config" (Perfetto)
+  {{.TechnicalInfo}}
+  {{.Style}}
+  Genera {{.Count}} suggerimenti ora.`
+
+	releasePromptTemplateIT = `# Compito
+Genera release notes professionali per un CHANGELOG.md seguendo lo standard "Keep a Changelog".
+# Dati del Release
+- Repository: {{.RepoOwner}}/{{.RepoName}}
+- Versioni: {{.CurrentVersion}} -> {{.LatestVersion}} ({{.ReleaseDate}})
+# Changelog (Diff)
+{{.Changelog}}
+# Istruzioni Critiche
+## 1. FILTRO DEL RUMORE TECNICO
+**IGNORA** la manutenzione interna, i refusi e la documentazione interna.
+**INCLUDI** feature, miglioramenti di UX/Performance, correzioni di bug e breaking change.
+## 2. RAGGRUPPAMENTO SEMANTICO (SEZIONI) - MOLTO IMPORTANTE
+**DEVI** raggruppare le modifiche in sezioni tematiche usando il campo "sections" dello schema JSON.
+**Ogni sezione** deve avere:
+- Un titolo descrittivo e accattivante (può includere un emoji)
+- Un elenco di elementi correlati
+
+**Esempi di buoni titoli di sezione:**
+- "✨ Miglioramenti dell'IA e della Generazione" - per miglioramenti alla generazione con IA
+- "🛠️ Template e Configurazione" - per modifiche a template e config
+- "🛡️ Stabilità e Performance" - per miglioramenti di stabilità
+- "🎨 Interfaccia Utente" - per modifiche visive
+- "🚀 Performance" - per ottimizzazioni
+- "🔒 Sicurezza" - per correzioni di sicurezza
+- "📚 Documentazione" - per modifiche alla documentazione
+- "🔧 Esperienza degli Sviluppatori" - per miglioramenti della DX
+
+**Quando usare ogni tipo:**
+- Raggruppa le modifiche correlate per area funzionale (es: IA, Template, CLI)
+- Se ci sono molte piccole modifiche dello stesso tipo, raggruppale (es: "Correzioni di Bug")
+- Usa al massimo 5-6 sezioni per mantenere la chiarezza
+
+## 3. STILE E NARRAZIONE (IMPORTANTE)
+- **Voce:** Usa "Abbiamo aggiunto/Abbiamo migliorato" (1ª persona plurale). Evita la forma passiva.
+- **Focus:** Concentrati sul BENEFICIO per l'utente, non sull'implementazione tecnica.
+- **Formato degli elementi:** Ogni elemento deve essere una frase completa e descrittiva.
+
+## 4. ESEMPI DI QUALITÀ (GOLD STANDARD)
+❌ MALE: "feat: update user schema" (Troppo tecnico)
+✅ BENE: "Abbiamo migliorato il profilo utente per supportare più indirizzi."
+❌ MALE: "fix: fix crash in login" (Vago)
+✅ BENE: "Abbiamo risolto un arresto anomalo durante l'accesso con Google."
+
+Genera ora le release notes usando lo schema JSON con sezioni semantiche. Rispondi in ITALIANO.`
+)
+
+const (
+	templateInstructionsIT = `## Template del Progetto
+
+ Il progetto ha un template specifico. DEVI seguirne la struttura e il formato quando generi il contenuto.`
+
+	prTemplateInstructionsIT = `## Template di PR del Progetto
+
+Il progetto ha un template di PR specifico. DEVI seguirne la struttura e il formato quando generi la descrizione della PR.
+
+IMPORTANTE: Genera la descrizione della PR seguendo la struttura e il formato del template qui sopra. Compila ogni sezione in base alle modifiche al codice e al contesto fornito.`
+
+	prIssueContextInstructionsIT = `
+  **IMPORTANTE - Contesto di Issue/Ticket:**
+  Questa PR è collegata alle seguenti issue:
+  {{.RelatedIssues}}
+
+  **ISTRUZIONI OBBLIGATORIE:**
+  1. DEVI includere ALL'INIZIO del riepilogo (prime righe) i riferimenti di chiusura:
+     - Se corregge bug: "Fixes #N"
+     - Se implementa feature: "Closes #N"
+     - Se è solo collegata: "Relates to #N"
+     - Formato: "Closes #39, Fixes #41" (separati da virgole)
+
+  2. Nella sezione delle modifiche principali, indica esplicitamente come ogni modifica risolve la issue.
+
+  3. Usa il formato corretto affinché GitHub colleghi automaticamente le issue.
+
+  **Esempio di formato corretto:**
+  Closes #39
+
+  - **Prima modifica principale:**
+    - Scopo: Risolvere il problema segnalato in #39...
+    - Impatto tecnico: ...
+  `
+
+	technicalAnalysisIT = `Fornisci un'analisi tecnica dettagliata che includa: buone pratiche applicate, impatto su performance/manutenibilità e considerazioni di sicurezza se pertinenti.`
+	noIssueReferenceIT  = `Non includere riferimenti a issue nel titolo.`
+)
+
+var releaseHeadersIT = map[string]string{
+	"breaking":      "MODIFICHE INCOMPATIBILI:",
+	"features":      "NUOVE FUNZIONALITÀ:",
+	"fixes":         "CORREZIONI DI BUG:",
+	"improvements":  "MIGLIORAMENTI:",
+	"closed_issues": "ISSUE CHIUSE:",
+	"merged_prs":    "PULL REQUEST UNITE:",
+	"contributors":  "CONTRIBUTORI",
+	"file_stats":    "STATISTICHE DEI FILE:",
+	"deps":          "AGGIORNAMENTI DELLE DIPENDENZE:",
+}
+
+const (
+	issuePromptTemplateIT = `# Compito
+  Agisci come un Tech Lead Senior e genera una issue di GitHub di alta qualità a partire dagli input forniti.
+
+  # Input
+  {{.IssueInfo}}
+
+  # Regole d'Oro (Vincoli)
+  1. **Forma Attiva:** Scrivi in PRIMA PERSONA ("Ho implementato", "Ho aggiunto", "Abbiamo rifattorizzato"). Evita la forma passiva come "È stato implementato".
+  2. **Prima il Contesto:** Spiega il PERCHÉ prima del COSA.
+  3. **Classificazione Precisa:** Scegli sempre almeno una categoria principale: 'feature', 'fix' o 'refactor'. Usa 'fix' SOLO per correzioni di bug. Usa 'refactor' per miglioramenti del codice senza modifiche alla logica. Usa 'feature' per nuove funzionalità.
+  4. **Niente Emoji:** Non usare emoji né nel titolo né nella descrizione. Mantieni uno stile puramente testuale e professionale.
+  5. **Etichette Equilibrate:** Punta a 2-4 etichette pertinenti. Includi la categoria principale più eventuali etichette legate ai file come 'test', 'docs' o 'infra', se pertinenti.
+
+  Genera la issue ora. Rispondi in ITALIANO.`
+
+	issueDefaultStructureIT = `
+  # Struttura della Descrizione
+  Il campo "description" deve essere in Markdown e seguire questa struttura:
+  - ### Contesto (Motivazione)
+  - ### Dettagli Tecnici (Modifiche architetturali, nuovi modelli, ecc.)
+  - ### Impatto (Benefici)
+`
+)
+
+const (
+	diffChunkPromptTemplateIT = `# Compito
+  Riassumi la parte {{.Part}} di {{.Parts}} di un git diff troppo grande per essere inviato in una sola richiesta.
+  Il tuo riassunto sostituisce questa parte del diff in un prompt successivo che scrive messaggi di commit, riepiloghi di PR o issue.
+  # File
+  {{.Files}}
+  # Diff
+  {{.Diff}}
+  # Istruzioni
+  1. Scrivi una sezione per file, iniziando dal suo percorso.
+  2. Elenca cosa è cambiato: funzioni, tipi, flag, configurazione e test aggiunti, rimossi o rinominati.
+  3. Segnala esplicitamente i cambiamenti di comportamento, i bug corretti e le modifiche incompatibili.
+  4. Ignora le modifiche di sola formattazione. Non inventare nulla che non sia nel diff.
+  5. Rispondi in testo semplice, senza introduzione né conclusione. Rispondi in ITALIANO.`
+
+	reducedDiffHeaderIT = `Il diff è troppo grande per la finestra di contesto del modello, quindi è stato riassunto per file in {{.Parts}} parti. Tratta questi riassunti come il diff.`
+
+	repairPromptTemplateIT = `# Compito
+  La tua risposta precedente non rispetta lo schema JSON richiesto dalla richiesta qui sotto. Correggila.
+  # Richiesta originale
+  {{.Prompt}}
+  # La tua risposta precedente
+  {{.Response}}
+  # Errori di validazione
+  {{.Errors}}
+  # Istruzioni
+  1. Restituisci la risposta completa corretta, non solo i campi sistemati.
+  2. Mantieni il contenuto della risposta precedente dove è valido.
+  3. Compila tutti i campi obbligatori e usa solo i valori consentiti.
+  4. Rispondi solo con l'oggetto JSON, senza blocchi markdown né commenti.`
+)
@@ -0,0 +1,232 @@
+package ai
+
+// Portuguese prompts, used for pt and its regional variants like pt-BR
+const (
+	issueReferenceInstructionsPT = `Existe uma issue associada (#{{.IssueNumber}}), você DEVE incluir a referência no título do commit:
+       - Para features/melhorias: "tipo: mensagem (#{{.IssueNumber}})"
+       - Para bugs: "fix: mensagem (#{{.IssueNumber}})" ou "fix(scope): mensagem (fixes #{{.IssueNumber}})"
+       - Exemplos válidos:
+         ✅ feat: adiciona suporte a modo escuro (#{{.IssueNumber}})
+         ✅ fix: corrige erro de autenticação (fixes #{{.IssueNumber}})
+         ✅ feat(api): implementa camada de cache (#{{.IssueNumber}})
+       - NUNCA omita a referência à issue #{{.IssueNumber}}.`
+
+	prPromptTemplatePT = `# Tarefa
+  Atue como um Tech Lead Sênior e gere um resumo do Pull Request.
+  # Conteúdo do PR
+  {{.PRContent}}
+  # Regras de Ouro (Restrições)
+  1. **Zero alucinações:** Se algo não está explícito no diff, não invente.
+  2. **Tom:** Profissional, próximo e direto. Use primeira pessoa ("Implementei", "Adicionei", "Corrigi"). Evite a voz passiva robótica ("Foi realizado").
+  # Instruções
+  1. Título: Descritivo e conciso (máx. 80 caracteres).
+  2. Mudanças Principais: Filtre o ruído. Explique o *impacto* técnico e o propósito, não só qual linha mudou.
+  3. Labels: Escolha com critério (feature, fix, refactor, docs, infra, test, breaking-change).
+
+  IMPORTANTE: Responda em PORTUGUÊS. Todo o conteúdo do JSON deve estar em português.`
+
+	promptTemplateWithTicketPT = `# Tarefa
+  Atue como um especialista em Git e gere {{.Count}} sugestões de mensagens de commit.
+  # Contexto
+  - Arquivos modificados: {{.Files}}
+  - Diff: {{.Diff}}
+  - Ticket/Issue: {{.Ticket}}
+  - Histórico recente: {{.History}}
+  - Instruções da issue: {{.Instructions}}
+  # Critérios de Qualidade
+  1. **Conventional Commits:** Siga estritamente ` + "`tipo(scope): descrição`" + `.
+     - Tipos: feat, fix, refactor, perf, test, docs, chore, build, ci.
+  2. **Precisão:**
+     - ❌ RUIM: "fix: várias correções no login" (Muito vago)
+     - ✅ BOM: "fix(auth): trata erro de token nulo (#42)" (Preciso)
+  3. **Scope:** Se você mexeu em arquivos de 'ui', o scope é (ui). Se for 'api', é (api). Se forem muitos, não use scope.
+  4. **Estilo:**
+     - Título: Modo imperativo ("adiciona", não "adicionado").
+     - Descrição: Primeira pessoa, tom profissional ("Otimizei a query para melhorar o tempo de resposta").
+  5. **Validação de Requisitos (IMPORTANTE):**
+     - Analise APENAS as mudanças do diff atual contra os critérios do ticket.
+     - Marque como "missing" SOMENTE requisitos que NÃO estão visíveis no diff.
+     - Se o histórico recente mostra que algo já foi implementado em commits anteriores, NÃO marque como faltante.
+     - Se você vê nomes de arquivos ou funções no diff que indicam uma implementação anterior (ex: "stats.go", "CountTokens"), assuma que já existe.
+     - Foque no que falta AGORA no contexto do commit atual, não no projeto inteiro.
+  {{.Style}}
+  Gere {{.Count}} sugestões agora.`
+
+	promptTemplateWithoutTicketPT = `# Tarefa
+  Atue como um especialista em Git e gere {{.Count}} sugestões de mensagens de commit baseadas nas mudanças de código.
+  # Entradas
+  - Arquivos modificados: {{.Files}}
+  - Mudanças (Diff): {{.Diff}}
+  - Instruções de issues: {{.Instructions}}
+  - Histórico: {{.History}}
+  # Estratégia de Geração
+  1. **Analise o Diff:** Identifique qual lógica realmente mudou. Ignore mudanças de formatação/espaços.
+  2. **Categorize:**
+     - Nova feature? -> feat
+     - Correção de bug? -> fix
+     - Mudança de código sem mudança de lógica? -> refactor
+     - Só documentação? -> docs
+  3. **Redija:**
+     - Título: Imperativo, máx. 50 caracteres se possível (ex: "adiciona validação", não "adicionando").
+     - Descrição: Primeira pessoa, tom profissional e natural. "Adicionei esta validação para evitar o erro X".
+  # Exemplos de Estilo
+  - ❌ "update main.go" (Péssimo, não diz nada)
+  - ❌ "o erro foi corrigido" (Voz passiva, muito robótico)
+  - ✅ "fix(cli): corrige panic quando não há config" (Bom)
+  {{.TechnicalInfo}}
+  {{.Style}}
+  Gere {{.Count}} sugestões agora.`
+
+	releasePromptTemplatePT = `# Tarefa
+Gere release notes profissionais para um CHANGELOG.md seguindo o padrão "Keep a Changelog".
+# Dados do Release
+- Repositório: {{.RepoOwner}}/{{.RepoName}}
+- Versões: {{.CurrentVersion}} -> {{.LatestVersion}} ({{.ReleaseDate}})
+# Changelog (Diff)
+{{.Changelog}}
+# Instruções Críticas
+## 1. FILTRAGEM DE RUÍDO TÉCNICO
+**IGNORE** commits de manutenção interna, typos e docs internos.
+**INCLUA** features, melhorias de UX/Performance, correções de bugs e breaking changes.
+## 2. AGRUPAMENTO SEMÂNTICO (SEÇÕES) - MUITO IMPORTANTE
+Você **DEVE** agrupar as mudanças em seções temáticas usando o campo "sections" do esquema JSON.
+**Cada seção** deve ter:
+- Um título descritivo e atraente (pode incluir emoji)
+- Uma lista de itens relacionados
+
+**Exemplos de bons títulos de seção:**
+- "✨ Melhorias de IA e Geração" - para melhorias na geração com IA
+- "🛠️ Templates e Configuração" - para mudanças em templates e config
+- "🛡️ Estabilidade e Performance" - para melhorias de estabilidade
+- "🎨 Interface do Usuário" - para mudanças visuais
+- "🚀 Performance" - para otimizações
+- "🔒 Segurança" - para correções de segurança
+- "📚 Documentação" - para mudanças na documentação
+- "🔧 Experiência de Desenvolvimento" - para melhorias de DX
+
+**Quando usar cada tipo:**
+- Agrupe mudanças relacionadas por área funcional (ex: IA, Templates, CLI)
+- Se houver muitas mudanças pequenas de um tipo, agrupe-as (ex: "Correções de Bugs")
+- Use no máximo 5-6 seções para manter a clareza
+
+## 3. ESTILO E NARRATIVA (IMPORTANTE)
+- **Voz:** Use "Adicionamos/Melhoramos" (1ª pessoa do plural). Evite "Foi implementado".
+- **Foco:** Concentre-se no BENEFÍCIO para o usuário, não na implementação técnica.
+- **Formato dos itens:** Cada item deve ser uma frase completa e descritiva.
+
+## 4. EXEMPLOS DE QUALIDADE (PADRÃO OURO)
+❌ RUIM: "feat: update user schema" (Técnico, chato)
+✅ BOM: "Melhoramos o perfil do usuário para suportar vários endereços."
+❌ RUIM: "fix: fix crash in login" (Vago)
+✅ BOM: "Corrigimos um fechamento inesperado ao entrar com o Google."
+
+Gere as release notes agora usando o esquema JSON com seções semânticas. Responda em PORTUGUÊS.`
+)
+
+const (
+	templateInstructionsPT = `## Template do Projeto
+
+ O projeto tem um template específico. Você DEVE seguir sua estrutura e formato ao gerar o conteúdo.`
+
+	prTemplateInstructionsPT = `## Template de PR do Projeto
+
+O projeto tem um template específico de PR. Você DEVE seguir sua estrutura e formato ao gerar a descrição do PR.
+
+IMPORTANTE: Gere a descrição do PR seguindo a estrutura e o formato mostrados no template acima. Preencha cada seção com base nas mudanças de código e no contexto fornecido.`
+
+	prIssueContextInstructionsPT = `
+  **IMPORTANTE - Contexto de Issues/Tickets:**
+  Este PR está relacionado com as seguintes issues:
+  {{.RelatedIssues}}
+
+  **INSTRUÇÕES OBRIGATÓRIAS:**
+  1. Você DEVE incluir NO INÍCIO do resumo (primeiras linhas) as referências de fechamento:
+     - Se corrige bugs: "Fixes #N"
+     - Se implementa features: "Closes #N"
+     - Se apenas relaciona: "Relates to #N"
+     - Formato: "Closes #39, Fixes #41" (separados por vírgulas)
+
+  2. Na seção de mudanças principais, mencione explicitamente como cada mudança resolve a issue.
+
+  3. Use o formato correto para que o GitHub vincule as issues automaticamente.
+
+  **Exemplo de formato correto:**
+  Closes #39
+
+  - **Primeira mudança principal:**
+    - Propósito: Resolver o problema reportado em #39...
+    - Impacto técnico: ...
+  `
+
+	technicalAnalysisPT = `Forneça uma análise técnica detalhada incluindo: boas práticas aplicadas, impacto em performance/manutenibilidade e considerações de segurança, se aplicável.`
+	noIssueReferencePT  = `Não inclua referências a issues no título.`
+)
+
+var releaseHeadersPT = map[string]string{
+	"breaking":      "MUDANÇAS INCOMPATÍVEIS:",
+	"features":      "NOVAS FUNCIONALIDADES:",
+	"fixes":         "CORREÇÕES DE BUGS:",
+	"improvements":  "MELHORIAS:",
+	"closed_issues": "ISSUES FECHADAS:",
+	"merged_prs":    "PULL REQUESTS MERGEADOS:",
+	"contributors":  "CONTRIBUIDORES",
+	"file_stats":    "ESTATÍSTICAS DE ARQUIVOS:",
+	"deps":          "ATUALIZAÇÕES DE DEPENDÊNCIAS:",
+}
+
+const (
+	issuePromptTemplatePT = `# Tarefa
+  Atue como um Tech Lead e gere uma issue do GitHub profissional baseada nas entradas.
+
+  # Entradas
+  {{.IssueInfo}}
+
+  # Regras de Ouro (Restrições)
+  1. **Voz Ativa:** Escreva em PRIMEIRA PESSOA ("Implementei", "Adicionei", "Corrigi"). Evite a voz passiva robótica.
+  2. **Contexto Primeiro:** Explique o POR QUÊ da mudança antes do QUÊ.
+  3. **Categorização Precisa:** Escolha sempre pelo menos uma categoria principal: 'feature', 'fix' ou 'refactor'. Use 'fix' SOMENTE para correções de bugs. Use 'refactor' para melhorias de código sem mudanças de lógica. Use 'feature' para funcionalidades novas.
+  4. **Sem Emojis:** Não use emojis nem no título nem na descrição. Mantenha um estilo sóbrio e técnico.
+  5. **Labels Equilibradas:** Busque entre 2 e 4 labels relevantes. Inclua a categoria principal mais qualquer label por tipo de arquivo como 'test', 'docs' ou 'infra', se corresponder.
+
+  Gere a issue agora. Responda em PORTUGUÊS.`
+
+	issueDefaultStructurePT = `
+  # Estrutura da Descrição
+  O campo "description" deve ser Markdown e seguir esta estrutura:
+  - ### Contexto (Qual é a motivação ou o problema que isto resolve?)
+  - ### Detalhes Técnicos (Mudanças de arquitetura, modelos novos, refactors)
+  - ### Impacto (O que o usuário ou o desenvolvedor ganha com isto?)
+`
+)
+
+const (
+	diffChunkPromptTemplatePT = `# Tarefa
+  Resuma a parte {{.Part}} de {{.Parts}} de um git diff que é grande demais para ser enviado em uma única requisição.
+  Seu resumo substitui esta parte do diff em um prompt posterior que escreve commits, resumos de PR ou issues.
+  # Arquivos
+  {{.Files}}
+  # Diff
+  {{.Diff}}
+  # Instruções
+  1. Escreva uma seção por arquivo, começando pelo seu caminho.
+  2. Liste o que mudou: funções, tipos, flags, configuração e testes adicionados, removidos ou renomeados.
+  3. Destaque explicitamente mudanças de comportamento, bugs corrigidos e mudanças incompatíveis.
+  4. Ignore mudanças só de formatação. Não invente nada que não esteja no diff.
+  5. Responda em texto simples, sem introdução nem conclusão. Responda em PORTUGUÊS.`
+
+	reducedDiffHeaderPT = `O diff é grande demais para a janela de contexto do modelo, então foi resumido por arquivo em {{.Parts}} partes. Trate estes resumos como o diff.`
+
+	repairPromptTemplatePT = `# Tarefa
+  Sua resposta anterior não segue o esquema JSON exigido pela requisição abaixo. Corrija-a.
+  # Requisição original
+  {{.Prompt}}
+  # Sua resposta anterior
+  {{.Response}}
+  # Erros de validação
+  {{.Errors}}
+  # Instruções
+  1. Devolva a resposta completa corrigida, não apenas os campos ajustados.
+  2. Mantenha o conteúdo da resposta anterior onde ele for válido.
+  3. Preencha todos os campos obrigatórios e use apenas os valores permitidos.
+  4. Responda apenas com o objeto JSON, sem blocos de markdown nem comentários.`
+)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/thomas-vilte/matecommit/internal/config"
 	"github.com/thomas-vilte/matecommit/internal/models"
 )
 
@@ -120,7 +121,7 @@ func TestGetPRPromptTemplate(t *testing.T) {
 	})
 
 	t.Run("Unknown language defaults to English", func(t *testing.T) {
-		result := GetPRPromptTemplate("fr")
+		result := GetPRPromptTemplate("ja")
 		expected := GetPRPromptTemplate("en")
 
 		assert.Equal(t, expected, result)
@@ -539,7 +540,7 @@ func TestGetReleaseNotesSectionHeaders(t *testing.T) {
 	})
 
 	t.Run("Unknown language defaults to English", func(t *testing.T) {
-		headers := GetReleaseNotesSectionHeaders("fr")
+		headers := GetReleaseNotesSectionHeaders("ja")
 		englishHeaders := GetReleaseNotesSectionHeaders("en")
 
 		assert.Equal(t, englishHeaders, headers)
@@ -603,3 +604,81 @@ func TestPromptRenderingWorkflow(t *testing.T) {
 		assert.Contains(t, result, "Closes #N")
 	})
 }
+
+func TestFormatCommitStyle(t *testing.T) {
+	style := &models.CommitStyle{
+		CommitCount:      120,
+		Conventional:     0.95,
+		Types:            []models.StyleCount{{Name: "feat", Count: 50}, {Name: "fix", Count: 40}},
+		Scopes:           []models.StyleCount{{Name: "ai", Count: 30}},
+		Casing:           models.CasingLower,
+		TicketPlacement:  models.TicketPlacementPrefix,
+		TicketExample:    "PROJ-12",
+		BodyShare:        0.6,
+		AverageBodyLines: 3,
+		AverageSubject:   48,
+		Examples:         []string{"PROJ-12 feat(ai): add routing rules"},
+	}
+
+	t.Run("renders rules and examples", func(t *testing.T) {
+		got := FormatCommitStyle("en", style)
+
+		assert.Contains(t, got, "Learned from the last 120 commits")
+		assert.Contains(t, got, "used in 95% of the commits")
+		assert.Contains(t, got, "Types in use (most used first): feat, fix.")
+		assert.Contains(t, got, "Common scopes: ai.")
+		assert.Contains(t, got, "Do not use emojis.")
+		assert.Contains(t, got, "Start the description in lowercase.")
+		assert.Contains(t, got, "at the start of the title (e.g. PROJ-12)")
+		assert.Contains(t, got, "60% of the commits have a body, about 3 lines long.")
+		assert.Contains(t, got, "  - PROJ-12 feat(ai): add routing rules")
+	})
+
+	t.Run("spanish", func(t *testing.T) {
+		assert.Contains(t, FormatCommitStyle("es", style), "Estilo de Commits del Repositorio")
+	})
+
+	t.Run("no style renders nothing", func(t *testing.T) {
+		assert.Empty(t, FormatCommitStyle("en", nil))
+	})
+
+	t.Run("commit prompt includes the style", func(t *testing.T) {
+		prompt := BuildCommitPrompt("en", models.CommitInfo{Files: []string{"main.go"}, Diff: "diff", Style: style}, 3)
+
+		require.Contains(t, prompt, "# Repository Commit Style")
+		assert.Less(t, strings.Index(prompt, "# Repository Commit Style"), strings.Index(prompt, "Generate 3 suggestions now."))
+	})
+}
+
+func TestPromptLanguages(t *testing.T) {
+	t.Run("every language has its own prompts that render", func(t *testing.T) {
+		for _, lang := range config.SupportedLanguages() {
+			for _, name := range PromptTemplateNames() {
+				text, ok := BuiltinPromptTemplate(name, lang)
+				require.True(t, ok)
+				if lang != "en" {
+					english, _ := BuiltinPromptTemplate(name, "en")
+					assert.NotEqual(t, english, text, "%s has no %s prompt", name, lang)
+				}
+
+				_, err := RenderPrompt(name, text, SamplePromptData())
+				assert.NoError(t, err, "%s (%s)", name, lang)
+			}
+
+			assert.Contains(t, promptLabelsByLang, lang)
+			assert.Contains(t, commitStyleLabelsByLang, lang)
+			assert.Len(t, GetReleaseNotesSectionHeaders(lang), 9)
+		}
+	})
+
+	t.Run("regional tags use the prompts of their base language", func(t *testing.T) {
+		assert.Equal(t, GetPRPromptTemplate("pt"), GetPRPromptTemplate("pt-BR"))
+		assert.Equal(t, GetCommitPromptTemplate("de", true), GetCommitPromptTemplate("de_AT", true))
+		assert.Contains(t, FormatIssuesForPrompt([]models.Issue{{Number: 1, Title: "Login", Description: "Falha no login"}}, "pt-BR"), "Descrição: Falha no login")
+	})
+
+	t.Run("unsupported languages use the English prompts", func(t *testing.T) {
+		assert.Equal(t, GetIssuePromptTemplate("en"), GetIssuePromptTemplate("ja-JP"))
+		assert.Equal(t, getPromptLabels("en"), getPromptLabels("ja"))
+	})
+}
@@ -0,0 +1,126 @@
+package ai
+
+import (
+	"context"
+	"strings"
+
+	"github.com/thomas-vilte/matecommit/internal/config"
+	domainErrors "github.com/thomas-vilte/matecommit/internal/errors"
+	"github.com/thomas-vilte/matecommit/internal/logger"
+	"github.com/thomas-vilte/matecommit/internal/models"
+)
+
+var _ PRSummarizer = (*PRSummarizerService)(nil)
+
+// PRSummarizerService summarizes pull requests with any LLM client.
+type PRSummarizerService struct {
+	client     LLMClient
+	wrapper    *CostAwareWrapper
+	generateFn GenerateFunc
+	reducer    *diffReducer
+	config     *config.Config
+}
+
+type PRSummaryJSON struct {
+	Title  string   `json:"title"`
+	Body   string   `json:"body"`
+	Labels []string `json:"labels"`
+}
+
+// NewPRSummarizerService creates the pull request summarizer on top of client.
+func NewPRSummarizerService(client LLMClient, cfg *config.Config, onConfirmation ConfirmationCallback) (*PRSummarizerService, error) {
+	wrapper, err := newServiceWrapper(client, cfg, 500, onConfirmation)
+	if err != nil {
+		return nil, err
+	}
+
+	reducer, err := newDiffReducer(client, cfg, "summarize-pr")
+	if err != nil {
+		return nil, err
+	}
+
+	return &PRSummarizerService{
+		client:     client,
+		wrapper:    wrapper,
+		generateFn: commandGenerateFunc(client, cfg, "summarize-pr"),
+		reducer:    reducer,
+		config:     cfg,
+	}, nil
+}
+
+func (s *PRSummarizerService) GeneratePRSummary(ctx context.Context, prContent string, availableLabels []string) (models.PRSummary, error) {
+	log := logger.FromContext(ctx)
+
+	log.Info("generating PR summary",
+		"provider", s.client.GetProviderName(),
+		"content_length", len(prContent),
+		"available_labels_count", len(availableLabels))
+
+	// The PR content ends with the diff, which is summarized when it does not fit the model
+	prContent, err := s.reducer.Reduce(ctx, prContent)
+	if err != nil {
+		log.Error("failed to reduce PR diff",
+			"error", err)
+		return models.PRSummary{}, err
+	}
+
+	prompt := s.generatePRPrompt(prContent, availableLabels)
+
+	log.Debug("calling AI for PR summary",
+		"prompt_length", len(prompt))
+
+	resp, usage, err := s.wrapper.WrapGenerate(ctx, "summarize-pr", prompt, s.generateFn)
+	if err != nil {
+		log.Error("failed to generate PR summary",
+			"error", err)
+		return models.PRSummary{}, err
+	}
+
+	responseText := extractResponseText(resp)
+	if responseText == "" {
+		return models.PRSummary{}, domainErrors.ErrInvalidAIOutput.
+			WithContext("reason", "empty response from AI").
+			WithContext("operation", "summarize PR")
+	}
+
+	jsonSummary, err := decodeJSON[PRSummaryJSON](responseText)
+	if err != nil {
+		respLen := len(responseText)
+		preview := responseText
+		if respLen > 500 {
+			preview = responseText[:500] + "..."
+		}
+		return models.PRSummary{}, domainErrors.ErrInvalidAIOutput.
+			WithContext("reason", "failed to parse JSON").
+			WithContext("response_length", respLen).
+			WithContext("preview", preview).
+			WithError(err)
+	}
+	if strings.TrimSpace(jsonSummary.Title) == "" {
+		respLen := len(responseText)
+		preview := responseText
+		if respLen > 500 {
+			preview = responseText[:500] + "..."
+		}
+		log.Warn("AI generated no PR title",
+			"response_length", respLen)
+		return models.PRSummary{}, domainErrors.ErrInvalidAIOutput.
+			WithContext("reason", "AI generated no PR title").
+			WithContext("response_length", respLen).
+			WithContext("preview", preview)
+	}
+
+	log.Info("PR summary generated successfully",
+		"labels_count", len(jsonSummary.Labels))
+
+	return models.PRSummary{
+		Title:  jsonSummary.Title,
+		Body:   jsonSummary.Body,
+		Labels: CleanLabels(jsonSummary.Labels, availableLabels),
+		Usage:  usage,
+	}, nil
+}
+
+func (s *PRSummarizerService) generatePRPrompt(prContent string, availableLabels []string) string {
+	return BuildPRPrompt(s.config.Language, prContent, availableLabels)
+}
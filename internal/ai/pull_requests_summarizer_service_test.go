@@ -0,0 +1,102 @@
+package ai
+
+import (
+	"context"
+	stdErrors "errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thomas-vilte/matecommit/internal/config"
+	"github.com/thomas-vilte/matecommit/internal/models"
+)
+
+func TestPRSummarizerService(t *testing.T) {
+	t.Run("GeneratePRSummary with client error", func(t *testing.T) {
+		// Arrange
+		t.Setenv("HOME", t.TempDir())
+		ctx := context.Background()
+		client := &fakeLLMClient{provider: "fake", model: "fake-model", err: stdErrors.New("boom")}
+
+		summarizer, err := NewPRSummarizerService(client, &config.Config{}, nil)
+		assert.NoError(t, err, "Error creando summarizer")
+		summarizer.wrapper.SetSkipConfirmation(true)
+
+		// Act
+		summary, err := summarizer.GeneratePRSummary(ctx, "", nil)
+
+		// Assert
+		assert.Equal(t, models.PRSummary{}, summary, "No deberían generarse resúmenes si falla el cliente")
+		assert.Error(t, err, "Debería retornar el error del cliente")
+	})
+
+	t.Run("generatePRPrompt should format correctly", func(t *testing.T) {
+		// Arrange
+		cfg := &config.Config{
+			Language: "en",
+		}
+
+		summarizer, err := NewPRSummarizerService(newFakeClient(), cfg, nil)
+		assert.NoError(t, err)
+
+		prContent := "Some PR content to summarize"
+
+		// Act
+		prompt := summarizer.generatePRPrompt(prContent, nil)
+
+		// Assert
+		assert.Contains(t, prompt, "Some PR content to summarize", "El prompt debe contener el contenido del PR")
+		assert.Contains(t, prompt, "Catchy but descriptive", "El prompt debe solicitar un título descriptivo")
+		assert.Contains(t, prompt, "Key Changes", "El prompt debe solicitar cambios clave")
+		assert.Contains(t, prompt, "Labels: Choose wisely", "El prompt debe solicitar etiquetas con criterio")
+	})
+}
+
+func TestGeneratePRSummary_HappyPath(t *testing.T) {
+	tmpHome, err := os.MkdirTemp("", "matecommit-test-pr-*")
+	assert.NoError(t, err)
+	defer func() {
+		if err := os.RemoveAll(tmpHome); err != nil {
+			return
+		}
+	}()
+	oldHome := os.Getenv("HOME")
+	_ = os.Setenv("HOME", tmpHome)
+	defer func() {
+		if err := os.Setenv("HOME", oldHome); err != nil {
+			return
+		}
+	}()
+
+	ctx := context.Background()
+	cfg := &config.Config{}
+	summarizer, _ := NewPRSummarizerService(newFakeClient(), cfg, nil)
+	summarizer.wrapper.SetSkipConfirmation(true)
+
+	t.Run("successful PR summary", func(t *testing.T) {
+		expectedJSON := `{"title": "Awesome Feature", "body": "This PR adds awesome feature", "labels": ["feature"]}`
+		summarizer.generateFn = func(ctx context.Context, mName string, p string) (interface{}, *models.TokenUsage, error) {
+			return expectedJSON, &models.TokenUsage{TotalTokens: 50}, nil
+		}
+
+		summary, err := summarizer.GeneratePRSummary(ctx, "successful content", nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Awesome Feature", summary.Title)
+		assert.Equal(t, "This PR adds awesome feature", summary.Body)
+		assert.Contains(t, summary.Labels, "feature")
+	})
+
+	t.Run("empty title error", func(t *testing.T) {
+		expectedJSON := `{"title": "", "body": "no title", "labels": []}`
+		summarizer.generateFn = func(ctx context.Context, mName string, p string) (interface{}, *models.TokenUsage, error) {
+			return expectedJSON, &models.TokenUsage{}, nil
+		}
+
+		summary, err := summarizer.GeneratePRSummary(ctx, "content with empty title", nil)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid AI output format")
+		assert.Empty(t, summary.Title)
+	})
+}
@@ -0,0 +1,33 @@
+package ai
+
+import (
+	"context"
+
+	"github.com/thomas-vilte/matecommit/internal/config"
+	"github.com/thomas-vilte/matecommit/internal/redact"
+)
+
+// RedactionReporter is told what was masked in a prompt before it is sent.
+// Returning false cancels the call.
+type RedactionReporter func(command string, report redact.Report) (proceed bool)
+
+type redactionReporterKey struct{}
+
+// WithRedactionReporter makes every wrapped call made with ctx report its redactions to reporter.
+func WithRedactionReporter(ctx context.Context, reporter RedactionReporter) context.Context {
+	return context.WithValue(ctx, redactionReporterKey{}, reporter)
+}
+
+// redactionReporterFromContext returns the reporter set by WithRedactionReporter, or nil.
+func redactionReporterFromContext(ctx context.Context) RedactionReporter {
+	reporter, _ := ctx.Value(redactionReporterKey{}).(RedactionReporter)
+	return reporter
+}
+
+// redactionConfig returns the redaction settings of the configuration; nil uses the built-in detectors
+func redactionConfig(cfg *config.Config) config.RedactionConfig {
+	if cfg == nil {
+		return config.RedactionConfig{}
+	}
+	return cfg.Redaction
+}
@@ -0,0 +1,138 @@
+package ai
+
+import (
+	"context"
+
+	"github.com/thomas-vilte/matecommit/internal/config"
+	domainErrors "github.com/thomas-vilte/matecommit/internal/errors"
+	"github.com/thomas-vilte/matecommit/internal/logger"
+	"github.com/thomas-vilte/matecommit/internal/models"
+)
+
+var _ ReleaseNotesGenerator = (*ReleaseNotesService)(nil)
+
+// ReleaseNotesService writes release notes with any LLM client.
+type ReleaseNotesService struct {
+	client     LLMClient
+	wrapper    *CostAwareWrapper
+	generateFn GenerateFunc
+	lang       string
+	owner      string
+	repo       string
+}
+
+type ReleaseNotesJSON struct {
+	Title      string   `json:"title"`
+	Summary    string   `json:"summary"`
+	Highlights []string `json:"highlights"`
+	Sections   []struct {
+		Title string   `json:"title"`
+		Items []string `json:"items"`
+	} `json:"sections"`
+	BreakingChanges []string `json:"breaking_changes"`
+	Contributors    string   `json:"contributors"`
+}
+
+// NewReleaseNotesService creates the release notes generator for owner/repo on top of client.
+func NewReleaseNotesService(client LLMClient, cfg *config.Config, onConfirmation ConfirmationCallback, owner, repo string) (*ReleaseNotesService, error) {
+	wrapper, err := newServiceWrapper(client, cfg, 700, onConfirmation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReleaseNotesService{
+		client:     client,
+		wrapper:    wrapper,
+		generateFn: commandGenerateFunc(client, cfg, "generate-release"),
+		lang:       cfg.Language,
+		owner:      owner,
+		repo:       repo,
+	}, nil
+}
+
+func (g *ReleaseNotesService) GenerateNotes(ctx context.Context, release *models.Release) (*models.ReleaseNotes, error) {
+	log := logger.FromContext(ctx)
+
+	log.Info("generating release notes",
+		"provider", g.client.GetProviderName(),
+		"version", release.Version,
+		"previous_version", release.PreviousVersion,
+		"features_count", len(release.Features),
+		"bugfixes_count", len(release.BugFixes),
+		"breaking_count", len(release.Breaking))
+
+	prompt := g.buildPrompt(release)
+
+	log.Debug("calling AI for release notes",
+		"prompt_length", len(prompt))
+
+	resp, usage, err := g.wrapper.WrapGenerate(withFileCount(ctx, release.FileStats.FilesChanged), "generate-release", prompt, g.generateFn)
+	if err != nil {
+		log.Error("failed to generate release notes",
+			"error", err,
+			"version", release.Version)
+		return nil, domainErrors.NewAppError(domainErrors.TypeAI, "error generating release notes", err)
+	}
+
+	responseText := extractResponseText(resp)
+	if responseText == "" {
+		log.Error("empty response from AI")
+		return nil, domainErrors.ErrInvalidAIOutput.
+			WithContext("reason", "empty response from AI").
+			WithContext("operation", "generate release notes")
+	}
+
+	log.Debug("AI response received",
+		"response_length", len(responseText))
+
+	notes, err := g.parseJSONResponse(responseText, release)
+	if err != nil {
+		log.Error("failed to parse release notes response",
+			"error", err)
+		return nil, domainErrors.NewAppError(domainErrors.TypeAI, "error parsing AI JSON response", err)
+	}
+
+	notes.Usage = usage
+
+	log.Info("release notes generated successfully",
+		"title", notes.Title,
+		"highlights_count", len(notes.Highlights))
+
+	return notes, nil
+}
+
+func (g *ReleaseNotesService) buildPrompt(release *models.Release) string {
+	return BuildReleasePrompt(g.lang, g.owner, g.repo, release)
+}
+
+func (g *ReleaseNotesService) parseJSONResponse(content string, release *models.Release) (*models.ReleaseNotes, error) {
+	jsonNotes, err := decodeJSON[ReleaseNotesJSON](content)
+	if err != nil {
+		return nil, domainErrors.NewAppError(domainErrors.TypeAI, "error parsing AI JSON response", err)
+	}
+
+	notes := &models.ReleaseNotes{
+		Title:           jsonNotes.Title,
+		Summary:         jsonNotes.Summary,
+		Highlights:      jsonNotes.Highlights,
+		BreakingChanges: jsonNotes.BreakingChanges,
+		Recommended:     release.VersionBump,
+		Links:           make(map[string]string),
+	}
+
+	if len(jsonNotes.Sections) > 0 {
+		notes.Sections = make([]models.ReleaseNotesSection, len(jsonNotes.Sections))
+		for i, s := range jsonNotes.Sections {
+			notes.Sections[i] = models.ReleaseNotesSection{
+				Title: s.Title,
+				Items: s.Items,
+			}
+		}
+	}
+
+	if jsonNotes.Contributors != "" && jsonNotes.Contributors != "N/A" {
+		notes.Links["Contributors"] = jsonNotes.Contributors
+	}
+
+	return notes, nil
+}
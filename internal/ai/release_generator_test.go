@@ -1,4 +1,4 @@
-package gemini
+package ai
 
 import (
 	"context"
@@ -9,52 +9,29 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/thomas-vilte/matecommit/internal/config"
 	"github.com/thomas-vilte/matecommit/internal/models"
-	"google.golang.org/genai"
 )
 
-func TestNewReleaseNotesGenerator(t *testing.T) {
+func TestNewReleaseNotesService(t *testing.T) {
 	// Arrange
-	ctx := context.Background()
 	cfg := &config.Config{
-		AIProviders: map[string]config.AIProviderConfig{"gemini": {APIKey: "test-api-key", Model: "gemini-2.5-flash", Temperature: 0.3, MaxTokens: 10000}},
-		Language:    "en",
-		AIConfig: config.AIConfig{
-			Models: map[config.AI]config.Model{
-				config.AIGemini: "gemini-pro",
-			},
-		},
+		Language: "en",
 	}
 
 	// Act
-	// Act
-	generator, err := NewReleaseNotesGenerator(ctx, cfg, nil, "test-owner", "test-repo")
+	generator, err := NewReleaseNotesService(newFakeClient(), cfg, nil, "test-owner", "test-repo")
 
 	// Assert
 	assert.NoError(t, err)
 	assert.NotNil(t, generator)
-	assert.Equal(t, "gemini-pro", generator.model)
 	assert.Equal(t, "en", generator.lang)
-}
-
-func TestNewReleaseNotesGenerator_MissingKey(t *testing.T) {
-	// Arrange
-	ctx := context.Background()
-	cfg := &config.Config{
-		AIProviders: map[string]config.AIProviderConfig{},
-	}
-	// Act
-	generator, err := NewReleaseNotesGenerator(ctx, cfg, nil, "test-owner", "test-repo")
-
-	// Assert
-	assert.Error(t, err)
-	assert.Nil(t, generator)
-	assert.Contains(t, err.Error(), "API key is missing")
+	assert.Equal(t, "test-owner", generator.owner)
+	assert.Equal(t, "test-repo", generator.repo)
 }
 
 func TestBuildPrompt(t *testing.T) {
 	t.Run("formats changes correctly in English", func(t *testing.T) {
 		// Arrange
-		generator := &ReleaseNotesGenerator{lang: "en"}
+		generator := &ReleaseNotesService{lang: "en"}
 		release := &models.Release{
 			Version:         "v1.0.0",
 			PreviousVersion: "v0.9.0",
@@ -68,7 +45,6 @@ func TestBuildPrompt(t *testing.T) {
 		// Act
 		prompt := generator.buildPrompt(release)
 
-		// Assert
 		// Assert
 		assert.Contains(t, prompt, "Versions: v0.9.0 -> v1.0.0 (major)")
 
@@ -84,7 +60,7 @@ func TestBuildPrompt(t *testing.T) {
 
 	t.Run("formats changes correctly in Spanish", func(t *testing.T) {
 		// Arrange
-		generator := &ReleaseNotesGenerator{lang: "es"}
+		generator := &ReleaseNotesService{lang: "es"}
 		release := &models.Release{
 			Version: "v1.0.0",
 		}
@@ -99,7 +75,7 @@ func TestBuildPrompt(t *testing.T) {
 
 	t.Run("handles empty changes", func(t *testing.T) {
 		// Arrange
-		generator := &ReleaseNotesGenerator{lang: "en"}
+		generator := &ReleaseNotesService{lang: "en"}
 		release := &models.Release{Version: "v1.0.0"}
 
 		// Act
@@ -114,7 +90,7 @@ func TestBuildPrompt(t *testing.T) {
 
 	t.Run("formats complex release with all sections", func(t *testing.T) {
 		// Arrange
-		generator := &ReleaseNotesGenerator{lang: "en", owner: "owner", repo: "repo"}
+		generator := &ReleaseNotesService{lang: "en", owner: "owner", repo: "repo"}
 		release := &models.Release{
 			Version:         "v2.0.0",
 			PreviousVersion: "v1.5.0",
@@ -164,7 +140,7 @@ func TestBuildPrompt(t *testing.T) {
 }
 
 func TestParseJSONResponse(t *testing.T) {
-	generator := &ReleaseNotesGenerator{}
+	generator := &ReleaseNotesService{}
 	release := &models.Release{Version: "v1.0.0", VersionBump: "minor"}
 
 	t.Run("parses JSON response correctly", func(t *testing.T) {
@@ -279,24 +255,16 @@ func TestGenerateNotes(t *testing.T) {
 	}()
 
 	ctx := context.Background()
-	cfg := &config.Config{
-		AIProviders: map[string]config.AIProviderConfig{"gemini": {APIKey: "test"}},
-		AIConfig:    config.AIConfig{Models: map[config.AI]config.Model{config.AIGemini: "gemini-pro"}},
-	}
+	cfg := &config.Config{}
 	// act
-	generator, _ := NewReleaseNotesGenerator(ctx, cfg, nil, "owner", "repo")
+	generator, _ := NewReleaseNotesService(newFakeClient(), cfg, nil, "owner", "repo")
 	generator.wrapper.SetSkipConfirmation(true)
 
 	t.Run("successful generation", func(t *testing.T) {
 		// Arrange
 		expectedJSON := `{"title": "Release v1.0.0", "summary": "Summary", "highlights": ["H1"], "breaking_changes": []}`
 		generator.generateFn = func(ctx context.Context, mName string, p string) (interface{}, *models.TokenUsage, error) {
-			return &genai.GenerateContentResponse{
-				Candidates: []*genai.Candidate{
-					{Content: &genai.Content{Parts: []*genai.Part{{Text: expectedJSON}}}},
-				},
-				UsageMetadata: &genai.GenerateContentResponseUsageMetadata{TotalTokenCount: 100},
-			}, &models.TokenUsage{TotalTokens: 100}, nil
+			return expectedJSON, &models.TokenUsage{TotalTokens: 100}, nil
 		}
 
 		// Act
@@ -323,12 +291,10 @@ func TestGenerateNotes(t *testing.T) {
 		assert.Contains(t, err.Error(), "AI error")
 	})
 
-	t.Run("no candidates from AI", func(t *testing.T) {
+	t.Run("empty response from AI", func(t *testing.T) {
 		// Arrange
 		generator.generateFn = func(ctx context.Context, mName string, p string) (interface{}, *models.TokenUsage, error) {
-			return &genai.GenerateContentResponse{
-				Candidates: []*genai.Candidate{},
-			}, &models.TokenUsage{}, nil
+			return "", &models.TokenUsage{}, nil
 		}
 
 		// Act
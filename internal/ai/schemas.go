@@ -0,0 +1,158 @@
+package ai
+
+// stringArraySchema is the schema of a plain list of strings.
+func stringArraySchema(description string) *Schema {
+	return &Schema{
+		Type:        SchemaArray,
+		Items:       &Schema{Type: SchemaString},
+		Description: description,
+	}
+}
+
+// commitSuggestionSchema returns the JSON schema for commit suggestions.
+// Some backends require an object at the root, so the suggestions are wrapped.
+func commitSuggestionSchema() *Schema {
+	return &Schema{
+		Type:     SchemaObject,
+		Required: []string{"suggestions"},
+		Properties: map[string]*Schema{
+			"suggestions": {
+				Type: SchemaArray,
+				Items: &Schema{
+					Type:     SchemaObject,
+					Required: []string{"title", "desc", "files"},
+					Properties: map[string]*Schema{
+						"title": {
+							Type:        SchemaString,
+							Description: "Commit title (type(scope): message)",
+						},
+						"desc": {
+							Type:        SchemaString,
+							Description: "Detailed explanation in first person",
+						},
+						"files": stringArraySchema("Array of file paths as strings"),
+						"analysis": {
+							Type:     SchemaObject,
+							Required: []string{"overview", "purpose", "impact"},
+							Properties: map[string]*Schema{
+								"overview": {Type: SchemaString},
+								"purpose":  {Type: SchemaString},
+								"impact":   {Type: SchemaString},
+							},
+						},
+						"requirements": {
+							Type:     SchemaObject,
+							Required: []string{"status", "missing", "suggestions"},
+							Properties: map[string]*Schema{
+								"status": {
+									Type: SchemaString,
+									Enum: []string{"full_met", "partially_met", "not_met"},
+								},
+								"missing": stringArraySchema(""),
+								"completed_indices": {
+									Type:  SchemaArray,
+									Items: &Schema{Type: SchemaInteger},
+								},
+								"suggestions": stringArraySchema(""),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// prSummarySchema returns the JSON schema for pull request summaries.
+func prSummarySchema() *Schema {
+	return &Schema{
+		Type:     SchemaObject,
+		Required: []string{"title", "body", "labels"},
+		Properties: map[string]*Schema{
+			"title": {
+				Type:        SchemaString,
+				Description: "PR title (max 80 chars)",
+			},
+			"body": {
+				Type:        SchemaString,
+				Description: "Detailed markdown body with overview, key changes, and technical impact",
+			},
+			"labels": stringArraySchema("Array of label strings (feature, fix, refactor, docs, infra, test, breaking-change)"),
+		},
+	}
+}
+
+// releaseNotesSchema returns the JSON schema for release notes.
+func releaseNotesSchema() *Schema {
+	return &Schema{
+		Type:     SchemaObject,
+		Required: []string{"title", "summary", "highlights", "breaking_changes"},
+		Properties: map[string]*Schema{
+			"title": {
+				Type:        SchemaString,
+				Description: "Concise and descriptive title",
+			},
+			"summary": {
+				Type:        SchemaString,
+				Description: "2-3 sentences explaining the release focus in first person plural",
+			},
+			"sections": {
+				Type: SchemaArray,
+				Items: &Schema{
+					Type:     SchemaObject,
+					Required: []string{"title", "items"},
+					Properties: map[string]*Schema{
+						"title": {
+							Type:        SchemaString,
+							Description: "Section title (e.g. '🎨 UI/UX Improvements')",
+						},
+						"items": stringArraySchema("List of items in this section"),
+					},
+				},
+				Description: "Categorized sections of the release notes",
+			},
+			"highlights":       stringArraySchema("Legacy flat list of highlights (keep empty if sections are used)"),
+			"breaking_changes": stringArraySchema("Array of breaking changes as strings (or [] if none)"),
+			"contributors": {
+				Type:        SchemaString,
+				Description: "Text with contributors (e.g., 'Thanks to @user1, @user2') or 'N/A'",
+			},
+		},
+	}
+}
+
+// issueSchema returns the JSON schema for generated issues.
+func issueSchema() *Schema {
+	return &Schema{
+		Type:     SchemaObject,
+		Required: []string{"title", "description", "labels"},
+		Properties: map[string]*Schema{
+			"title": {
+				Type:        SchemaString,
+				Description: "The title of the issue",
+			},
+			"description": {
+				Type:        SchemaString,
+				Description: "The body of the issue in markdown format",
+			},
+			"labels": stringArraySchema("List of labels (e.g. bug, feature, refactor, good first issue)"),
+		},
+	}
+}
+
+// schemaForCommand returns the structured output of a wrapped command.
+// Unknown commands get no schema and are answered with plain text.
+func schemaForCommand(command string) (string, *Schema) {
+	switch command {
+	case "suggest-commits":
+		return "commit_suggestions", commitSuggestionSchema()
+	case "summarize-pr":
+		return "pr_summary", prSummarySchema()
+	case "generate-release":
+		return "release_notes", releaseNotesSchema()
+	case "generate-issue":
+		return "issue_content", issueSchema()
+	default:
+		return "", nil
+	}
+}
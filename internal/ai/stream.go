@@ -0,0 +1,107 @@
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	domainErrors "github.com/thomas-vilte/matecommit/internal/errors"
+)
+
+// maxStreamLineSize bounds a single line of a streamed response; usage and tool events can be large.
+const maxStreamLineSize = 1024 * 1024
+
+// StreamSink shows the partial output of a response while the provider generates it.
+type StreamSink interface {
+	// Chunk receives the next piece of text as it arrives.
+	Chunk(text string)
+	// Done is called once the response is complete or has failed.
+	Done()
+}
+
+// StreamingClient is implemented by clients that can emit partial output while generating.
+// The returned response holds the full text, so it is validated and cached like any other.
+type StreamingClient interface {
+	GenerateStream(ctx context.Context, req LLMRequest, onChunk func(text string)) (*LLMResponse, error)
+}
+
+type streamSinkKey struct{}
+
+// WithStreamSink makes every wrapped call made with ctx stream its output to sink,
+// when the provider supports it.
+func WithStreamSink(ctx context.Context, sink StreamSink) context.Context {
+	return context.WithValue(ctx, streamSinkKey{}, sink)
+}
+
+// streamSinkFromContext returns the sink set by WithStreamSink, or nil.
+func streamSinkFromContext(ctx context.Context) StreamSink {
+	sink, _ := ctx.Value(streamSinkKey{}).(StreamSink)
+	return sink
+}
+
+// generate sends req with client, streaming the output to the sink of ctx when both support it.
+func generate(ctx context.Context, client LLMClient, req LLMRequest) (*LLMResponse, error) {
+	sink := streamSinkFromContext(ctx)
+	streamer, ok := client.(StreamingClient)
+	if sink == nil || !ok {
+		return client.Generate(ctx, req)
+	}
+
+	defer sink.Done()
+	return streamer.GenerateStream(ctx, req, sink.Chunk)
+}
+
+// ReadEventStream reads a server-sent events body and calls onData with the payload of every data line.
+// It stops at the end of the body or at the "[DONE]" marker used by OpenAI-compatible APIs.
+// Errors returned by onData are passed through; read failures become generation errors.
+func ReadEventStream(r io.Reader, onData func(data []byte) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStreamLineSize)
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		data, ok := bytes.CutPrefix(line, []byte("data:"))
+		if !ok {
+			continue
+		}
+		data = bytes.TrimSpace(data)
+		if bytes.Equal(data, []byte("[DONE]")) {
+			return nil
+		}
+		if len(data) == 0 {
+			continue
+		}
+		if err := onData(data); err != nil {
+			return err
+		}
+	}
+	return streamReadError(scanner.Err())
+}
+
+// ReadJSONLines reads a newline-delimited JSON body and calls onLine with every non-empty line.
+// Errors are reported like in ReadEventStream.
+func ReadJSONLines(r io.Reader, onLine func(line []byte) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStreamLineSize)
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := onLine(line); err != nil {
+			return err
+		}
+	}
+	return streamReadError(scanner.Err())
+}
+
+// streamReadError maps a failure while reading a streamed body to a generation error.
+func streamReadError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return domainErrors.ErrAIGeneration.WithError(fmt.Errorf("error reading stream: %w", err))
+}
@@ -0,0 +1,156 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	domainErrors "github.com/thomas-vilte/matecommit/internal/errors"
+)
+
+// fakeStreamingClient streams its text in the given chunks.
+type fakeStreamingClient struct {
+	fakeLLMClient
+	chunks []string
+}
+
+func (f *fakeStreamingClient) GenerateStream(_ context.Context, req LLMRequest, onChunk func(text string)) (*LLMResponse, error) {
+	f.requests = append(f.requests, req)
+	if f.err != nil {
+		return nil, f.err
+	}
+	for _, chunk := range f.chunks {
+		onChunk(chunk)
+	}
+	return &LLMResponse{Text: strings.Join(f.chunks, "")}, nil
+}
+
+// recordingSink keeps what a stream sink receives.
+type recordingSink struct {
+	chunks []string
+	done   int
+}
+
+func (s *recordingSink) Chunk(text string) { s.chunks = append(s.chunks, text) }
+
+func (s *recordingSink) Done() { s.done++ }
+
+func TestGenerateFuncStreaming(t *testing.T) {
+	t.Run("streams to the sink of the context", func(t *testing.T) {
+		// Arrange
+		client := &fakeStreamingClient{chunks: []string{`{"title":`, `"ok"}`}}
+		sink := &recordingSink{}
+		ctx := WithStreamSink(context.Background(), sink)
+		generate := newGenerateFunc(client, LLMRequest{})
+
+		// Act
+		resp, _, err := generate(ctx, "model-x", "prompt")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, `{"title":"ok"}`, resp)
+		assert.Equal(t, client.chunks, sink.chunks)
+		assert.Equal(t, 1, sink.done)
+	})
+
+	t.Run("ends the stream when the call fails", func(t *testing.T) {
+		// Arrange
+		client := &fakeStreamingClient{fakeLLMClient: fakeLLMClient{err: errors.New("boom")}}
+		sink := &recordingSink{}
+		generate := newGenerateFunc(client, LLMRequest{})
+
+		// Act
+		_, _, err := generate(WithStreamSink(context.Background(), sink), "model-x", "prompt")
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, 1, sink.done)
+	})
+
+	t.Run("does not stream without a sink", func(t *testing.T) {
+		// Arrange
+		client := &fakeStreamingClient{fakeLLMClient: fakeLLMClient{text: "plain"}, chunks: []string{"streamed"}}
+		generate := newGenerateFunc(client, LLMRequest{})
+
+		// Act
+		resp, _, err := generate(context.Background(), "model-x", "prompt")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "plain", resp)
+	})
+
+	t.Run("falls back to Generate for clients that cannot stream", func(t *testing.T) {
+		// Arrange
+		client := &fakeLLMClient{text: "plain"}
+		sink := &recordingSink{}
+		generate := newGenerateFunc(client, LLMRequest{})
+
+		// Act
+		resp, _, err := generate(WithStreamSink(context.Background(), sink), "model-x", "prompt")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "plain", resp)
+		assert.Empty(t, sink.chunks)
+		assert.Zero(t, sink.done)
+	})
+}
+
+func TestReadEventStream(t *testing.T) {
+	t.Run("passes data lines until the done marker", func(t *testing.T) {
+		// Arrange
+		body := "event: delta\ndata: {\"a\":1}\n\n: keep-alive\n\ndata:{\"a\":2}\n\ndata: [DONE]\n\ndata: {\"a\":3}\n"
+		var payloads []string
+
+		// Act
+		err := ReadEventStream(strings.NewReader(body), func(data []byte) error {
+			payloads = append(payloads, string(data))
+			return nil
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{`{"a":1}`, `{"a":2}`}, payloads)
+	})
+
+	t.Run("returns the callback error", func(t *testing.T) {
+		// Arrange
+		stop := errors.New("stop")
+
+		// Act
+		err := ReadEventStream(strings.NewReader("data: x\ndata: y\n"), func([]byte) error { return stop })
+
+		// Assert
+		assert.ErrorIs(t, err, stop)
+	})
+
+	t.Run("maps oversized lines to a generation error", func(t *testing.T) {
+		// Arrange
+		body := "data: " + strings.Repeat("x", maxStreamLineSize+1) + "\n"
+
+		// Act
+		err := ReadEventStream(strings.NewReader(body), func([]byte) error { return nil })
+
+		// Assert
+		assert.ErrorIs(t, err, domainErrors.ErrAIGeneration)
+	})
+}
+
+func TestReadJSONLines(t *testing.T) {
+	// Arrange
+	var lines []string
+
+	// Act
+	err := ReadJSONLines(strings.NewReader("{\"a\":1}\n\n{\"a\":2}\n"), func(line []byte) error {
+		lines = append(lines, string(line))
+		return nil
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{`{"a":1}`, `{"a":2}`}, lines)
+}
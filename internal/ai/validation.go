@@ -0,0 +1,193 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// maxRepairAttempts bounds how many times a response that does not match its schema is sent back to the model
+const maxRepairAttempts = 2
+
+// ValidationError is one mismatch between a response and the schema it should follow.
+type ValidationError struct {
+	// Path locates the value, e.g. suggestions[0].requirements.status; empty is the root
+	Path    string
+	Message string
+}
+
+func (e ValidationError) String() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Validate checks a decoded JSON value against the schema: types, required properties and enums.
+// Required strings must not be blank, so a response cannot pass with empty fields.
+// Properties missing from the schema are ignored.
+func (s *Schema) Validate(value interface{}) []ValidationError {
+	var errs []ValidationError
+	s.validate("", value, &errs)
+	return errs
+}
+
+func (s *Schema) validate(path string, value interface{}, errs *[]ValidationError) {
+	if s == nil {
+		return
+	}
+	if value == nil {
+		*errs = append(*errs, ValidationError{Path: path, Message: fmt.Sprintf("must be %s, got null", withArticle(s.Type))})
+		return
+	}
+
+	switch s.Type {
+	case SchemaObject:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			*errs = append(*errs, typeError(path, s.Type, value))
+			return
+		}
+		for _, name := range s.Required {
+			property, present := object[name]
+			if !present {
+				*errs = append(*errs, ValidationError{Path: joinPath(path, name), Message: "required property is missing"})
+				continue
+			}
+			if text, isString := property.(string); isString && strings.TrimSpace(text) == "" {
+				*errs = append(*errs, ValidationError{Path: joinPath(path, name), Message: "required property is empty"})
+			}
+		}
+		for _, name := range s.PropertyNames() {
+			if property, present := object[name]; present && (property != nil || slices.Contains(s.Required, name)) {
+				s.Properties[name].validate(joinPath(path, name), property, errs)
+			}
+		}
+
+	case SchemaArray:
+		items, ok := value.([]interface{})
+		if !ok {
+			*errs = append(*errs, typeError(path, s.Type, value))
+			return
+		}
+		for i, item := range items {
+			s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, errs)
+		}
+
+	case SchemaString:
+		text, ok := value.(string)
+		if !ok {
+			*errs = append(*errs, typeError(path, s.Type, value))
+			return
+		}
+		if len(s.Enum) > 0 && !slices.Contains(s.Enum, text) {
+			*errs = append(*errs, ValidationError{Path: path, Message: fmt.Sprintf("%q is not one of %s", text, strings.Join(s.Enum, ", "))})
+		}
+
+	case SchemaInteger:
+		number, ok := value.(json.Number)
+		if _, err := number.Int64(); !ok || err != nil {
+			*errs = append(*errs, typeError(path, s.Type, value))
+		}
+
+	case SchemaNumber:
+		if _, ok := value.(json.Number); !ok {
+			*errs = append(*errs, typeError(path, s.Type, value))
+		}
+
+	case SchemaBoolean:
+		if _, ok := value.(bool); !ok {
+			*errs = append(*errs, typeError(path, s.Type, value))
+		}
+	}
+}
+
+// validateResponse checks the text of a structured response against its schema.
+// It returns the text to hand to the services, trimmed and without a wrapping markdown fence,
+// and what is wrong with it. A bare array is accepted for an object whose only property is an array,
+// because some models drop the envelope the schema asks for.
+func validateResponse(schema *Schema, text string) (string, []ValidationError) {
+	text = stripCodeFence(strings.TrimSpace(text))
+
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return text, []ValidationError{{Message: fmt.Sprintf("response is not valid JSON: %v", err)}}
+	}
+	if decoder.More() {
+		return text, []ValidationError{{Message: "response has text after the JSON value"}}
+	}
+
+	if items, isArray := value.([]interface{}); isArray && schema.Type == SchemaObject && len(schema.Properties) == 1 {
+		name := schema.PropertyNames()[0]
+		if schema.Properties[name].Type == SchemaArray {
+			value = map[string]interface{}{name: items}
+		}
+	}
+
+	return text, schema.Validate(value)
+}
+
+// stripCodeFence removes a ```json fence wrapping the whole text; text around a fence is left for the validator to reject.
+func stripCodeFence(text string) string {
+	if !strings.HasPrefix(text, "```") || !strings.HasSuffix(text, "```") || len(text) < 6 {
+		return text
+	}
+	body := strings.TrimSuffix(text[3:], "```")
+	if newline := strings.IndexByte(body, '\n'); newline >= 0 && !strings.ContainsAny(body[:newline], "{[") {
+		body = body[newline+1:]
+	}
+	return strings.TrimSpace(body)
+}
+
+// formatValidationErrors lists the errors one per line for the repair prompt.
+func formatValidationErrors(errs []ValidationError) string {
+	lines := make([]string, 0, len(errs))
+	for _, err := range errs {
+		lines = append(lines, "- "+err.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func typeError(path string, want SchemaType, value interface{}) ValidationError {
+	return ValidationError{Path: path, Message: fmt.Sprintf("must be %s, got %s", withArticle(want), jsonTypeName(value))}
+}
+
+// jsonTypeName returns the JSON type of a decoded value.
+func jsonTypeName(value interface{}) string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "an array"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "an integer"
+		}
+		return "a number"
+	default:
+		return "null"
+	}
+}
+
+func withArticle(t SchemaType) string {
+	switch t {
+	case SchemaObject, SchemaArray, SchemaInteger:
+		return "an " + string(t)
+	default:
+		return "a " + string(t)
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,103 @@
+package ai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		schema     *Schema
+		text       string
+		wantText   string
+		wantErrors []string
+	}{
+		{
+			name:     "valid commit suggestions",
+			schema:   commitSuggestionSchema(),
+			text:     `{"suggestions": [{"title": "feat: add x", "desc": "Adds x", "files": ["x.go"], "requirements": {"status": "full_met", "missing": [], "suggestions": [], "completed_indices": [0, 1]}}]}`,
+			wantText: `{"suggestions": [{"title": "feat: add x", "desc": "Adds x", "files": ["x.go"], "requirements": {"status": "full_met", "missing": [], "suggestions": [], "completed_indices": [0, 1]}}]}`,
+		},
+		{
+			name:     "bare array for an envelope",
+			schema:   commitSuggestionSchema(),
+			text:     `[{"title": "feat: add x", "desc": "Adds x", "files": []}]`,
+			wantText: `[{"title": "feat: add x", "desc": "Adds x", "files": []}]`,
+		},
+		{
+			name:     "markdown fence is stripped",
+			schema:   prSummarySchema(),
+			text:     "```json\n{\"title\": \"T\", \"body\": \"B\", \"labels\": []}\n```",
+			wantText: `{"title": "T", "body": "B", "labels": []}`,
+		},
+		{
+			name:   "enum value outside the allowed set",
+			schema: commitSuggestionSchema(),
+			text:   `{"suggestions": [{"title": "t", "desc": "d", "files": [], "requirements": {"status": "done", "missing": [], "suggestions": []}}]}`,
+			wantErrors: []string{
+				`suggestions[0].requirements.status: "done" is not one of full_met, partially_met, not_met`,
+			},
+		},
+		{
+			name:   "missing, empty and mistyped fields",
+			schema: issueSchema(),
+			text:   `{"title": " ", "labels": "bug"}`,
+			wantErrors: []string{
+				"description: required property is missing",
+				"title: required property is empty",
+				"labels: must be an array, got a string",
+			},
+		},
+		{
+			name:   "wrong item type",
+			schema: commitSuggestionSchema(),
+			text:   `{"suggestions": [{"title": "t", "desc": "d", "files": [], "requirements": {"status": "not_met", "missing": [], "suggestions": [], "completed_indices": [1.5]}}]}`,
+			wantErrors: []string{
+				"suggestions[0].requirements.completed_indices[0]: must be an integer, got a number",
+			},
+		},
+		{
+			name:       "not JSON",
+			schema:     issueSchema(),
+			text:       "Here is your issue: Add login",
+			wantErrors: []string{"response is not valid JSON: invalid character 'H' looking for beginning of value"},
+		},
+		{
+			name:       "text after the JSON",
+			schema:     prSummarySchema(),
+			text:       `{"title": "T", "body": "B", "labels": []} Hope this helps!`,
+			wantErrors: []string{"response has text after the JSON value"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			text, errs := validateResponse(tt.schema, tt.text)
+
+			// Assert
+			got := make([]string, 0, len(errs))
+			for _, err := range errs {
+				got = append(got, err.String())
+			}
+			if len(tt.wantErrors) == 0 {
+				require.Empty(t, got)
+				assert.Equal(t, tt.wantText, text)
+				return
+			}
+			assert.ElementsMatch(t, tt.wantErrors, got)
+		})
+	}
+}
+
+func TestFormatValidationErrors(t *testing.T) {
+	errs := []ValidationError{
+		{Message: "response is not valid JSON"},
+		{Path: "labels", Message: "must be an array, got a string"},
+	}
+
+	assert.Equal(t, "- response is not valid JSON\n- labels: must be an array, got a string", formatValidationErrors(errs))
+}
@@ -4,16 +4,49 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 )
 
+var (
+	ErrEntryNotFound = errors.New("cache entry not found")
+	ErrAmbiguousHash = errors.New("hash prefix matches several cache entries")
+	ErrInvalidHash   = errors.New("hash prefix is not hexadecimal")
+)
+
+// Metadata describes the call that produced a cached response
+type Metadata struct {
+	Command  string `json:"command,omitempty"`
+	Provider string `json:"provider,omitempty"`
+	Model    string `json:"model,omitempty"`
+}
+
 type CachedResponse struct {
 	Hash      string          `json:"hash"`
 	Response  json.RawMessage `json:"response"`
 	CreatedAt time.Time       `json:"created_at"`
+	// Metadata is empty in the entries written by older versions
+	Metadata
+}
+
+// Entry is a cached response with the size of its file
+type Entry struct {
+	CachedResponse
+	Size int64
+}
+
+// Stats summarizes the entries of the cache
+type Stats struct {
+	Entries int
+	Size    int64
+	Oldest  time.Time
+	Newest  time.Time
 }
 
 type Cache struct {
@@ -73,8 +106,8 @@ func (c *Cache) Get(hash string) (json.RawMessage, bool, error) {
 	return cached.Response, true, nil
 }
 
-// Set saves a response to the cache
-func (c *Cache) Set(hash string, response interface{}) error {
+// Set saves a response to the cache, with the call that produced it
+func (c *Cache) Set(hash string, response interface{}, metadata Metadata) error {
 	responseData, err := json.Marshal(response)
 	if err != nil {
 		return fmt.Errorf("error marshaling response: %w", err)
@@ -84,6 +117,7 @@ func (c *Cache) Set(hash string, response interface{}) error {
 		Hash:      hash,
 		Response:  responseData,
 		CreatedAt: time.Now(),
+		Metadata:  metadata,
 	}
 
 	data, err := json.MarshalIndent(cached, "", "  ")
@@ -129,3 +163,135 @@ func (c *Cache) CleanExpired() error {
 func (c *Cache) Clean() error {
 	return os.RemoveAll(c.cacheDir)
 }
+
+// Entries lists the cached responses from the newest to the oldest. Files that cannot be read are skipped.
+func (c *Cache) Entries() ([]Entry, error) {
+	files, err := os.ReadDir(c.cacheDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading cache directory: %w", err)
+	}
+
+	var entries []Entry
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		entry, err := c.readEntry(filepath.Join(c.cacheDir, file.Name()))
+		if err != nil {
+			slog.Warn("skipping unreadable cache entry",
+				"file", file.Name(),
+				"error", err)
+			continue
+		}
+		entries = append(entries, *entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.After(entries[j].CreatedAt)
+	})
+	return entries, nil
+}
+
+// Stats counts the entries of the cache and their size
+func (c *Cache) Stats() (Stats, error) {
+	entries, err := c.Entries()
+	if err != nil {
+		return Stats{}, err
+	}
+
+	stats := Stats{Entries: len(entries)}
+	for i, entry := range entries {
+		stats.Size += entry.Size
+		if i == 0 {
+			stats.Newest = entry.CreatedAt
+		}
+		stats.Oldest = entry.CreatedAt
+	}
+	return stats, nil
+}
+
+// Find returns the entry whose hash starts with prefix. It returns ErrEntryNotFound when there is
+// none, ErrAmbiguousHash when there are several and ErrInvalidHash when prefix is not hexadecimal,
+// which keeps it from escaping the cache directory or acting as a glob pattern.
+func (c *Cache) Find(prefix string) (*Entry, error) {
+	if prefix == "" {
+		return nil, ErrEntryNotFound
+	}
+	prefix = strings.ToLower(prefix)
+	if strings.Trim(prefix, "0123456789abcdef") != "" {
+		return nil, ErrInvalidHash
+	}
+	matches, err := filepath.Glob(filepath.Join(c.cacheDir, prefix+"*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("error reading cache directory: %w", err)
+	}
+	switch len(matches) {
+	case 0:
+		return nil, ErrEntryNotFound
+	case 1:
+		return c.readEntry(matches[0])
+	default:
+		return nil, ErrAmbiguousHash
+	}
+}
+
+// Prune removes the entries created more than olderThan ago and returns how many it removed
+func (c *Cache) Prune(olderThan time.Duration) (int, error) {
+	return c.remove(func(entry Entry) bool {
+		return time.Since(entry.CreatedAt) > olderThan
+	})
+}
+
+// Invalidate removes the entries of the given commands and returns how many it removed.
+// Entries written by older versions have no command and are kept.
+func (c *Cache) Invalidate(commands ...string) (int, error) {
+	return c.remove(func(entry Entry) bool {
+		for _, command := range commands {
+			if entry.Command != "" && entry.Command == command {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (c *Cache) remove(match func(Entry) bool) (int, error) {
+	entries, err := c.Entries()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		if !match(entry) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(c.cacheDir, entry.Hash+".json")); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("error removing cache entry: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+func (c *Cache) readEntry(path string) (*Entry, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading cache: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading cache: %w", err)
+	}
+
+	entry := &Entry{Size: info.Size()}
+	if err := json.Unmarshal(data, &entry.CachedResponse); err != nil {
+		return nil, fmt.Errorf("error unmarshaling cache: %w", err)
+	}
+	// The file name is the key Get looks up, so it wins over the stored hash
+	entry.Hash = strings.TrimSuffix(filepath.Base(path), ".json")
+	return entry, nil
+}
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -75,7 +76,7 @@ func TestCache_SetAndGet(t *testing.T) {
 	hash := c.GenerateHash("matecommit-key")
 
 	// Act - Set
-	err := c.Set(hash, data)
+	err := c.Set(hash, data, Metadata{})
 	if err != nil {
 		t.Fatalf("Set() error = %v", err)
 	}
@@ -127,7 +128,7 @@ func TestCache_Get_Expired(t *testing.T) {
 		}
 	}()
 	hash := "expired-hash"
-	_ = c.Set(hash, "some data")
+	_ = c.Set(hash, "some data", Metadata{})
 
 	time.Sleep(20 * time.Millisecond)
 
@@ -156,10 +157,10 @@ func TestCache_CleanExpired(t *testing.T) {
 			t.Errorf("RemoveAll() error = %v", err)
 		}
 	}()
-	_ = c.Set("fresh", "data")
+	_ = c.Set("fresh", "data", Metadata{})
 
 	oldHash := "old"
-	_ = c.Set(oldHash, "data")
+	_ = c.Set(oldHash, "data", Metadata{})
 	oldFilePath := filepath.Join(tempDir, oldHash+".json")
 	oldTime := time.Now().Add(-2 * time.Hour)
 	_ = os.Chtimes(oldFilePath, oldTime, oldTime)
@@ -186,8 +187,8 @@ func TestCache_Clean(t *testing.T) {
 	// Arrange
 	c, tempDir := setupTestCache(t, 1*time.Hour)
 
-	_ = c.Set("hash1", "data")
-	_ = c.Set("hash2", "data")
+	_ = c.Set("hash1", "data", Metadata{})
+	_ = c.Set("hash2", "data", Metadata{})
 
 	// Act
 	err := c.Clean()
@@ -225,3 +226,125 @@ func TestCache_Get_UnmarshalError(t *testing.T) {
 		t.Error("Get() found = true, want false for invalid JSON")
 	}
 }
+
+func writeTestEntry(t *testing.T, dir, hash string, metadata Metadata, createdAt time.Time) {
+	t.Helper()
+	data, err := json.Marshal(CachedResponse{
+		Hash:      hash,
+		Response:  json.RawMessage(`"data"`),
+		CreatedAt: createdAt,
+		Metadata:  metadata,
+	})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, hash+".json"), data, 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+func TestCache_Entries(t *testing.T) {
+	// Arrange
+	c, tempDir := setupTestCache(t, 1*time.Hour)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	now := time.Now()
+	writeTestEntry(t, tempDir, "aaa111", Metadata{Command: "suggest-commits", Provider: "gemini", Model: "gemini-2.5-flash"}, now.Add(-2*time.Hour))
+	writeTestEntry(t, tempDir, "bbb222", Metadata{Command: "summarize-pr"}, now)
+	_ = os.WriteFile(filepath.Join(tempDir, "broken.json"), []byte("{"), 0644)
+
+	// Act
+	entries, err := c.Entries()
+	stats, statsErr := c.Stats()
+
+	// Assert
+	if err != nil || statsErr != nil {
+		t.Fatalf("Entries() error = %v, Stats() error = %v", err, statsErr)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 readable entries, got %d", len(entries))
+	}
+	if entries[0].Hash != "bbb222" || entries[1].Model != "gemini-2.5-flash" {
+		t.Errorf("expected the newest entry first with its metadata, got %+v", entries)
+	}
+	if entries[1].Size == 0 {
+		t.Error("expected the size of the entry file")
+	}
+	if stats.Entries != 2 || stats.Size != entries[0].Size+entries[1].Size || !stats.Oldest.Equal(entries[1].CreatedAt) {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestCache_Find(t *testing.T) {
+	// Arrange
+	c, tempDir := setupTestCache(t, 1*time.Hour)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	writeTestEntry(t, tempDir, "abc123", Metadata{Command: "suggest-commits"}, time.Now())
+	writeTestEntry(t, tempDir, "abd456", Metadata{Command: "summarize-pr"}, time.Now())
+
+	tests := []struct {
+		prefix   string
+		wantHash string
+		wantErr  error
+	}{
+		{prefix: "abc", wantHash: "abc123"},
+		{prefix: "abd456", wantHash: "abd456"},
+		{prefix: "ab", wantErr: ErrAmbiguousHash},
+		{prefix: "fff", wantErr: ErrEntryNotFound},
+		{prefix: "", wantErr: ErrEntryNotFound},
+		{prefix: "ABC1", wantHash: "abc123"},
+		{prefix: "*", wantErr: ErrInvalidHash},
+		{prefix: "[a", wantErr: ErrInvalidHash},
+		{prefix: "../abc", wantErr: ErrInvalidHash},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			// Act
+			entry, err := c.Find(tt.prefix)
+
+			// Assert
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Find(%q) error = %v, want %v", tt.prefix, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || entry.Hash != tt.wantHash {
+				t.Errorf("Find(%q) = %+v, %v, want %s", tt.prefix, entry, err, tt.wantHash)
+			}
+		})
+	}
+}
+
+func TestCache_PruneAndInvalidate(t *testing.T) {
+	// Arrange
+	c, tempDir := setupTestCache(t, 30*24*time.Hour)
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	now := time.Now()
+	writeTestEntry(t, tempDir, "old", Metadata{Command: "summarize-pr"}, now.Add(-96*time.Hour))
+	writeTestEntry(t, tempDir, "suggest", Metadata{Command: "suggest-commits"}, now)
+	writeTestEntry(t, tempDir, "legacy", Metadata{}, now)
+	writeTestEntry(t, tempDir, "keep", Metadata{Command: "summarize-pr"}, now)
+
+	// Act
+	pruned, pruneErr := c.Prune(72 * time.Hour)
+	invalidated, invalidateErr := c.Invalidate("suggest-commits")
+
+	// Assert
+	if pruneErr != nil || invalidateErr != nil {
+		t.Fatalf("Prune() error = %v, Invalidate() error = %v", pruneErr, invalidateErr)
+	}
+	if pruned != 1 || invalidated != 1 {
+		t.Errorf("expected 1 pruned and 1 invalidated entry, got %d and %d", pruned, invalidated)
+	}
+	entries, _ := c.Entries()
+	if len(entries) != 2 {
+		t.Errorf("expected the legacy and the recent entry to be kept, got %+v", entries)
+	}
+}
@@ -0,0 +1,209 @@
+// Package cassette records the HTTP exchanges of a command to a directory and replays them later.
+//
+// A cassette is a directory with one JSON file per exchange, numbered in the order they happened.
+// Credentials in headers and query strings are replaced before anything is written, and bodies go
+// through the same detectors as the AI prompts, so a cassette can be shared to reproduce a problem.
+package cassette
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/thomas-vilte/matecommit/internal/config"
+	"github.com/thomas-vilte/matecommit/internal/redact"
+)
+
+// scrubbedValue replaces the credentials found in headers and query strings.
+const scrubbedValue = "[REDACTED]"
+
+// sensitiveNameParts mark the headers and query parameters that carry credentials.
+var sensitiveNameParts = []string{"auth", "token", "key", "secret", "password", "cookie", "signature", "session"}
+
+// Interaction is one recorded HTTP exchange.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is the scrubbed request of an interaction; replay matches on its method, URL and body.
+type Request struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// Response is the scrubbed response of an interaction.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	// BodyBase64 is set when the body is not text and was stored base64-encoded
+	BodyBase64 bool `json:"body_base64,omitempty"`
+}
+
+// Install makes every HTTP client that relies on the default transport go through rt.
+// The AI providers, the VCS clients and the ticket services all do.
+func Install(rt http.RoundTripper) {
+	http.DefaultTransport = rt
+}
+
+// scrubber removes credentials and secrets from what is written to a cassette.
+type scrubber struct {
+	redactor *redact.Redactor
+}
+
+// newScrubber uses the redaction patterns of the configuration. The entropy detector stays off,
+// since it would also mask the IDs and hashes a replay depends on.
+func newScrubber(cfg config.RedactionConfig) (*scrubber, error) {
+	redactor, err := redact.New(config.RedactionConfig{Patterns: cfg.Patterns, DisableEntropy: true})
+	if err != nil {
+		return nil, err
+	}
+	return &scrubber{redactor: redactor}, nil
+}
+
+// headers returns a copy of h with the credential headers replaced.
+func (s *scrubber) headers(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	out := h.Clone()
+	for name := range out {
+		if isSensitive(name) {
+			out[name] = []string{scrubbedValue}
+		}
+	}
+	return out
+}
+
+// url returns u with the credential query parameters replaced.
+func (s *scrubber) url(u *url.URL) string {
+	scrubbed := *u
+	scrubbed.User = nil
+	query := scrubbed.Query()
+	changed := false
+	for name := range query {
+		if isSensitive(name) {
+			query[name] = []string{scrubbedValue}
+			changed = true
+		}
+	}
+	if changed {
+		scrubbed.RawQuery = query.Encode()
+	}
+	return scrubbed.String()
+}
+
+// body returns the text of a body with its secrets masked, or its base64 encoding when it is not text.
+func (s *scrubber) body(data []byte) (string, bool) {
+	if !utf8.Valid(data) {
+		return base64.StdEncoding.EncodeToString(data), true
+	}
+	text, _ := s.redactor.Redact(string(data))
+	return text, false
+}
+
+// request scrubs a request whose body was already read.
+func (s *scrubber) request(req *http.Request, body []byte) Request {
+	text, _ := s.body(body)
+	return Request{
+		Method:  req.Method,
+		URL:     s.url(req.URL),
+		Headers: s.headers(req.Header),
+		Body:    text,
+	}
+}
+
+func isSensitive(name string) bool {
+	lower := strings.ToLower(name)
+	for _, part := range sensitiveNameParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// readBody reads and closes a request or response body; a nil body is empty.
+func readBody(body io.ReadCloser) ([]byte, error) {
+	if body == nil || body == http.NoBody {
+		return nil, nil
+	}
+	defer func() {
+		_ = body.Close()
+	}()
+	return io.ReadAll(body)
+}
+
+// load reads the interactions of a cassette in the order they were recorded.
+func load(dir string) ([]Interaction, error) {
+	files, err := interactionFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	interactions := make([]Interaction, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading cassette: %w", err)
+		}
+		var interaction Interaction
+		if err := json.Unmarshal(data, &interaction); err != nil {
+			return nil, fmt.Errorf("error decoding cassette file %s: %w", filepath.Base(file), err)
+		}
+		interactions = append(interactions, interaction)
+	}
+	return interactions, nil
+}
+
+// interactionFiles lists the interaction files of a cassette sorted by their number.
+func interactionFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("error listing cassette: %w", err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// responseFrom builds the HTTP response of a recorded interaction.
+func responseFrom(req *http.Request, recorded Response) (*http.Response, error) {
+	body := []byte(recorded.Body)
+	if recorded.BodyBase64 {
+		decoded, err := base64.StdEncoding.DecodeString(recorded.Body)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding recorded body: %w", err)
+		}
+		body = decoded
+	}
+
+	header := recorded.Headers.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	// Scrubbing can change the length of the body
+	header.Del("Content-Length")
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
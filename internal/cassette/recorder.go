@@ -0,0 +1,106 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/thomas-vilte/matecommit/internal/config"
+)
+
+// Recorder is a transport that sends every request through base and writes the exchange to a cassette.
+type Recorder struct {
+	dir      string
+	base     http.RoundTripper
+	scrubber *scrubber
+
+	mu   sync.Mutex
+	next int
+}
+
+// NewRecorder creates the cassette directory if needed; new interactions are numbered after the existing ones.
+func NewRecorder(dir string, base http.RoundTripper, redaction config.RedactionConfig) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating cassette directory: %w", err)
+	}
+	files, err := interactionFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	scrubber, err := newScrubber(redaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Recorder{
+		dir:      dir,
+		base:     base,
+		scrubber: scrubber,
+		next:     len(files) + 1,
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+// Failed exchanges are not recorded; streamed responses are stored whole.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading request body: %w", err)
+	}
+	outReq := req.Clone(req.Context())
+	outReq.Body = io.NopCloser(bytes.NewReader(reqBody))
+
+	resp, err := r.base.RoundTrip(outReq)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := readBody(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	text, isBase64 := r.scrubber.body(respBody)
+	interaction := Interaction{
+		Request: r.scrubber.request(req, reqBody),
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Headers:    r.scrubber.headers(resp.Header),
+			Body:       text,
+			BodyBase64: isBase64,
+		},
+	}
+	if err := r.save(req, interaction); err != nil {
+		slog.Warn("could not record HTTP exchange",
+			"url", interaction.Request.URL,
+			"error", err)
+	}
+
+	return resp, nil
+}
+
+// save writes an interaction as the next file of the cassette, e.g. 0003-post-api.github.com.json
+func (r *Recorder) save(req *http.Request, interaction Interaction) error {
+	data, err := json.MarshalIndent(interaction, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding interaction: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name := fmt.Sprintf("%04d-%s-%s.json", r.next, strings.ToLower(req.Method), req.URL.Hostname())
+	if err := os.WriteFile(filepath.Join(r.dir, name), data, 0600); err != nil {
+		return fmt.Errorf("error writing interaction: %w", err)
+	}
+	r.next++
+	return nil
+}
@@ -0,0 +1,74 @@
+package cassette
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/thomas-vilte/matecommit/internal/config"
+)
+
+// Replayer is a transport that answers every request from a cassette and never touches the network.
+type Replayer struct {
+	interactions []Interaction
+	used         []bool
+	scrubber     *scrubber
+
+	mu sync.Mutex
+}
+
+// NewReplayer loads a recorded cassette. The redaction settings must be the ones it was recorded with,
+// so the requests are scrubbed the same way before they are matched.
+func NewReplayer(dir string, redaction config.RedactionConfig) (*Replayer, error) {
+	interactions, err := load(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(interactions) == 0 {
+		return nil, fmt.Errorf("no recorded interactions in %s", dir)
+	}
+	scrubber, err := newScrubber(redaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Replayer{
+		interactions: interactions,
+		used:         make([]bool, len(interactions)),
+		scrubber:     scrubber,
+	}, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+// It answers with the first unused interaction with the same method, URL and body; when the body
+// differs (a prompt that changed slightly) the first unused one with the same method and URL is used.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading request body: %w", err)
+	}
+	scrubbed := r.scrubber.request(req, body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	match := -1
+	for i, interaction := range r.interactions {
+		if r.used[i] || interaction.Request.Method != scrubbed.Method || interaction.Request.URL != scrubbed.URL {
+			continue
+		}
+		if interaction.Request.Body == scrubbed.Body {
+			match = i
+			break
+		}
+		if match < 0 {
+			match = i
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("no recorded response for %s %s", scrubbed.Method, scrubbed.URL)
+	}
+
+	r.used[match] = true
+	return responseFrom(req, r.interactions[match].Response)
+}
@@ -1,17 +1,26 @@
 package cache
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/thomas-vilte/matecommit/internal/ai"
 	"github.com/thomas-vilte/matecommit/internal/cache"
 	"github.com/thomas-vilte/matecommit/internal/config"
 	"github.com/thomas-vilte/matecommit/internal/i18n"
+	"github.com/thomas-vilte/matecommit/internal/services/cost"
 	"github.com/urfave/cli/v3"
 )
 
+// shortHashLength is how much of a hash list shows; show accepts any unique prefix
+const shortHashLength = 12
+
 type CacheCommand struct{}
 
 func NewCacheCommand() *CacheCommand {
@@ -27,9 +36,9 @@ func (c *CacheCommand) CreateCommand(t *i18n.Translations, _ *config.Config) *cl
 				Name:  "clean",
 				Usage: t.GetMessage("cache.clean_usage", 0, nil),
 				Action: func(ctx context.Context, cmd *cli.Command) error {
-					cacheService, err := cache.NewCache(24 * time.Hour)
+					cacheService, err := openCache(t)
 					if err != nil {
-						return fmt.Errorf(t.GetMessage("cache.error_init", 0, nil)+": %w", err)
+						return err
 					}
 
 					if err := cacheService.Clean(); err != nil {
@@ -41,6 +50,254 @@ func (c *CacheCommand) CreateCommand(t *i18n.Translations, _ *config.Config) *cl
 					return nil
 				},
 			},
+			{
+				Name:  "stats",
+				Usage: t.GetMessage("cache.stats_usage", 0, nil),
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					cacheService, err := openCache(t)
+					if err != nil {
+						return err
+					}
+					return c.showStats(cacheService, t)
+				},
+			},
+			{
+				Name:  "list",
+				Usage: t.GetMessage("cache.list_usage", 0, nil),
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					cacheService, err := openCache(t)
+					if err != nil {
+						return err
+					}
+					return c.showList(cacheService, t)
+				},
+			},
+			{
+				Name:      "show",
+				Usage:     t.GetMessage("cache.show_usage", 0, nil),
+				ArgsUsage: "<hash>",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					if cmd.Args().Len() != 1 {
+						return errors.New(t.GetMessage("cache.error_missing_hash", 0, nil))
+					}
+					cacheService, err := openCache(t)
+					if err != nil {
+						return err
+					}
+					return c.showEntry(cacheService, t, cmd.Args().First())
+				},
+			},
+			{
+				Name:  "prune",
+				Usage: t.GetMessage("cache.prune_usage", 0, nil),
+				Flags: []cli.Flag{
+					&cli.DurationFlag{
+						Name:     "older-than",
+						Usage:    t.GetMessage("cache.older_than_flag", 0, nil),
+						Required: true,
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					cacheService, err := openCache(t)
+					if err != nil {
+						return err
+					}
+					removed, err := cacheService.Prune(cmd.Duration("older-than"))
+					if err != nil {
+						return fmt.Errorf(t.GetMessage("cache.error_clean", 0, nil)+": %w", err)
+					}
+					printRemoved(t, removed)
+					return nil
+				},
+			},
+			{
+				Name:  "invalidate",
+				Usage: t.GetMessage("cache.invalidate_usage", 0, nil),
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "command",
+						Usage:    t.GetMessage("cache.command_flag", 0, nil),
+						Required: true,
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					cacheService, err := openCache(t)
+					if err != nil {
+						return err
+					}
+					removed, err := cacheService.Invalidate(ai.WrappedCommands(cmd.String("command"))...)
+					if err != nil {
+						return fmt.Errorf(t.GetMessage("cache.error_clean", 0, nil)+": %w", err)
+					}
+					printRemoved(t, removed)
+					return nil
+				},
+			},
 		},
 	}
 }
+
+func openCache(t *i18n.Translations) (*cache.Cache, error) {
+	cacheService, err := cache.NewCache(24 * time.Hour)
+	if err != nil {
+		return nil, fmt.Errorf(t.GetMessage("cache.error_init", 0, nil)+": %w", err)
+	}
+	return cacheService, nil
+}
+
+// showStats prints the size of the cache and, from the history, how often it answered this month.
+func (c *CacheCommand) showStats(cacheService *cache.Cache, t *i18n.Translations) error {
+	stats, err := cacheService.Stats()
+	if err != nil {
+		return fmt.Errorf(t.GetMessage("cache.error_read", 0, nil)+": %w", err)
+	}
+
+	cyan := color.New(color.FgCyan, color.Bold)
+	yellow := color.New(color.FgYellow)
+
+	_, _ = cyan.Printf("\n💾 %s\n", t.GetMessage("cache.stats_title", 0, nil))
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("%s: %d\n", t.GetMessage("cache.stats_entries", 0, nil), stats.Entries)
+	fmt.Printf("%s: %s\n", t.GetMessage("cache.stats_size", 0, nil), formatSize(stats.Size))
+	if stats.Entries > 0 {
+		fmt.Printf("%s: %s (%s)\n",
+			t.GetMessage("cache.stats_oldest", 0, nil),
+			stats.Oldest.Format("2006-01-02 15:04"),
+			formatAge(time.Since(stats.Oldest)))
+	}
+
+	if manager, err := cost.NewManager(0); err == nil {
+		if hitRate, _, err := manager.GetCacheStats(); err == nil {
+			_, _ = yellow.Println(t.GetMessage("cache.stats_hit_rate", 0, struct{ Rate string }{fmt.Sprintf("%.1f", hitRate)}))
+		}
+	}
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println()
+	return nil
+}
+
+// showList prints one line per entry, from the newest to the oldest.
+func (c *CacheCommand) showList(cacheService *cache.Cache, t *i18n.Translations) error {
+	entries, err := cacheService.Entries()
+	if err != nil {
+		return fmt.Errorf(t.GetMessage("cache.error_read", 0, nil)+": %w", err)
+	}
+	if len(entries) == 0 {
+		fmt.Println(t.GetMessage("cache.list_empty", 0, nil))
+		return nil
+	}
+
+	commandWidth := len([]rune(t.GetMessage("cache.column_command", 0, nil)))
+	modelWidth := len([]rune(t.GetMessage("cache.column_model", 0, nil)))
+	for _, entry := range entries {
+		commandWidth = max(commandWidth, len(orDash(entry.Command)))
+		modelWidth = max(modelWidth, len(orDash(entry.Model)))
+	}
+
+	dim := color.New(color.FgHiBlack)
+	fmt.Printf("%-*s │ %-*s │ %-*s │ %6s │ %9s\n",
+		shortHashLength, t.GetMessage("cache.column_hash", 0, nil),
+		commandWidth, t.GetMessage("cache.column_command", 0, nil),
+		modelWidth, t.GetMessage("cache.column_model", 0, nil),
+		t.GetMessage("cache.column_age", 0, nil),
+		t.GetMessage("cache.column_size", 0, nil))
+	fmt.Printf("%s─┼─%s─┼─%s─┼─%s─┼─%s\n",
+		strings.Repeat("─", shortHashLength),
+		strings.Repeat("─", commandWidth),
+		strings.Repeat("─", modelWidth),
+		strings.Repeat("─", 6),
+		strings.Repeat("─", 9))
+
+	for _, entry := range entries {
+		hash := entry.Hash
+		if len(hash) > shortHashLength {
+			hash = hash[:shortHashLength]
+		}
+		fmt.Printf("%-*s │ %-*s │ %-*s │ %6s │ %9s\n",
+			shortHashLength, hash,
+			commandWidth, orDash(entry.Command),
+			modelWidth, orDash(entry.Model),
+			formatAge(time.Since(entry.CreatedAt)),
+			formatSize(entry.Size))
+	}
+	_, _ = dim.Printf("\n%s\n", t.GetMessage("cache.tip_show", 0, nil))
+	return nil
+}
+
+// showEntry prints the metadata of an entry and its response.
+func (c *CacheCommand) showEntry(cacheService *cache.Cache, t *i18n.Translations, prefix string) error {
+	entry, err := cacheService.Find(prefix)
+	switch {
+	case errors.Is(err, cache.ErrEntryNotFound):
+		return errors.New(t.GetMessage("cache.error_not_found", 0, struct{ Hash string }{prefix}))
+	case errors.Is(err, cache.ErrInvalidHash):
+		return errors.New(t.GetMessage("cache.error_invalid_hash", 0, struct{ Hash string }{prefix}))
+	case errors.Is(err, cache.ErrAmbiguousHash):
+		return errors.New(t.GetMessage("cache.error_ambiguous", 0, struct{ Hash string }{prefix}))
+	case err != nil:
+		return fmt.Errorf(t.GetMessage("cache.error_read", 0, nil)+": %w", err)
+	}
+
+	dim := color.New(color.FgHiBlack)
+	fmt.Printf("%s: %s\n", t.GetMessage("cache.column_hash", 0, nil), entry.Hash)
+	fmt.Printf("%s: %s\n", t.GetMessage("cache.column_command", 0, nil), orDash(entry.Command))
+	fmt.Printf("%s: %s\n", t.GetMessage("cache.column_provider", 0, nil), orDash(entry.Provider))
+	fmt.Printf("%s: %s\n", t.GetMessage("cache.column_model", 0, nil), orDash(entry.Model))
+	fmt.Printf("%s: %s (%s)\n", t.GetMessage("cache.column_created", 0, nil),
+		entry.CreatedAt.Format("2006-01-02 15:04:05"), formatAge(time.Since(entry.CreatedAt)))
+	fmt.Printf("%s: %s\n", t.GetMessage("cache.column_size", 0, nil), formatSize(entry.Size))
+	_, _ = dim.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println(responseText(entry.Response))
+	return nil
+}
+
+// responseText returns the text of a cached response; responses are cached as JSON strings,
+// anything else is indented.
+func responseText(response json.RawMessage) string {
+	var text string
+	if err := json.Unmarshal(response, &text); err == nil {
+		return text
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, response, "", "  "); err == nil {
+		return indented.String()
+	}
+	return string(response)
+}
+
+func printRemoved(t *i18n.Translations, removed int) {
+	green := color.New(color.FgGreen, color.Bold)
+	_, _ = green.Printf("✓ %s\n", t.GetMessage("cache.removed", 0, struct{ Count int }{removed}))
+}
+
+// orDash stands in for the metadata that entries written by older versions lack
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func formatSize(size int64) string {
+	switch {
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	case size >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
+
+func formatAge(age time.Duration) string {
+	switch {
+	case age >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(age.Hours()/24))
+	case age >= time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	case age >= time.Minute:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	default:
+		return fmt.Sprintf("%ds", int(age.Seconds()))
+	}
+}
@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomas-vilte/matecommit/internal/cache"
+	"github.com/thomas-vilte/matecommit/internal/config"
+	"github.com/thomas-vilte/matecommit/internal/i18n"
+	"github.com/urfave/cli/v3"
+)
+
+// setupCacheTest fills the cache of an isolated HOME with one response per command
+func setupCacheTest(t *testing.T) (hashes map[string]string, run func(args ...string) (string, error)) {
+	t.Helper()
+	translations, err := i18n.NewTranslations("en", "../../i18n/locales")
+	require.NoError(t, err)
+	t.Setenv("HOME", t.TempDir())
+
+	cacheService, err := cache.NewCache(time.Hour)
+	require.NoError(t, err)
+	hashes = make(map[string]string)
+	for _, command := range []string{"suggest-commits", "summarize-pr"} {
+		hashes[command] = cacheService.GenerateHash(command)
+		require.NoError(t, cacheService.Set(hashes[command], "response of "+command, cache.Metadata{
+			Command:  command,
+			Provider: "gemini",
+			Model:    "gemini-2.5-flash",
+		}))
+	}
+	cmd := NewCacheCommand().CreateCommand(translations, &config.Config{Language: "en"})
+
+	run = func(args ...string) (string, error) {
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		app := &cli.Command{Commands: []*cli.Command{cmd}}
+		runErr := app.Run(context.Background(), append([]string{"matecommit", "cache"}, args...))
+
+		require.NoError(t, w.Close())
+		os.Stdout = oldStdout
+		var buf bytes.Buffer
+		_, _ = io.Copy(&buf, r)
+		return buf.String(), runErr
+	}
+	return hashes, run
+}
+
+func TestCacheCommand_ListAndShow(t *testing.T) {
+	// Arrange
+	hashes, run := setupCacheTest(t)
+
+	// Act
+	list, listErr := run("list")
+	show, showErr := run("show", hashes["summarize-pr"][:8])
+
+	// Assert
+	require.NoError(t, listErr)
+	assert.Contains(t, list, hashes["suggest-commits"][:shortHashLength])
+	assert.Contains(t, list, "summarize-pr")
+	assert.Contains(t, list, "gemini-2.5-flash")
+
+	require.NoError(t, showErr)
+	assert.Contains(t, show, hashes["summarize-pr"])
+	assert.Contains(t, show, "Provider: gemini")
+	assert.Contains(t, show, "response of summarize-pr")
+}
+
+func TestCacheCommand_Show_UnknownHash(t *testing.T) {
+	// Arrange
+	_, run := setupCacheTest(t)
+
+	// Act
+	_, err := run("show", "fff")
+
+	// Assert
+	assert.ErrorContains(t, err, "No cached response matches fff")
+}
+
+func TestCacheCommand_Show_InvalidHash(t *testing.T) {
+	// Arrange
+	_, run := setupCacheTest(t)
+
+	// Act
+	_, err := run("show", "../config")
+
+	// Assert
+	assert.ErrorContains(t, err, "../config is not a cache hash")
+}
+
+func TestCacheCommand_Invalidate(t *testing.T) {
+	// Arrange
+	hashes, run := setupCacheTest(t)
+
+	// Act
+	_, err := run("invalidate", "--command", "suggest")
+
+	// Assert
+	require.NoError(t, err)
+	list, _ := run("list")
+	assert.NotContains(t, list, hashes["suggest-commits"][:shortHashLength], "suggest should match the suggest-commits entries")
+	assert.Contains(t, list, hashes["summarize-pr"][:shortHashLength])
+}
+
+func TestCacheCommand_Stats(t *testing.T) {
+	// Arrange
+	_, run := setupCacheTest(t)
+
+	// Act
+	output, err := run("stats")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, output, "Entries: 2")
+	assert.Contains(t, output, "Oldest:")
+}
+
+func TestFormatSize(t *testing.T) {
+	assert.Equal(t, "512 B", formatSize(512))
+	assert.Equal(t, "1.5 KB", formatSize(1536))
+	assert.Equal(t, "2.0 MB", formatSize(2*1024*1024))
+}
@@ -23,6 +23,7 @@ func (c *ConfigCommandFactory) CreateCommand(t *i18n.Translations, cfg *config.C
 			c.newInitCommand(t, cfg),
 			c.newEditCommand(t, cfg),
 			c.newSetCommand(t, cfg),
+			c.newRoutingCommand(t, cfg),
 		},
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/exec"
 	"strings"
@@ -45,6 +46,7 @@ func (d *DoctorCommand) runHealthCheck(ctx context.Context, t *i18n.Translations
 		{name: "doctor.check_git_user_name", fn: d.checkGitUserName},
 		{name: "doctor.check_git_user_email", fn: d.checkGitUserEmail},
 		{name: "doctor.check_ai_provider", fn: d.checkActiveAIProvider},
+		{name: "doctor.check_models", fn: d.checkModels},
 		{name: "doctor.check_github_token", fn: d.checkGitHubTokenWithScopes},
 		{name: "doctor.check_editor", fn: d.checkEditor},
 	}
@@ -98,9 +100,13 @@ func (d *DoctorCommand) runHealthCheck(ctx context.Context, t *i18n.Translations
 	fmt.Println()
 	ui.PrintInfo(t.GetMessage("doctor.available_commands", 0, nil))
 
-	hasGemini := false
-	if providerCfg, exists := cfg.AIProviders["gemini"]; exists && providerCfg.APIKey != "" {
-		hasGemini = true
+	activeAI := cfg.AIConfig.ActiveAI
+	if activeAI == "" {
+		activeAI = config.AIGemini
+	}
+	hasAIKey := activeAI == config.AILocal
+	if providerCfg, exists := cfg.AIProviders[string(activeAI)]; exists && providerCfg.APIKey != "" {
+		hasAIKey = true
 	}
 	hasGitHub := false
 	if cfg.VCSConfigs != nil {
@@ -109,7 +115,7 @@ func (d *DoctorCommand) runHealthCheck(ctx context.Context, t *i18n.Translations
 		}
 	}
 
-	d.printCommandStatus("suggest", hasGemini, t)
+	d.printCommandStatus("suggest", hasAIKey, t)
 	d.printCommandStatus("summarize-pr", hasGitHub, t)
 	d.printCommandStatus("config", true, t)
 
@@ -209,15 +215,13 @@ func (d *DoctorCommand) checkGeminiAPIKey(ctx context.Context, t *i18n.Translati
 	testCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	service, err := gemini.NewGeminiCommitSummarizer(testCtx, cfg, nil)
-	if err != nil {
+	if _, err := gemini.NewClient(testCtx, cfg); err != nil {
 		return checkResult{
 			status:     checkStatusError,
 			message:    t.GetMessage("doctor.gemini_key_invalid", 0, nil),
 			suggestion: t.GetMessage("doctor.check_api_key", 0, nil),
 		}
 	}
-	_ = service
 
 	return checkResult{
 		status:  checkStatusOK,
@@ -225,6 +229,38 @@ func (d *DoctorCommand) checkGeminiAPIKey(ctx context.Context, t *i18n.Translati
 	}
 }
 
+// checkLocalAIServer verifies that the self-hosted model server answers at its base URL.
+func (d *DoctorCommand) checkLocalAIServer(ctx context.Context, t *i18n.Translations, cfg *config.Config) checkResult {
+	baseURL := cfg.AIProviders[string(config.AILocal)].BaseURL
+	if baseURL == "" {
+		baseURL = "http://localhost:11434"
+	}
+
+	testCtx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(testCtx, http.MethodGet, baseURL, nil)
+	if err == nil {
+		var resp *http.Response
+		resp, err = http.DefaultClient.Do(req)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+	}
+	if err != nil {
+		return checkResult{
+			status:     checkStatusError,
+			message:    t.GetMessage("doctor.local_ai_unreachable", 0, struct{ URL string }{baseURL}),
+			suggestion: t.GetMessage("doctor.local_ai_suggestion", 0, nil),
+		}
+	}
+
+	return checkResult{
+		status:  checkStatusOK,
+		message: t.GetMessage("doctor.local_ai_reachable", 0, struct{ URL string }{baseURL}),
+	}
+}
+
 func (d *DoctorCommand) checkEditor(_ context.Context, t *i18n.Translations, _ *config.Config) checkResult {
 	editor := os.Getenv("EDITOR")
 	if editor == "" {
@@ -333,6 +369,10 @@ func (d *DoctorCommand) checkActiveAIProvider(ctx context.Context, t *i18n.Trans
 		}
 	}
 
+	if activeAI == config.AILocal {
+		return d.checkLocalAIServer(ctx, t, cfg)
+	}
+
 	providerCfg, exists := cfg.AIProviders[string(activeAI)]
 	if !exists || providerCfg.APIKey == "" {
 		return checkResult{
@@ -353,6 +393,85 @@ func (d *DoctorCommand) checkActiveAIProvider(ctx context.Context, t *i18n.Trans
 	}
 }
 
+// checkModels warns about configured models the registry does not know, that are deprecated
+// or that cannot follow a JSON schema: the active model, the per-command models and the fallback chain.
+func (d *DoctorCommand) checkModels(_ context.Context, t *i18n.Translations, cfg *config.Config) checkResult {
+	registry := config.DefaultModelRegistry()
+
+	type usedModel struct {
+		provider config.AI
+		model    config.Model
+	}
+	var used []usedModel
+	seen := make(map[usedModel]bool)
+	add := func(provider config.AI, model config.Model) {
+		if provider == "" {
+			return
+		}
+		if model == "" {
+			model = config.DefaultModelForAI(provider)
+		}
+		entry := usedModel{provider: provider, model: model}
+		if model != "" && !seen[entry] {
+			seen[entry] = true
+			used = append(used, entry)
+		}
+	}
+
+	activeAI := cfg.AIConfig.ActiveAI
+	activeModel := cfg.AIConfig.Models[activeAI]
+	if activeModel == "" {
+		activeModel = config.Model(cfg.AIProviders[string(activeAI)].Model)
+	}
+	add(activeAI, activeModel)
+	for _, command := range config.ConfigurableCommands() {
+		if model := cfg.AIConfig.CommandConfig(command).Model; model != "" {
+			add(activeAI, model)
+		}
+	}
+	for _, fallback := range cfg.AIConfig.Fallback {
+		add(fallback.Provider, fallback.Model)
+	}
+
+	var problems, names []string
+	for _, entry := range used {
+		names = append(names, string(entry.model))
+		data := struct {
+			Provider    config.AI
+			Model       config.Model
+			Replacement config.Model
+		}{Provider: entry.provider, Model: entry.model}
+
+		info, known := registry.Lookup(entry.provider, string(entry.model))
+		switch {
+		case !known:
+			problems = append(problems, t.GetMessage("doctor.model_unknown", 0, data))
+		case info.Deprecated && info.ReplacedBy != "":
+			data.Replacement = info.ReplacedBy
+			problems = append(problems, t.GetMessage("doctor.model_deprecated_replaced", 0, data))
+		case info.Deprecated:
+			problems = append(problems, t.GetMessage("doctor.model_deprecated", 0, data))
+		case !info.JSONSchema:
+			problems = append(problems, t.GetMessage("doctor.model_no_json_schema", 0, data))
+		}
+	}
+
+	if len(problems) > 0 {
+		return checkResult{
+			status:     checkStatusWarning,
+			message:    strings.Join(problems, "; "),
+			suggestion: t.GetMessage("doctor.models_suggestion", 0, struct{ Path string }{config.UserModelsPath()}),
+		}
+	}
+	if len(names) == 0 {
+		return checkResult{status: checkStatusOK}
+	}
+	return checkResult{
+		status:  checkStatusOK,
+		message: fmt.Sprintf("(%s)", strings.Join(names, ", ")),
+	}
+}
+
 func (d *DoctorCommand) checkGitHubTokenWithScopes(ctx context.Context, t *i18n.Translations, cfg *config.Config) checkResult {
 	vcsCfg, ok := cfg.VCSConfigs["github"]
 	if !ok || vcsCfg.Token == "" {
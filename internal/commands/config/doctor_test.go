@@ -0,0 +1,54 @@
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomas-vilte/matecommit/internal/config"
+	"github.com/thomas-vilte/matecommit/internal/i18n"
+)
+
+func TestDoctorCommand_CheckModels(t *testing.T) {
+	translations, err := i18n.NewTranslations("en", "../../i18n/locales")
+	require.NoError(t, err)
+	d := NewDoctorCommand()
+
+	t.Run("registered models pass", func(t *testing.T) {
+		// Arrange
+		cfg := &config.Config{AIConfig: config.AIConfig{
+			ActiveAI: config.AIGemini,
+			Models:   map[config.AI]config.Model{config.AIGemini: config.ModelGeminiV25Flash},
+			Commands: map[string]config.CommandAIConfig{config.CommandRelease: {Model: config.ModelGeminiV3Pro}},
+			Fallback: []config.AIFallback{{Provider: config.AIOpenAI}},
+		}}
+
+		// Act
+		result := d.checkModels(context.Background(), translations, cfg)
+
+		// Assert
+		assert.Equal(t, checkStatusOK, result.status)
+		assert.Equal(t, "(gemini-2.5-flash, gemini-3-pro-preview, gpt-4o-mini)", result.message)
+	})
+
+	t.Run("warns about unknown and deprecated models", func(t *testing.T) {
+		// Arrange
+		cfg := &config.Config{
+			AIConfig: config.AIConfig{
+				ActiveAI: config.AIOpenAI,
+				Fallback: []config.AIFallback{{Provider: config.AILocal, Model: "mystery-model"}},
+			},
+			AIProviders: map[string]config.AIProviderConfig{"openai": {Model: "gpt-4-turbo"}},
+		}
+
+		// Act
+		result := d.checkModels(context.Background(), translations, cfg)
+
+		// Assert
+		assert.Equal(t, checkStatusWarning, result.status)
+		assert.Contains(t, result.message, "gpt-4-turbo (openai) is deprecated, use gpt-4o instead")
+		assert.Contains(t, result.message, "mystery-model (local) is not in the model registry")
+		assert.NotEmpty(t, result.suggestion)
+	})
+}
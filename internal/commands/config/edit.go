@@ -32,7 +32,13 @@ func editConfigAction(cfg *config.Config, t *i18n.Translations) cli.ActionFunc {
 			}
 		}
 
-		cmd := exec.Command(editor, cfg.PathFile)
+		// Inside a repository with its own config, that is the one to edit
+		path := cfg.PathFile
+		if cfg.LocalPathFile != "" {
+			path = cfg.LocalPathFile
+		}
+
+		cmd := exec.Command(editor, path)
 		cmd.Stdin = os.Stdin
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
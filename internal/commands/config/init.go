@@ -9,12 +9,15 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/google/go-github/v80/github"
+	"github.com/thomas-vilte/matecommit/internal/ai/anthropic"
 	"github.com/thomas-vilte/matecommit/internal/ai/gemini"
+	"github.com/thomas-vilte/matecommit/internal/ai/openai"
 	"github.com/thomas-vilte/matecommit/internal/commands/completion_helper"
 	"github.com/thomas-vilte/matecommit/internal/config"
 	"github.com/thomas-vilte/matecommit/internal/i18n"
@@ -69,16 +72,9 @@ func initConfigAction(cfg *config.Config, t *i18n.Translations) cli.ActionFunc {
 				return errors.New(t.GetMessage("config_local.not_in_repo", 0, nil))
 			}
 
-			localCfg, err := config.LoadConfig(localPath)
+			localCfg, err := config.LoadLocalConfig(localPath)
 			if err != nil {
-				if os.IsNotExist(err) {
-					localCfg, err = config.CreateDefaultConfig(localPath)
-					if err != nil {
-						return fmt.Errorf("error creating local config: %w", err)
-					}
-				} else {
-					return fmt.Errorf("error loading local config: %w", err)
-				}
+				return fmt.Errorf("error loading local config: %w", err)
 			}
 
 			reader := bufio.NewReader(os.Stdin)
@@ -106,14 +102,18 @@ func initConfigAction(cfg *config.Config, t *i18n.Translations) cli.ActionFunc {
 			return runFullSetupLocal(ctx, command, reader, localCfg, t)
 		}
 
+		globalCfg, err := config.GlobalOnly(cfg)
+		if err != nil {
+			return fmt.Errorf("error loading global config: %w", err)
+		}
 		reader := bufio.NewReader(os.Stdin)
 
 		if command.Bool("quick") {
-			return runQuickSetup(ctx, reader, cfg, t)
+			return runQuickSetup(ctx, reader, globalCfg, t)
 		}
 
 		if command.Bool("full") {
-			return runFullSetup(ctx, command, reader, cfg, t)
+			return runFullSetup(ctx, command, reader, globalCfg, t)
 		}
 
 		fmt.Println(t.GetMessage("setup_mode.choose_mode", 0, nil))
@@ -125,10 +125,10 @@ func initConfigAction(cfg *config.Config, t *i18n.Translations) cli.ActionFunc {
 		choice = strings.TrimSpace(choice)
 
 		if choice == "" || choice == "1" {
-			return runQuickSetup(ctx, reader, cfg, t)
+			return runQuickSetup(ctx, reader, globalCfg, t)
 		}
 
-		return runFullSetup(ctx, command, reader, cfg, t)
+		return runFullSetup(ctx, command, reader, globalCfg, t)
 	}
 }
 
@@ -159,23 +159,45 @@ func runFullSetup(ctx context.Context, command *cli.Command, reader *bufio.Reade
 	return nil
 }
 
+// aiProviderSetupInfo holds what the wizard shows when asking for a provider's API key.
+type aiProviderSetupInfo struct {
+	Name   string
+	KeyURL string
+}
+
+var aiProviderSetup = map[config.AI]aiProviderSetupInfo{
+	config.AIGemini:    {Name: "Gemini", KeyURL: "https://makersuite.google.com/app/apikey"},
+	config.AIOpenAI:    {Name: "OpenAI", KeyURL: "https://platform.openai.com/api-keys"},
+	config.AIAnthropic: {Name: "Anthropic", KeyURL: "https://console.anthropic.com/settings/keys"},
+	config.AILocal:     {Name: "Local"},
+}
+
 func configureWelcome(ctx context.Context, reader *bufio.Reader, cfg *config.Config, t *i18n.Translations) error {
 	aiProviders := config.SupportedAIs()
 	aiProvidersStr := strings.Join(toStrings(aiProviders), ", ")
 
-	geminiModels := config.ModelsForAI(config.AIGemini)
-	geminiModelsStr := strings.Join(toStrings(geminiModels), ", ")
-	geminiDefault := string(config.DefaultModelForAI(config.AIGemini))
-
 	printSection(t.GetMessage("init.section_welcome", 0, nil))
 	fmt.Println(t.GetMessage("init.welcome", 0, nil))
 	fmt.Println(t.GetMessage("init.ai_intro", 0, struct{ Providers string }{aiProvidersStr}))
 
-	ui.PrintInfo(t.GetMessage("config.api_key_instructions", 0, struct{ Provider string }{"Gemini"}))
-	ui.PrintInfo(t.GetMessage("config.get_key_at", 0, struct{ URL string }{"https://makersuite.google.com/app/apikey"}))
+	provider, err := configureAIProvider(reader, cfg, t)
+	if err != nil {
+		return err
+	}
+	if provider == config.AILocal {
+		return configureLocalAI(reader, cfg, t)
+	}
+	setup := aiProviderSetup[provider]
+
+	providerModels := config.ModelsForAI(provider)
+	providerModelsStr := strings.Join(toStrings(providerModels), ", ")
+	providerDefault := string(config.DefaultModelForAI(provider))
+
+	ui.PrintInfo(t.GetMessage("config.api_key_instructions", 0, struct{ Provider string }{setup.Name}))
+	ui.PrintInfo(t.GetMessage("config.get_key_at", 0, struct{ URL string }{setup.KeyURL}))
 	fmt.Println()
 
-	fmt.Print(t.GetMessage("init.prompt_ai_api_key", 0, struct{ Provider string }{"Gemini"}))
+	fmt.Print(t.GetMessage("init.prompt_ai_api_key", 0, struct{ Provider string }{setup.Name}))
 	apiKey, err := reader.ReadString('\n')
 	if err != nil {
 		return fmt.Errorf("error reading API_KEY: %w", err)
@@ -183,7 +205,7 @@ func configureWelcome(ctx context.Context, reader *bufio.Reader, cfg *config.Con
 	apiKey = strings.TrimSpace(apiKey)
 
 	if apiKey != "" {
-		if !validateGeminiAPIKey(ctx, apiKey, t) {
+		if !validateAPIKey(ctx, provider, apiKey, t) {
 			ui.PrintWarning(t.GetMessage("config.api_key_saved_unverified", 0, nil))
 			if ui.AskConfirmation(t.GetMessage("config.retry_api_key", 0, nil)) {
 				return configureWelcome(ctx, reader, cfg, t)
@@ -191,42 +213,123 @@ func configureWelcome(ctx context.Context, reader *bufio.Reader, cfg *config.Con
 		}
 	}
 
-	fmt.Println(t.GetMessage("init.model_hint_supported", 0, struct{ Models string }{geminiModelsStr}))
-	fmt.Print(t.GetMessage("init.prompt_model_with_default", 0, struct{ Default string }{geminiDefault}))
+	fmt.Println(t.GetMessage("init.model_hint_supported", 0, struct{ Models string }{providerModelsStr}))
+	printModelDetails(provider, t)
+	fmt.Print(t.GetMessage("init.prompt_model_with_default", 0, struct{ Default string }{providerDefault}))
 	modelInput, err := reader.ReadString('\n')
 	if err != nil {
 		return fmt.Errorf("error reading model: %w", err)
 	}
 	modelInput = strings.TrimSpace(modelInput)
 
-	selectedModel := geminiDefault
+	selectedModel := providerDefault
 	if modelInput != "" {
 		selectedModel = modelInput
 	}
+	warnDeprecatedModel(provider, selectedModel, t)
 
 	if cfg.AIProviders == nil {
 		cfg.AIProviders = make(map[string]config.AIProviderConfig)
 	}
 
-	cfg.AIProviders["gemini"] = config.AIProviderConfig{
+	cfg.AIProviders[string(provider)] = config.AIProviderConfig{
 		APIKey:      apiKey,
 		Model:       selectedModel,
 		Temperature: 0.3,
 		MaxTokens:   10000,
 	}
 
-	cfg.AIConfig.ActiveAI = config.AIGemini
+	cfg.AIConfig.ActiveAI = provider
 	if cfg.AIConfig.Models == nil {
 		cfg.AIConfig.Models = make(map[config.AI]config.Model)
 	}
-	cfg.AIConfig.Models[config.AIGemini] = config.Model(selectedModel)
+	cfg.AIConfig.Models[provider] = config.Model(selectedModel)
 
 	return nil
 }
 
+// configureLocalAI asks for the address of a self-hosted model server instead of an API key.
+func configureLocalAI(reader *bufio.Reader, cfg *config.Config, t *i18n.Translations) error {
+	const defaultBaseURL = "http://localhost:11434"
+
+	ui.PrintInfo(t.GetMessage("init.local_ai_intro", 0, nil))
+	fmt.Print(t.GetMessage("init.prompt_local_base_url_with_default", 0, struct{ Default string }{defaultBaseURL}))
+	baseURL, err := reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("error reading base URL: %w", err)
+	}
+	baseURL = strings.TrimSpace(baseURL)
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	if !isValidURL(baseURL) {
+		ui.PrintWarning(t.GetMessage("init.warning_invalid_url", 0, nil))
+	}
+
+	localModels := strings.Join(toStrings(config.ModelsForAI(config.AILocal)), ", ")
+	localDefault := string(config.DefaultModelForAI(config.AILocal))
+
+	fmt.Println(t.GetMessage("init.model_hint_supported", 0, struct{ Models string }{localModels}))
+	printModelDetails(config.AILocal, t)
+	fmt.Print(t.GetMessage("init.prompt_model_with_default", 0, struct{ Default string }{localDefault}))
+	modelInput, err := reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("error reading model: %w", err)
+	}
+	selectedModel := strings.TrimSpace(modelInput)
+	if selectedModel == "" {
+		selectedModel = localDefault
+	}
+	warnDeprecatedModel(config.AILocal, selectedModel, t)
+
+	if cfg.AIProviders == nil {
+		cfg.AIProviders = make(map[string]config.AIProviderConfig)
+	}
+	cfg.AIProviders[string(config.AILocal)] = config.AIProviderConfig{
+		BaseURL:     baseURL,
+		Model:       selectedModel,
+		Temperature: 0.3,
+		MaxTokens:   10000,
+	}
+
+	cfg.AIConfig.ActiveAI = config.AILocal
+	if cfg.AIConfig.Models == nil {
+		cfg.AIConfig.Models = make(map[config.AI]config.Model)
+	}
+	cfg.AIConfig.Models[config.AILocal] = config.Model(selectedModel)
+
+	return nil
+}
+
+// configureAIProvider asks which AI provider to use. Enter keeps the current one (Gemini on a fresh config).
+func configureAIProvider(reader *bufio.Reader, cfg *config.Config, t *i18n.Translations) (config.AI, error) {
+	current := config.AIGemini
+	if _, ok := aiProviderSetup[cfg.AIConfig.ActiveAI]; ok {
+		current = cfg.AIConfig.ActiveAI
+	}
+
+	fmt.Print(t.GetMessage("init.prompt_ai_provider_with_default", 0, struct{ Default string }{string(current)}))
+	input, err := reader.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("error reading AI provider: %w", err)
+	}
+	input = strings.TrimSpace(strings.ToLower(input))
+
+	if input == "" {
+		return current, nil
+	}
+	if _, ok := aiProviderSetup[config.AI(input)]; !ok {
+		fmt.Println(t.GetMessage("init.error_invalid_ai_provider", 0, struct{ Default string }{string(current)}))
+		return current, nil
+	}
+
+	return config.AI(input), nil
+}
+
 func configureLanguage(reader *bufio.Reader, cfg *config.Config, t *i18n.Translations) error {
 	printSection(t.GetMessage("init.section_language", 0, nil))
-	fmt.Println(t.GetMessage("init.language_supported_with_current", 0, struct{ Current string }{cfg.Language}))
+	languages := strings.Join(config.SupportedLanguages(), ", ")
+	fmt.Println(t.GetMessage("init.language_supported_with_current", 0, struct{ Languages, Current string }{languages, cfg.Language}))
 	fmt.Print(t.GetMessage("init.prompt_language_blank_keeps", 0, nil))
 
 	lang, err := reader.ReadString('\n')
@@ -236,10 +339,10 @@ func configureLanguage(reader *bufio.Reader, cfg *config.Config, t *i18n.Transla
 	lang = strings.TrimSpace(strings.ToLower(lang))
 
 	if lang != "" {
-		if isValidLanguage(lang) {
+		if config.IsSupportedLanguage(lang) {
 			cfg.Language = lang
 		} else {
-			fmt.Println(t.GetMessage("init.error_invalid_language", 0, nil))
+			fmt.Println(t.GetMessage("init.error_invalid_language", 0, struct{ Languages string }{languages}))
 		}
 	}
 
@@ -404,23 +507,23 @@ func printConfigSummary(cfg *config.Config, t *i18n.Translations) {
 	activeAI := string(cfg.AIConfig.ActiveAI)
 	fmt.Println(t.GetMessage("config_models.active_ai_label", 0, struct{ IA string }{activeAI}))
 
-	if m, ok := cfg.AIConfig.Models[config.AIGemini]; ok && m != "" {
+	if m, ok := cfg.AIConfig.Models[cfg.AIConfig.ActiveAI]; ok && m != "" {
 		fmt.Println(t.GetMessage("init.summary_model", 0, struct {
 			AI    string
 			Model string
-		}{"gemini", string(m)}))
+		}{activeAI, string(m)}))
 	} else {
-		fmt.Println(t.GetMessage("init.summary_model_none", 0, struct{ AI string }{"gemini"}))
+		fmt.Println(t.GetMessage("init.summary_model_none", 0, struct{ AI string }{activeAI}))
 	}
 
 	apiMask := "❌"
-	if providerCfg, exists := cfg.AIProviders["gemini"]; exists && providerCfg.APIKey != "" {
+	if providerCfg, exists := cfg.AIProviders[activeAI]; exists && providerCfg.APIKey != "" {
 		apiMask = "✅"
 	}
 	fmt.Println(t.GetMessage("init.summary_api", 0, struct {
 		AI         string
 		Configured string
-	}{"gemini", apiMask}))
+	}{activeAI, apiMask}))
 
 	if cfg.ActiveVCSProvider != "" {
 		fmt.Println(t.GetMessage("vcs_summary.config_active_vcs_updated", 0, struct{ Provider string }{cfg.ActiveVCSProvider}))
@@ -442,6 +545,18 @@ func printConfigSummary(cfg *config.Config, t *i18n.Translations) {
 	fmt.Println(langLabel)
 }
 
+// validateAPIKey checks the API key of the chosen provider.
+func validateAPIKey(ctx context.Context, provider config.AI, apiKey string, t *i18n.Translations) bool {
+	switch provider {
+	case config.AIAnthropic:
+		return validateAnthropicAPIKey(ctx, apiKey, t)
+	case config.AIOpenAI:
+		return validateOpenAIAPIKey(ctx, apiKey, t)
+	default:
+		return validateGeminiAPIKey(ctx, apiKey, t)
+	}
+}
+
 func validateGeminiAPIKey(ctx context.Context, apiKey string, t *i18n.Translations) bool {
 	if apiKey == "" {
 		return false
@@ -472,7 +587,7 @@ func validateGeminiAPIKey(ctx context.Context, apiKey string, t *i18n.Translatio
 	testCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	_, err := gemini.NewGeminiCommitSummarizer(testCtx, testCfg, nil)
+	_, err := gemini.NewClient(testCtx, testCfg)
 	if err != nil {
 		spinner.Error(t.GetMessage("config.api_key_invalid", 0, nil))
 		ui.PrintError(os.Stdout, t.GetMessage("config.check_api_key_error", 0, struct{ Error string }{err.Error()}))
@@ -483,6 +598,54 @@ func validateGeminiAPIKey(ctx context.Context, apiKey string, t *i18n.Translatio
 	return true
 }
 
+// validateOpenAIAPIKey lists the models of the account, which is free and fails on an invalid key.
+func validateOpenAIAPIKey(ctx context.Context, apiKey string, t *i18n.Translations) bool {
+	if apiKey == "" {
+		return false
+	}
+
+	ui.PrintInfo(t.GetMessage("config.validating_api_key", 0, nil))
+	spinner := ui.NewSmartSpinner(t.GetMessage("config.testing_connection", 0, nil))
+	spinner.Start()
+
+	testCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	provider := openai.NewOpenAIProvider(apiKey, string(config.DefaultModelForAI(config.AIOpenAI)))
+	if _, err := provider.ListModels(testCtx); err != nil {
+		spinner.Error(t.GetMessage("config.api_key_invalid", 0, nil))
+		ui.PrintError(os.Stdout, t.GetMessage("config.check_api_key_error", 0, struct{ Error string }{err.Error()}))
+		return false
+	}
+
+	spinner.Success(t.GetMessage("config.api_key_valid", 0, nil))
+	return true
+}
+
+// validateAnthropicAPIKey counts the tokens of a tiny prompt, which is free and fails on an invalid key.
+func validateAnthropicAPIKey(ctx context.Context, apiKey string, t *i18n.Translations) bool {
+	if apiKey == "" {
+		return false
+	}
+
+	ui.PrintInfo(t.GetMessage("config.validating_api_key", 0, nil))
+	spinner := ui.NewSmartSpinner(t.GetMessage("config.testing_connection", 0, nil))
+	spinner.Start()
+
+	testCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	provider := anthropic.NewAnthropicProvider(apiKey, string(config.DefaultModelForAI(config.AIAnthropic)))
+	if _, err := provider.CountTokens(testCtx, "ping"); err != nil {
+		spinner.Error(t.GetMessage("config.api_key_invalid", 0, nil))
+		ui.PrintError(os.Stdout, t.GetMessage("config.check_api_key_error", 0, struct{ Error string }{err.Error()}))
+		return false
+	}
+
+	spinner.Success(t.GetMessage("config.api_key_valid", 0, nil))
+	return true
+}
+
 func validateGitHubToken(ctx context.Context, token string, t *i18n.Translations) bool {
 	if token == "" {
 		return false
@@ -658,14 +821,6 @@ func disableTickets(cfg *config.Config) {
 	cfg.ActiveTicketService = ""
 }
 
-func isValidLanguage(lang string) bool {
-	validLangs := map[string]bool{
-		"en": true,
-		"es": true,
-	}
-	return validLangs[strings.ToLower(lang)]
-}
-
 func isValidURL(rawURL string) bool {
 	u, err := url.Parse(rawURL)
 	return err == nil && u.Scheme != "" && u.Host != ""
@@ -680,6 +835,49 @@ func printSection(title string) {
 	fmt.Println(title)
 }
 
+// printModelDetails lists the context window and pricing of the models offered for a provider.
+func printModelDetails(provider config.AI, t *i18n.Translations) {
+	for _, info := range config.DefaultModelRegistry().Models(provider) {
+		price := t.GetMessage("init.model_free", 0, nil)
+		if info.Pricing != nil {
+			price = t.GetMessage("init.model_price", 0, struct{ Input, Output string }{
+				Input:  strconv.FormatFloat(info.Pricing.InputPerMillion, 'f', -1, 64),
+				Output: strconv.FormatFloat(info.Pricing.OutputPerMillion, 'f', -1, 64),
+			})
+		}
+		fmt.Println(t.GetMessage("init.model_details", 0, struct {
+			Model   config.Model
+			Context string
+			Price   string
+		}{info.Model, formatTokenCount(info.ContextWindow), price}))
+	}
+}
+
+// warnDeprecatedModel warns when the chosen model is deprecated in the model registry.
+func warnDeprecatedModel(provider config.AI, model string, t *i18n.Translations) {
+	info, known := config.DefaultModelRegistry().Lookup(provider, model)
+	if !known || !info.Deprecated {
+		return
+	}
+	if info.ReplacedBy != "" {
+		ui.PrintWarning(t.GetMessage("init.warning_model_deprecated_replaced", 0, struct{ Model, Replacement string }{model, string(info.ReplacedBy)}))
+		return
+	}
+	ui.PrintWarning(t.GetMessage("init.warning_model_deprecated", 0, struct{ Model string }{model}))
+}
+
+// formatTokenCount shortens a token count for display (1048576 -> 1M, 128000 -> 128K).
+func formatTokenCount(tokens int) string {
+	switch {
+	case tokens >= 1_000_000:
+		return strconv.FormatFloat(float64(tokens/100_000)/10, 'f', -1, 64) + "M"
+	case tokens >= 1_000:
+		return strconv.Itoa(tokens/1_000) + "K"
+	default:
+		return strconv.Itoa(tokens)
+	}
+}
+
 func toStrings[T ~string](vals []T) []string {
 	out := make([]string, 0, len(vals))
 	for _, v := range vals {
@@ -87,6 +87,7 @@ func runInitCommandTest(t *testing.T, userInput string, fullMode bool) (output s
 func TestInitCommand(t *testing.T) {
 	t.Run("should configure all options successfully", func(t *testing.T) {
 		userInput := strings.Join([]string{
+			"",
 			"my-gemini-api-key",
 			"n",
 			"gemini-1.5-flash",
@@ -108,6 +109,7 @@ func TestInitCommand(t *testing.T) {
 
 	t.Run("should skip optional VCS and Tickets sections", func(t *testing.T) {
 		userInput := strings.Join([]string{
+			"",
 			"test-api-key",
 			"n",
 			"",
@@ -135,17 +137,19 @@ func TestInitCommand(t *testing.T) {
 		userInput := strings.Join([]string{
 			"",
 			"",
-			"fr",
+			"",
+			"ja",
 			"n",
 			"n",
 		}, "\n") + "\n"
 		output, finalCfg := runInitCommandTest(t, userInput, true)
-		assert.Contains(t, output, "Invalid language. Please enter 'en' or 'es'.")
+		assert.Contains(t, output, "Invalid language. Please enter one of: en, es, pt, fr, de, it.")
 		assert.Equal(t, "en", finalCfg.Language)
 	})
 
 	t.Run("should run configuration and save", func(t *testing.T) {
 		userInput := strings.Join([]string{
+			"",
 			"first-run-key",
 			"n",
 			"",
@@ -162,4 +166,52 @@ func TestInitCommand(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("should configure the chosen AI provider", func(t *testing.T) {
+		userInput := strings.Join([]string{
+			"anthropic",
+			"",
+			"",
+			"en",
+			"n",
+			"n",
+		}, "\n") + "\n"
+		output, finalCfg := runInitCommandTest(t, userInput, true)
+
+		assert.Contains(t, output, "Anthropic")
+		assert.Equal(t, config.AIAnthropic, finalCfg.AIConfig.ActiveAI)
+		assert.Equal(t, config.DefaultModelForAI(config.AIAnthropic), finalCfg.AIConfig.Models[config.AIAnthropic])
+	})
+
+	t.Run("should keep default provider when input is unsupported", func(t *testing.T) {
+		userInput := strings.Join([]string{
+			"unknown-ai",
+			"",
+			"",
+			"en",
+			"n",
+			"n",
+		}, "\n") + "\n"
+		output, finalCfg := runInitCommandTest(t, userInput, true)
+
+		assert.Contains(t, output, "Unsupported AI provider")
+		assert.Equal(t, config.AIGemini, finalCfg.AIConfig.ActiveAI)
+	})
+}
+
+func TestInitCommand_LocalProvider(t *testing.T) {
+	userInput := strings.Join([]string{
+		"local",
+		"http://localhost:8080/v1",
+		"qwen2.5-coder",
+		"en",
+		"n",
+		"n",
+	}, "\n") + "\n"
+	_, finalCfg := runInitCommandTest(t, userInput, true)
+
+	assert.Equal(t, config.AILocal, finalCfg.AIConfig.ActiveAI)
+	assert.Equal(t, "http://localhost:8080/v1", finalCfg.AIProviders["local"].BaseURL)
+	assert.Empty(t, finalCfg.AIProviders["local"].APIKey)
+	assert.Equal(t, config.Model("qwen2.5-coder"), finalCfg.AIConfig.Models[config.AILocal])
 }
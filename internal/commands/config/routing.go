@@ -0,0 +1,124 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/thomas-vilte/matecommit/internal/config"
+	"github.com/thomas-vilte/matecommit/internal/i18n"
+	"github.com/thomas-vilte/matecommit/internal/services/routing"
+	"github.com/thomas-vilte/matecommit/internal/ui"
+	"github.com/urfave/cli/v3"
+)
+
+func (c *ConfigCommandFactory) newRoutingCommand(t *i18n.Translations, cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:  "routing",
+		Usage: t.GetMessage("config_routing.usage", 0, nil),
+		Commands: []*cli.Command{
+			c.newRoutingTestCommand(t, cfg),
+		},
+	}
+}
+
+func (c *ConfigCommandFactory) newRoutingTestCommand(t *i18n.Translations, cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:  "test",
+		Usage: t.GetMessage("config_routing.test_usage", 0, nil),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "op",
+				Usage:    t.GetMessage("config_routing.op_flag", 0, nil),
+				Required: true,
+			},
+			&cli.IntFlag{
+				Name:  "tokens",
+				Usage: t.GetMessage("config_routing.tokens_flag", 0, nil),
+			},
+			&cli.IntFlag{
+				Name:  "files",
+				Usage: t.GetMessage("config_routing.files_flag", 0, nil),
+			},
+			&cli.StringFlag{
+				Name:  "provider",
+				Usage: t.GetMessage("config_routing.provider_flag", 0, nil),
+			},
+		},
+		Action: func(ctx context.Context, command *cli.Command) error {
+			operation := strings.ToLower(command.String("op"))
+			if !slices.Contains(config.ConfigurableCommands(), operation) {
+				return fmt.Errorf("unknown operation: %s (valid: %s)", operation, strings.Join(config.ConfigurableCommands(), ", "))
+			}
+
+			provider := config.AI(command.String("provider"))
+			if provider == "" {
+				provider = cfg.AIConfig.ActiveAI
+			}
+			if provider == "" {
+				provider = config.AIGemini
+			}
+
+			request := routing.Request{
+				Operation:       operation,
+				Provider:        string(provider),
+				EstimatedTokens: int(command.Int("tokens")),
+				FileCount:       int(command.Int("files")),
+			}
+			printRoutingTest(t, cfg, request)
+			return nil
+		},
+	}
+}
+
+// printRoutingTest explains which routing rule fires for the request, the same way the cost-aware wrapper evaluates it
+func printRoutingTest(t *i18n.Translations, cfg *config.Config, request routing.Request) {
+	ui.PrintSectionBanner(t.GetMessage("config_routing.test_header", 0, nil))
+	ui.PrintKeyValue(t.GetMessage("config_routing.operation_label", 0, nil), request.Operation)
+	ui.PrintKeyValue(t.GetMessage("config_routing.provider_label", 0, nil), request.Provider)
+	ui.PrintKeyValue(t.GetMessage("config_routing.tokens_label", 0, nil), strconv.Itoa(request.EstimatedTokens))
+	ui.PrintKeyValue(t.GetMessage("config_routing.files_label", 0, nil), strconv.Itoa(request.FileCount))
+	fmt.Println()
+
+	currentModel := cfg.AIConfig.Models[config.AI(request.Provider)]
+	if currentModel == "" {
+		currentModel = config.DefaultModelForAI(config.AI(request.Provider))
+	}
+
+	if commandModel := cfg.AIConfig.CommandConfig(request.Operation).Model; commandModel != "" {
+		ui.PrintInfo(t.GetMessage("config_routing.command_model_set", 0, struct {
+			Operation string
+			Model     config.Model
+		}{request.Operation, commandModel}))
+		return
+	}
+
+	selector := routing.NewModelSelector(cfg.AIConfig.Routing)
+	if len(cfg.AIConfig.Routing) > 0 {
+		ui.PrintInfo(t.GetMessage("config_routing.configured_rules", 0, struct{ Count int }{len(cfg.AIConfig.Routing)}))
+	} else {
+		ui.PrintInfo(t.GetMessage("config_routing.builtin_rules", 0, nil))
+	}
+
+	rule, index, ok := selector.Select(request)
+	if !ok || rule.Model == "" {
+		ui.PrintWarning(t.GetMessage("config_routing.no_match", 0, struct{ Model config.Model }{currentModel}))
+		return
+	}
+
+	ui.PrintSuccess(os.Stdout, t.GetMessage("config_routing.rule_fired", 0, struct {
+		Position int
+		Name     string
+	}{index + 1, rule.Name}))
+
+	rationale, isKey := selector.GetRationale(rule)
+	if isKey {
+		rationale = t.GetMessage(rationale, 0, nil)
+	}
+	ui.PrintKeyValue(t.GetMessage("config_routing.model_label", 0, nil), string(rule.Model))
+	ui.PrintKeyValue(t.GetMessage("config_routing.current_model_label", 0, nil), string(currentModel))
+	ui.PrintKeyValue(t.GetMessage("config_routing.rationale_label", 0, nil), rationale)
+}
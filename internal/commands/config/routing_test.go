@@ -0,0 +1,121 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomas-vilte/matecommit/internal/config"
+	"github.com/urfave/cli/v3"
+)
+
+func TestRoutingTestCommand(t *testing.T) {
+	runRoutingTest := func(t *testing.T, cfg *config.Config, args ...string) (string, error) {
+		t.Helper()
+		_, translations, _, cleanup := setupConfigTest(t)
+		defer cleanup()
+
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		cmd := NewConfigCommandFactory().newRoutingCommand(translations, cfg)
+		app := &cli.Command{Commands: []*cli.Command{cmd}}
+		err := app.Run(context.Background(), append([]string{"config", "routing", "test"}, args...))
+
+		require.NoError(t, w.Close())
+		os.Stdout = oldStdout
+		var buf bytes.Buffer
+		_, copyErr := io.Copy(&buf, r)
+		require.NoError(t, copyErr)
+		return buf.String(), err
+	}
+
+	t.Run("should show the built-in rule that fires", func(t *testing.T) {
+		// Act
+		output, err := runRoutingTest(t, &config.Config{}, "--op", "suggest", "--tokens", "20000")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Contains(t, output, "Rule #3 fires: large-context")
+		assert.Contains(t, output, "gemini-3-flash-preview")
+		assert.Contains(t, output, "Large operation")
+	})
+
+	t.Run("should show the configured rule that fires", func(t *testing.T) {
+		// Arrange
+		cfg := &config.Config{AIConfig: config.AIConfig{
+			ActiveAI: config.AIOpenAI,
+			Routing: []config.RoutingRule{
+				{Name: "small", Operation: config.CommandSuggest, MaxTokens: 1000, Model: "gpt-4o-mini"},
+				{Name: "large", Operation: config.CommandSuggest, MinTokens: 1001, Model: "gpt-4o", Rationale: "Large diffs need the strong model"},
+			},
+		}}
+
+		// Act
+		output, err := runRoutingTest(t, cfg, "--op", "suggest", "--tokens", "20000")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Contains(t, output, "Evaluating 2 configured rules in order")
+		assert.Contains(t, output, "Rule #2 fires: large")
+		assert.Contains(t, output, "Large diffs need the strong model")
+	})
+
+	t.Run("should show the position of a rule whose model was replaced", func(t *testing.T) {
+		// Arrange
+		cfg := &config.Config{AIConfig: config.AIConfig{
+			ActiveAI: config.AIOpenAI,
+			Routing: []config.RoutingRule{
+				{Name: "small", Operation: config.CommandSuggest, MaxTokens: 1000, Model: "gpt-4o-mini"},
+				{Name: "legacy", Operation: config.CommandSuggest, Model: "gpt-4-turbo"},
+			},
+		}}
+
+		// Act
+		output, err := runRoutingTest(t, cfg, "--op", "suggest", "--tokens", "5000")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Contains(t, output, "Rule #2 fires: legacy")
+		assert.Contains(t, output, "gpt-4o")
+	})
+
+	t.Run("should report when no rule matches", func(t *testing.T) {
+		// Arrange
+		cfg := &config.Config{AIConfig: config.AIConfig{ActiveAI: config.AIAnthropic}}
+
+		// Act
+		output, err := runRoutingTest(t, cfg, "--op", "release")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Contains(t, output, "No rule matches, the current model (claude-3-haiku-20240307) is used")
+	})
+
+	t.Run("should report that a command model skips routing", func(t *testing.T) {
+		// Arrange
+		cfg := &config.Config{AIConfig: config.AIConfig{Commands: map[string]config.CommandAIConfig{
+			config.CommandRelease: {Model: config.ModelGeminiV3Pro},
+		}}}
+
+		// Act
+		output, err := runRoutingTest(t, cfg, "--op", "release")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Contains(t, output, "ai_config.commands.release.model is set to gemini-3-pro-preview")
+	})
+
+	t.Run("should reject unknown operations", func(t *testing.T) {
+		// Act
+		_, err := runRoutingTest(t, &config.Config{}, "--op", "deploy")
+
+		// Assert
+		assert.ErrorContains(t, err, "unknown operation: deploy")
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -14,6 +15,13 @@ import (
 	"github.com/urfave/cli/v3"
 )
 
+const (
+	// commandKeyPrefix selects the per-command AI settings, e.g. ai_config.commands.release.model
+	commandKeyPrefix = "ai_config.commands."
+	// budgetKeyPrefix selects the budget caps and policy, e.g. ai_config.budget.monthly
+	budgetKeyPrefix = "ai_config.budget."
+)
+
 func (c *ConfigCommandFactory) newSetCommand(t *i18n.Translations, cfg *config.Config) *cli.Command {
 	return &cli.Command{
 		Name:      "set",
@@ -56,23 +64,22 @@ func (c *ConfigCommandFactory) newSetCommand(t *i18n.Translations, cfg *config.C
 					return errors.New(t.GetMessage("config_local.not_in_repo", 0, nil))
 				}
 
-				localCfg, err := config.LoadConfig(localPath)
+				localCfg, err := config.LoadLocalConfig(localPath)
 				if err != nil {
-					if os.IsNotExist(err) || errors.Is(err, os.ErrNotExist) {
-						localCfg, err = config.CreateDefaultConfig(localPath)
-						if err != nil {
-							return fmt.Errorf("error creating local config: %w", err)
-						}
-					} else {
-						return fmt.Errorf("error loading local config: %w. Fix the file manually or delete it", err)
-					}
+					return fmt.Errorf("error loading local config: %w. Fix the file manually or delete it", err)
 				}
 				targetCfg = localCfg
+			} else {
+				globalCfg, err := config.GlobalOnly(cfg)
+				if err != nil {
+					return fmt.Errorf("error loading global config: %w", err)
+				}
+				targetCfg = globalCfg
 			}
 
 			switch key {
 			case "lang", "language":
-				if isValidLanguage(value) {
+				if config.IsSupportedLanguage(value) {
 					targetCfg.Language = value
 				} else {
 					return fmt.Errorf("invalid language: %s", value)
@@ -92,11 +99,16 @@ func (c *ConfigCommandFactory) newSetCommand(t *i18n.Translations, cfg *config.C
 			case "active-ai", "active_ai":
 				targetCfg.AIConfig.ActiveAI = config.AI(value)
 			case "model":
-				if targetCfg.AIConfig.ActiveAI != "" {
+				// A repository without a provider of its own sets the model of the global one
+				activeAI := targetCfg.AIConfig.ActiveAI
+				if activeAI == "" {
+					activeAI = cfg.AIConfig.ActiveAI
+				}
+				if activeAI != "" {
 					if targetCfg.AIConfig.Models == nil {
 						targetCfg.AIConfig.Models = make(map[config.AI]config.Model)
 					}
-					targetCfg.AIConfig.Models[targetCfg.AIConfig.ActiveAI] = config.Model(value)
+					targetCfg.AIConfig.Models[activeAI] = config.Model(value)
 				} else {
 					return fmt.Errorf("no active AI provider configured")
 				}
@@ -106,8 +118,28 @@ func (c *ConfigCommandFactory) newSetCommand(t *i18n.Translations, cfg *config.C
 				targetCfg.GitFallback.UserName = value
 			case "git.email", "git-email":
 				targetCfg.GitFallback.UserEmail = value
+			case "token-counting", "token_counting", "ai_config.token_counting":
+				if !slices.Contains(config.TokenCountingModes(), value) {
+					return fmt.Errorf("invalid token counting mode (valid: %s): %s", strings.Join(config.TokenCountingModes(), ", "), value)
+				}
+				targetCfg.AIConfig.TokenCounting = value
+			case "budget-policy", "budget_policy":
+				if err := setBudgetConfig(targetCfg, "policy", value); err != nil {
+					return err
+				}
 			default:
-				return fmt.Errorf("unknown configuration key: %s", key)
+				var err error
+				switch {
+				case strings.HasPrefix(key, commandKeyPrefix):
+					err = setCommandAIConfig(targetCfg, strings.TrimPrefix(key, commandKeyPrefix), value)
+				case strings.HasPrefix(key, budgetKeyPrefix):
+					err = setBudgetConfig(targetCfg, strings.TrimPrefix(key, budgetKeyPrefix), value)
+				default:
+					err = fmt.Errorf("unknown configuration key: %s", key)
+				}
+				if err != nil {
+					return err
+				}
 			}
 
 			var err error
@@ -136,3 +168,87 @@ func (c *ConfigCommandFactory) newSetCommand(t *i18n.Translations, cfg *config.C
 		},
 	}
 }
+
+// setCommandAIConfig sets one field ("<command>.<model|temperature|max_tokens>") of the per-command AI settings
+func setCommandAIConfig(cfg *config.Config, path, value string) error {
+	command, field, _ := strings.Cut(path, ".")
+	if !slices.Contains(config.ConfigurableCommands(), command) {
+		return fmt.Errorf("unknown command: %s (valid: %s)", command, strings.Join(config.ConfigurableCommands(), ", "))
+	}
+
+	settings := cfg.AIConfig.CommandConfig(command)
+	switch field {
+	case "model":
+		settings.Model = config.Model(value)
+	case "temperature":
+		temperature, err := strconv.ParseFloat(value, 32)
+		if err != nil || temperature < 0 || temperature > 2 {
+			return fmt.Errorf("invalid temperature (must be 0-2): %s", value)
+		}
+		settings.Temperature = float32(temperature)
+	case "max_tokens", "max-tokens":
+		maxTokens, err := strconv.Atoi(value)
+		if err != nil || maxTokens < 1 {
+			return fmt.Errorf("invalid max tokens (must be a positive number): %s", value)
+		}
+		settings.MaxTokens = maxTokens
+	default:
+		return fmt.Errorf("unknown configuration key: %s%s", commandKeyPrefix, path)
+	}
+
+	if cfg.AIConfig.Commands == nil {
+		cfg.AIConfig.Commands = make(map[string]config.CommandAIConfig)
+	}
+	cfg.AIConfig.Commands[command] = settings
+	return nil
+}
+
+// setBudgetConfig sets one budget field: daily, monthly, repo_daily, repo_monthly, policy or commands.<command>.
+// A limit of 0 removes it.
+func setBudgetConfig(cfg *config.Config, path, value string) error {
+	if path == "policy" {
+		if !slices.Contains(config.BudgetPolicies(), value) {
+			return fmt.Errorf("invalid budget policy (valid: %s): %s", strings.Join(config.BudgetPolicies(), ", "), value)
+		}
+		cfg.AIConfig.Budget.Policy = value
+		return nil
+	}
+
+	limit, err := strconv.ParseFloat(value, 64)
+	if err != nil || limit < 0 {
+		return fmt.Errorf("invalid budget (must be a non-negative amount in USD): %s", value)
+	}
+
+	budget := &cfg.AIConfig.Budget
+	switch path {
+	case "daily":
+		if limit == 0 {
+			cfg.AIConfig.BudgetDaily = nil
+		} else {
+			cfg.AIConfig.BudgetDaily = &limit
+		}
+	case "monthly":
+		budget.Monthly = limit
+	case "repo_daily", "repo-daily":
+		budget.RepoDaily = limit
+	case "repo_monthly", "repo-monthly":
+		budget.RepoMonthly = limit
+	default:
+		command, ok := strings.CutPrefix(path, "commands.")
+		if !ok {
+			return fmt.Errorf("unknown configuration key: %s%s", budgetKeyPrefix, path)
+		}
+		if !slices.Contains(config.ConfigurableCommands(), command) {
+			return fmt.Errorf("unknown command: %s (valid: %s)", command, strings.Join(config.ConfigurableCommands(), ", "))
+		}
+		if limit == 0 {
+			delete(budget.Commands, command)
+			return nil
+		}
+		if budget.Commands == nil {
+			budget.Commands = make(map[string]float64)
+		}
+		budget.Commands[command] = limit
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomas-vilte/matecommit/internal/config"
+	"github.com/urfave/cli/v3"
+)
+
+func TestSetCommand_CommandAIConfig(t *testing.T) {
+	run := func(t *testing.T, cfg *config.Config, key, value string) error {
+		t.Helper()
+		_, translations, _, cleanup := setupConfigTest(t)
+		defer cleanup()
+		cmd := NewConfigCommandFactory().newSetCommand(translations, cfg)
+		app := &cli.Command{Commands: []*cli.Command{cmd}}
+		return app.Run(context.Background(), []string{"config", "set", "--global", key, value})
+	}
+
+	t.Run("should set the model, temperature and max tokens of a command", func(t *testing.T) {
+		// Arrange
+		cfg, _, _, cleanup := setupConfigTest(t)
+		defer cleanup()
+
+		// Act
+		require.NoError(t, run(t, cfg, "ai_config.commands.release.model", "gpt-4o"))
+		require.NoError(t, run(t, cfg, "ai_config.commands.release.temperature", "0.2"))
+		require.NoError(t, run(t, cfg, "ai_config.commands.suggest.max_tokens", "800"))
+
+		// Assert
+		saved, err := config.LoadConfig(cfg.PathFile)
+		require.NoError(t, err)
+		assert.Equal(t, config.CommandAIConfig{Model: "gpt-4o", Temperature: 0.2}, saved.AIConfig.CommandConfig(config.CommandRelease))
+		assert.Equal(t, 800, saved.AIConfig.CommandConfig(config.CommandSuggest).MaxTokens)
+	})
+
+	t.Run("should reject unknown commands and invalid values", func(t *testing.T) {
+		// Arrange
+		cfg, _, _, cleanup := setupConfigTest(t)
+		defer cleanup()
+
+		// Act & Assert
+		assert.ErrorContains(t, run(t, cfg, "ai_config.commands.deploy.model", "gpt-4o"), "unknown command: deploy")
+		assert.ErrorContains(t, run(t, cfg, "ai_config.commands.issue.temperature", "5"), "invalid temperature")
+		assert.ErrorContains(t, run(t, cfg, "ai_config.commands.issue.max_tokens", "-1"), "invalid max tokens")
+		assert.ErrorContains(t, run(t, cfg, "ai_config.commands.issue.top_p", "1"), "unknown configuration key")
+		assert.Empty(t, cfg.AIConfig.Commands)
+	})
+}
+
+func TestSetCommand_TokenCounting(t *testing.T) {
+	t.Run("should set a valid mode and reject unknown ones", func(t *testing.T) {
+		// Arrange
+		cfg, translations, _, cleanup := setupConfigTest(t)
+		defer cleanup()
+		app := &cli.Command{Commands: []*cli.Command{NewConfigCommandFactory().newSetCommand(translations, cfg)}}
+
+		// Act
+		err := app.Run(context.Background(), []string{"config", "set", "--global", "ai_config.token_counting", "estimate"})
+		invalidErr := app.Run(context.Background(), []string{"config", "set", "--global", "token_counting", "guess"})
+
+		// Assert
+		require.NoError(t, err)
+		saved, loadErr := config.LoadConfig(cfg.PathFile)
+		require.NoError(t, loadErr)
+		assert.Equal(t, config.TokenCountingEstimate, saved.AIConfig.TokenCountingMode())
+		assert.ErrorContains(t, invalidErr, "invalid token counting mode")
+	})
+}
+
+func TestSetCommand_Budget(t *testing.T) {
+	run := func(t *testing.T, cfg *config.Config, key, value string) error {
+		t.Helper()
+		_, translations, _, cleanup := setupConfigTest(t)
+		defer cleanup()
+		app := &cli.Command{Commands: []*cli.Command{NewConfigCommandFactory().newSetCommand(translations, cfg)}}
+		return app.Run(context.Background(), []string{"config", "set", "--global", key, value})
+	}
+
+	t.Run("should set caps and the policy", func(t *testing.T) {
+		// Arrange
+		cfg, _, _, cleanup := setupConfigTest(t)
+		defer cleanup()
+
+		// Act
+		require.NoError(t, run(t, cfg, "ai_config.budget.monthly", "20"))
+		require.NoError(t, run(t, cfg, "ai_config.budget.daily", "1.5"))
+		require.NoError(t, run(t, cfg, "ai_config.budget.commands.release", "1"))
+		require.NoError(t, run(t, cfg, "budget-policy", "confirm"))
+
+		// Assert
+		saved, err := config.LoadConfig(cfg.PathFile)
+		require.NoError(t, err)
+		assert.Equal(t, 20.0, saved.AIConfig.Budget.Monthly)
+		require.NotNil(t, saved.AIConfig.BudgetDaily)
+		assert.Equal(t, 1.5, *saved.AIConfig.BudgetDaily)
+		assert.Equal(t, map[string]float64{"release": 1}, saved.AIConfig.Budget.Commands)
+		assert.Equal(t, config.BudgetPolicyConfirm, saved.AIConfig.Budget.EffectivePolicy())
+	})
+
+	t.Run("should reject invalid values", func(t *testing.T) {
+		// Arrange
+		cfg, _, _, cleanup := setupConfigTest(t)
+		defer cleanup()
+
+		// Act & Assert
+		assert.ErrorContains(t, run(t, cfg, "ai_config.budget.monthly", "-1"), "invalid budget")
+		assert.ErrorContains(t, run(t, cfg, "ai_config.budget.policy", "ignore"), "invalid budget policy")
+		assert.ErrorContains(t, run(t, cfg, "ai_config.budget.commands.deploy", "1"), "unknown command: deploy")
+		assert.ErrorContains(t, run(t, cfg, "ai_config.budget.weekly", "1"), "unknown configuration key")
+	})
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/thomas-vilte/matecommit/internal/config"
 	"github.com/thomas-vilte/matecommit/internal/i18n"
@@ -14,19 +15,30 @@ func (c *ConfigCommandFactory) newShowCommand(t *i18n.Translations, cfg *config.
 		Name:  "show",
 		Usage: t.GetMessage("config_show_usage", 0, nil),
 		Action: func(ctx context.Context, command *cli.Command) error {
+			// The repository config is listed apart below, so cfg is shown without it
+			globalCfg, err := config.GlobalOnly(cfg)
+			if err != nil {
+				return err
+			}
+
 			fmt.Println(t.GetMessage("config_local.global_config_header", 0, nil))
 			fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━\n")
 
-			fmt.Printf("%s\n", t.GetMessage("language_label", 0, struct{ Lang string }{cfg.Language}))
+			fmt.Printf("%s\n", t.GetMessage("language_label", 0, struct{ Lang string }{globalCfg.Language}))
 
-			fmt.Printf("%s\n", t.GetMessage("emojis_label", 0, struct{ Emoji bool }{cfg.UseEmoji}))
+			fmt.Printf("%s\n", t.GetMessage("emojis_label", 0, struct{ Emoji bool }{globalCfg.UseEmoji}))
 
-			hasGemini := false
-			if providerCfg, exists := cfg.AIProviders["gemini"]; exists && providerCfg.APIKey != "" {
-				hasGemini = true
+			activeAI := globalCfg.AIConfig.ActiveAI
+			if activeAI == "" {
+				activeAI = config.AIGemini
 			}
 
-			if !hasGemini {
+			hasAPIKey := false
+			if providerCfg, exists := globalCfg.AIProviders[string(activeAI)]; exists && providerCfg.APIKey != "" {
+				hasAPIKey = true
+			}
+
+			if !hasAPIKey {
 				fmt.Println(t.GetMessage("api.key_not_set", 0, nil))
 				fmt.Println(t.GetMessage("api.key_tip", 0, nil))
 				fmt.Println(t.GetMessage("api.key_config_command", 0, nil))
@@ -34,10 +46,10 @@ func (c *ConfigCommandFactory) newShowCommand(t *i18n.Translations, cfg *config.
 				fmt.Println(t.GetMessage("api.key_set", 0, nil))
 			}
 
-			if cfg.UseTicket {
-				fmt.Printf("%s\n", t.GetMessage("config_models.ticket_service_enabled", 0, struct{ Service string }{cfg.ActiveTicketService}))
-				if cfg.ActiveTicketService == "jira" {
-					jiraCfg := cfg.TicketProviders["jira"]
+			if globalCfg.UseTicket {
+				fmt.Printf("%s\n", t.GetMessage("config_models.ticket_service_enabled", 0, struct{ Service string }{globalCfg.ActiveTicketService}))
+				if globalCfg.ActiveTicketService == "jira" {
+					jiraCfg := globalCfg.TicketProviders["jira"]
 					fmt.Printf("%s\n", t.GetMessage("config_models.jira_config_label", 0, struct {
 						BaseURL string
 						Email   string
@@ -50,31 +62,52 @@ func (c *ConfigCommandFactory) newShowCommand(t *i18n.Translations, cfg *config.
 				fmt.Println(t.GetMessage("config_models.ticket_service_disabled", 0, nil))
 			}
 
-			fmt.Printf("%s\n", t.GetMessage("config_models.active_ai_label", 0, struct{ IA config.AI }{cfg.AIConfig.ActiveAI}))
+			fmt.Printf("%s\n", t.GetMessage("config_models.active_ai_label", 0, struct{ IA config.AI }{globalCfg.AIConfig.ActiveAI}))
 
-			if len(cfg.AIConfig.Models) > 0 {
+			if len(globalCfg.AIConfig.Models) > 0 {
 				fmt.Println(t.GetMessage("config_models.ai_models_label", 0, nil))
-				for ai, model := range cfg.AIConfig.Models {
+				for ai, model := range globalCfg.AIConfig.Models {
 					fmt.Printf("- %s: %s\n", ai, model)
 				}
 			} else {
 				fmt.Println(t.GetMessage("config_models.no_ai_models_configured", 0, nil))
 			}
 
-			if cfg.GitFallback.UserName != "" || cfg.GitFallback.UserEmail != "" {
+			if len(globalCfg.AIConfig.Fallback) > 0 {
+				fmt.Println(t.GetMessage("config_models.ai_fallback_label", 0, nil))
+				for i, fallback := range globalCfg.AIConfig.Fallback {
+					model := fallback.Model
+					if model == "" {
+						model = config.DefaultModelForAI(fallback.Provider)
+					}
+					fmt.Printf("%d. %s: %s\n", i+1, fallback.Provider, model)
+				}
+			}
+
+			if len(globalCfg.AIConfig.Commands) > 0 {
+				fmt.Println(t.GetMessage("config_models.ai_commands_label", 0, nil))
+				for _, command := range config.ConfigurableCommands() {
+					settings, ok := globalCfg.AIConfig.Commands[command]
+					if !ok {
+						continue
+					}
+					fmt.Printf("- %s: %s\n", command, formatCommandAIConfig(settings))
+				}
+			}
+
+			if globalCfg.GitFallback.UserName != "" || globalCfg.GitFallback.UserEmail != "" {
 				fmt.Println()
 				fmt.Println(t.GetMessage("config_git.fallback_header", 0, nil))
-				if cfg.GitFallback.UserName != "" {
-					fmt.Printf("%s\n", t.GetMessage("config_git.fallback_name", 0, struct{ Name string }{cfg.GitFallback.UserName}))
+				if globalCfg.GitFallback.UserName != "" {
+					fmt.Printf("%s\n", t.GetMessage("config_git.fallback_name", 0, struct{ Name string }{globalCfg.GitFallback.UserName}))
 				}
-				if cfg.GitFallback.UserEmail != "" {
-					fmt.Printf("%s\n", t.GetMessage("config_git.fallback_email", 0, struct{ Email string }{cfg.GitFallback.UserEmail}))
+				if globalCfg.GitFallback.UserEmail != "" {
+					fmt.Printf("%s\n", t.GetMessage("config_git.fallback_email", 0, struct{ Email string }{globalCfg.GitFallback.UserEmail}))
 				}
 			}
 
-			localPath := config.GetRepoConfigPath()
-			if localPath != "" {
-				localCfg, err := config.LoadConfig(localPath)
+			if cfg.LocalPathFile != "" {
+				localCfg, err := config.LoadConfig(cfg.LocalPathFile)
 				if err == nil {
 					fmt.Println()
 					fmt.Println(t.GetMessage("config_local.local_config_header", 0, nil))
@@ -100,3 +133,18 @@ func (c *ConfigCommandFactory) newShowCommand(t *i18n.Translations, cfg *config.
 		},
 	}
 }
+
+// formatCommandAIConfig lists the fields set for a command, e.g. "model=gpt-4o, temperature=0.2"
+func formatCommandAIConfig(settings config.CommandAIConfig) string {
+	var parts []string
+	if settings.Model != "" {
+		parts = append(parts, fmt.Sprintf("model=%s", settings.Model))
+	}
+	if settings.Temperature > 0 {
+		parts = append(parts, fmt.Sprintf("temperature=%g", settings.Temperature))
+	}
+	if settings.MaxTokens > 0 {
+		parts = append(parts, fmt.Sprintf("max_tokens=%d", settings.MaxTokens))
+	}
+	return strings.Join(parts, ", ")
+}
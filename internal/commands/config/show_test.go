@@ -114,4 +114,40 @@ func TestShowCommand(t *testing.T) {
 		assert.Contains(t, output, "gemini: gemini-1.5-flash")
 		assert.Contains(t, output, "openai: gpt-4o")
 	})
+	t.Run("should display the per-command AI settings", func(t *testing.T) {
+		// Arrange
+		cfg, translations, _, cleanup := setupConfigTest(t)
+		cfg.AIConfig = config.AIConfig{
+			ActiveAI: config.AIOpenAI,
+			Commands: map[string]config.CommandAIConfig{
+				config.CommandSuggest: {Model: config.ModelGPTV4oMini},
+				config.CommandIssue:   {Temperature: 0.7, MaxTokens: 2000},
+			},
+		}
+		assert.NoError(t, config.SaveConfig(cfg))
+		defer cleanup()
+
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		cmd := NewConfigCommandFactory().newShowCommand(translations, cfg)
+		app := &cli.Command{Commands: []*cli.Command{cmd}}
+
+		// Act
+		err := app.Run(context.Background(), []string{"config", "show"})
+
+		assert.NoError(t, w.Close())
+		os.Stdout = oldStdout
+		var buf bytes.Buffer
+		_, copyErr := io.Copy(&buf, r)
+		assert.NoError(t, copyErr)
+		output := buf.String()
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Contains(t, output, "Per-command AI settings:")
+		assert.Contains(t, output, "- suggest: model=gpt-4o-mini")
+		assert.Contains(t, output, "- issue: temperature=0.7, max_tokens=2000")
+	})
 }
@@ -0,0 +1,248 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/thomas-vilte/matecommit/internal/ai"
+	"github.com/thomas-vilte/matecommit/internal/config"
+	"github.com/thomas-vilte/matecommit/internal/i18n"
+	"github.com/thomas-vilte/matecommit/internal/ui"
+	"github.com/urfave/cli/v3"
+)
+
+type PromptsCommand struct{}
+
+func NewPromptsCommand() *PromptsCommand {
+	return &PromptsCommand{}
+}
+
+func (c *PromptsCommand) CreateCommand(t *i18n.Translations, cfg *config.Config) *cli.Command {
+	return &cli.Command{
+		Name:  "prompts",
+		Usage: t.GetMessage("prompts.usage", 0, nil),
+		Commands: []*cli.Command{
+			{
+				Name:      "show",
+				Usage:     t.GetMessage("prompts.show_usage", 0, nil),
+				ArgsUsage: "[" + strings.Join(ai.PromptTemplateNames(), "|") + "]",
+				Flags: []cli.Flag{
+					newLangFlag(t),
+					&cli.BoolFlag{
+						Name:  "render",
+						Usage: t.GetMessage("prompts.render_flag", 0, nil),
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					lang := promptLang(cmd, cfg)
+					if cmd.Args().Len() == 0 {
+						return c.listTemplates(t, lang)
+					}
+					return c.showTemplate(t, cmd.Args().First(), lang, cmd.Bool("render"))
+				},
+			},
+			{
+				Name:      "export",
+				Usage:     t.GetMessage("prompts.export_usage", 0, nil),
+				ArgsUsage: "[" + strings.Join(ai.PromptTemplateNames(), "|") + "]",
+				Flags: []cli.Flag{
+					newLangFlag(t),
+					&cli.BoolFlag{
+						Name:  "global",
+						Usage: t.GetMessage("prompts.global_flag", 0, nil),
+					},
+					&cli.BoolFlag{
+						Name:  "force",
+						Usage: t.GetMessage("prompts.force_flag", 0, nil),
+					},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					names := ai.PromptTemplateNames()
+					if cmd.Args().Len() > 0 {
+						names = cmd.Args().Slice()
+					}
+					source := ai.PromptSourceRepo
+					if cmd.Bool("global") {
+						source = ai.PromptSourceUser
+					}
+					return c.exportTemplates(t, names, promptLang(cmd, cfg), source, cmd.Bool("force"))
+				},
+			},
+			{
+				Name:      "validate",
+				Usage:     t.GetMessage("prompts.validate_usage", 0, nil),
+				ArgsUsage: "[" + strings.Join(ai.PromptTemplateNames(), "|") + "]",
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return c.validateOverrides(t, cmd.Args().Slice())
+				},
+			},
+		},
+	}
+}
+
+func newLangFlag(t *i18n.Translations) cli.Flag {
+	return &cli.StringFlag{
+		Name:  "lang",
+		Usage: t.GetMessage("prompts.lang_flag", 0, nil),
+	}
+}
+
+// promptLang returns the language of the --lang flag, or the configured one
+func promptLang(cmd *cli.Command, cfg *config.Config) string {
+	if lang := cmd.String("lang"); lang != "" {
+		return lang
+	}
+	return cfg.Language
+}
+
+func (c *PromptsCommand) listTemplates(t *i18n.Translations, lang string) error {
+	ui.PrintSectionBanner(t.GetMessage("prompts.list_header", 0, struct{ Lang string }{lang}))
+	for _, name := range ai.PromptTemplateNames() {
+		tmpl, err := ai.LoadPromptTemplate(name, lang)
+		if err != nil {
+			return err
+		}
+		ui.PrintKeyValue(name, describeSource(t, tmpl))
+	}
+	return nil
+}
+
+func (c *PromptsCommand) showTemplate(t *i18n.Translations, name, lang string, render bool) error {
+	tmpl, err := loadTemplate(t, name, lang)
+	if err != nil {
+		return err
+	}
+
+	ui.PrintSectionBanner(fmt.Sprintf("%s (%s)", tmpl.Name, tmpl.Lang))
+	ui.PrintKeyValue(t.GetMessage("prompts.source_label", 0, nil), describeSource(t, tmpl))
+	fmt.Println()
+
+	if !render {
+		fmt.Println(tmpl.Text)
+		return nil
+	}
+	rendered, err := ai.ValidatePromptTemplate(tmpl)
+	if err != nil {
+		return fmt.Errorf(t.GetMessage("prompts.error_render", 0, struct{ Name string }{tmpl.Name})+": %w", err)
+	}
+	fmt.Println(rendered)
+	return nil
+}
+
+// exportTemplates writes the built-in templates as override files to start customizing from
+func (c *PromptsCommand) exportTemplates(t *i18n.Translations, names []string, lang, source string, force bool) error {
+	dir := ai.PromptDir(source)
+	if dir == "" {
+		return fmt.Errorf("%s", t.GetMessage("prompts.error_no_repo", 0, nil))
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf(t.GetMessage("prompts.error_export", 0, nil)+": %w", err)
+	}
+
+	for _, name := range names {
+		builtin, ok := ai.BuiltinPromptTemplate(name, lang)
+		if !ok {
+			return unknownTemplateError(t, name)
+		}
+		path := filepath.Join(dir, ai.PromptTemplateFile(name, lang))
+		if _, err := os.Stat(path); err == nil && !force {
+			ui.PrintWarning(t.GetMessage("prompts.exists", 0, struct{ Path string }{path}))
+			continue
+		}
+		if err := os.WriteFile(path, []byte(builtin), 0644); err != nil {
+			return fmt.Errorf(t.GetMessage("prompts.error_export", 0, nil)+": %w", err)
+		}
+		ui.PrintSuccess(os.Stdout, t.GetMessage("prompts.exported", 0, struct{ Path string }{path}))
+	}
+	return nil
+}
+
+// validateOverrides renders every override file of the repository and the user against sample data
+func (c *PromptsCommand) validateOverrides(t *i18n.Translations, names []string) error {
+	for _, name := range names {
+		if !slices.Contains(ai.PromptTemplateNames(), name) {
+			return unknownTemplateError(t, name)
+		}
+	}
+
+	checked, failed := 0, 0
+	for _, source := range []string{ai.PromptSourceRepo, ai.PromptSourceUser} {
+		dir := ai.PromptDir(source)
+		if dir == "" {
+			continue
+		}
+		files, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+		if err != nil {
+			return err
+		}
+		for _, path := range files {
+			name, lang, ok := parseTemplateFile(filepath.Base(path))
+			if len(names) > 0 && !slices.Contains(names, name) {
+				continue
+			}
+			checked++
+
+			if !ok || !slices.Contains(ai.PromptTemplateNames(), name) {
+				failed++
+				ui.PrintError(os.Stdout, t.GetMessage("prompts.invalid", 0, struct{ Path, Error string }{
+					path, t.GetMessage("prompts.unknown_file", 0, struct{ Names string }{strings.Join(ai.PromptTemplateNames(), ", ")}),
+				}))
+				continue
+			}
+
+			text, err := os.ReadFile(path)
+			if err == nil {
+				_, err = ai.ValidatePromptTemplate(ai.PromptTemplate{Name: name, Lang: lang, Source: source, Path: path, Text: string(text)})
+			}
+			if err != nil {
+				failed++
+				ui.PrintError(os.Stdout, t.GetMessage("prompts.invalid", 0, struct{ Path, Error string }{path, err.Error()}))
+				continue
+			}
+			ui.PrintSuccess(os.Stdout, t.GetMessage("prompts.valid", 0, struct{ Path string }{path}))
+		}
+	}
+
+	if checked == 0 {
+		ui.PrintInfo(t.GetMessage("prompts.no_overrides", 0, nil))
+		return nil
+	}
+	if failed > 0 {
+		return fmt.Errorf("%s", t.GetMessage("prompts.validation_failed", 0, struct{ Count int }{failed}))
+	}
+	return nil
+}
+
+func loadTemplate(t *i18n.Translations, name, lang string) (ai.PromptTemplate, error) {
+	if !slices.Contains(ai.PromptTemplateNames(), name) {
+		return ai.PromptTemplate{}, unknownTemplateError(t, name)
+	}
+	return ai.LoadPromptTemplate(name, lang)
+}
+
+func unknownTemplateError(t *i18n.Translations, name string) error {
+	return fmt.Errorf("%s", t.GetMessage("prompts.error_unknown", 0, struct{ Name, Names string }{
+		name, strings.Join(ai.PromptTemplateNames(), ", "),
+	}))
+}
+
+func describeSource(t *i18n.Translations, tmpl ai.PromptTemplate) string {
+	if tmpl.Source == ai.PromptSourceBuiltin {
+		return t.GetMessage("prompts.source_builtin", 0, nil)
+	}
+	return t.GetMessage("prompts.source_"+tmpl.Source, 0, struct{ Path string }{tmpl.Path})
+}
+
+// parseTemplateFile splits an override file name, <name>.<lang>.tmpl, in its parts
+func parseTemplateFile(file string) (name, lang string, ok bool) {
+	base := strings.TrimSuffix(file, ".tmpl")
+	dot := strings.LastIndex(base, ".")
+	if dot <= 0 || dot == len(base)-1 {
+		return base, "", false
+	}
+	return base[:dot], base[dot+1:], true
+}
@@ -0,0 +1,107 @@
+package prompts
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomas-vilte/matecommit/internal/config"
+	"github.com/thomas-vilte/matecommit/internal/i18n"
+	"github.com/urfave/cli/v3"
+)
+
+// setupPromptsTest runs the test inside a fresh repository with an isolated HOME
+func setupPromptsTest(t *testing.T) (repoDir string, run func(args ...string) (string, error)) {
+	t.Helper()
+	translations, err := i18n.NewTranslations("en", "../../i18n/locales")
+	require.NoError(t, err)
+
+	t.Setenv("HOME", t.TempDir())
+	repoDir = t.TempDir()
+	require.NoError(t, exec.Command("git", "init", "-q", repoDir).Run())
+	t.Chdir(repoDir)
+	cmd := NewPromptsCommand().CreateCommand(translations, &config.Config{Language: "en"})
+
+	run = func(args ...string) (string, error) {
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		app := &cli.Command{Commands: []*cli.Command{cmd}}
+		runErr := app.Run(context.Background(), append([]string{"matecommit", "prompts"}, args...))
+
+		require.NoError(t, w.Close())
+		os.Stdout = oldStdout
+		var buf bytes.Buffer
+		_, copyErr := io.Copy(&buf, r)
+		require.NoError(t, copyErr)
+		return buf.String(), runErr
+	}
+	return repoDir, run
+}
+
+func TestPromptsCommand(t *testing.T) {
+	t.Run("export writes the built-in template to the repository", func(t *testing.T) {
+		// Arrange
+		repoDir, run := setupPromptsTest(t)
+
+		// Act
+		_, err := run("export", "--lang", "es", "release")
+
+		// Assert
+		require.NoError(t, err)
+		data, readErr := os.ReadFile(filepath.Join(repoDir, ".matecommit", "prompts", "release.es.tmpl"))
+		require.NoError(t, readErr)
+		assert.Contains(t, string(data), "{{.Changelog}}")
+	})
+
+	t.Run("show reports the override and renders it", func(t *testing.T) {
+		// Arrange
+		repoDir, run := setupPromptsTest(t)
+		dir := filepath.Join(repoDir, ".matecommit", "prompts")
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "suggest.en.tmpl"), []byte("Mention the migration ID.\n{{.Files}}"), 0644))
+
+		// Act
+		list, listErr := run("show")
+		rendered, showErr := run("show", "--render", "suggest")
+
+		// Assert
+		require.NoError(t, listErr)
+		require.NoError(t, showErr)
+		assert.Contains(t, list, "repository (")
+		assert.Contains(t, list, "built-in")
+		assert.Contains(t, rendered, "Mention the migration ID.\n- internal/auth/login.go")
+	})
+
+	t.Run("validate reports broken overrides", func(t *testing.T) {
+		// Arrange
+		repoDir, run := setupPromptsTest(t)
+		dir := filepath.Join(repoDir, ".matecommit", "prompts")
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "issue.en.tmpl"), []byte("{{.IssueInfo}}"), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "release.en.tmpl"), []byte("{{.MigrationID}}"), 0644))
+
+		// Act
+		output, err := run("validate")
+
+		// Assert
+		assert.ErrorContains(t, err, "1 template override(s) are invalid")
+		assert.Contains(t, output, "issue.en.tmpl is valid")
+		assert.Contains(t, output, "MigrationID")
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		_, run := setupPromptsTest(t)
+
+		_, err := run("show", "deploy")
+
+		assert.ErrorContains(t, err, "unknown template deploy")
+	})
+}
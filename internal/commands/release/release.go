@@ -6,7 +6,6 @@ import (
 	"os"
 
 	"github.com/thomas-vilte/matecommit/internal/ai"
-	"github.com/thomas-vilte/matecommit/internal/ai/gemini"
 	cfg "github.com/thomas-vilte/matecommit/internal/config"
 	"github.com/thomas-vilte/matecommit/internal/git"
 	"github.com/thomas-vilte/matecommit/internal/i18n"
@@ -115,17 +114,16 @@ func (r *ReleaseCommandFactory) createReleaseService(ctx context.Context, mainPa
 	}
 
 	var notesGen ai.ReleaseNotesGenerator
-	if r.config.AIConfig.ActiveAI == "gemini" {
+	if r.config.AIConfig.ActiveAI != "" {
 		owner, repo, _, err := r.gitService.GetRepoInfo(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("error retrieving information from repository: %w", err)
 		}
 
-		gen, err := gemini.NewReleaseNotesGenerator(ctx, r.config, nil, owner, repo)
+		notesGen, err = r.newNotesGenerator(ctx, owner, repo)
 		if err != nil {
 			return nil, err
 		}
-		notesGen = gen
 	}
 
 	return services.NewReleaseService(
@@ -135,3 +133,17 @@ func (r *ReleaseCommandFactory) createReleaseService(ctx context.Context, mainPa
 		services.WithReleaseConfig(r.config),
 	), nil
 }
+
+// newNotesGenerator builds the release notes generator for the active AI provider.
+func (r *ReleaseCommandFactory) newNotesGenerator(ctx context.Context, owner, repo string) (ai.ReleaseNotesGenerator, error) {
+	client, err := ai.NewLLMClient(ctx, r.config, r.config.AIConfig.ActiveAI)
+	if err != nil {
+		return nil, err
+	}
+
+	gen, err := ai.NewReleaseNotesService(client, r.config, nil, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	return gen, nil
+}
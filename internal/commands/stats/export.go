@@ -0,0 +1,85 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/thomas-vilte/matecommit/internal/i18n"
+	"github.com/thomas-vilte/matecommit/internal/services/cost"
+	"github.com/urfave/cli/v3"
+)
+
+// newExportCommand writes the history with its aggregates in a machine readable format.
+// The layout is documented in cost.WriteExport.
+func (c *StatsCommand) newExportCommand(t *i18n.Translations) *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: t.GetMessage("stats.export_usage", 0, nil),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "format",
+				Value: cost.ExportFormatCSV,
+				Usage: t.GetMessage("stats.export_format_flag", 0, struct{ Formats string }{strings.Join(cost.ExportFormats(), ", ")}),
+			},
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: t.GetMessage("stats.since_flag", 0, nil),
+			},
+			&cli.StringFlag{
+				Name:  "until",
+				Usage: t.GetMessage("stats.until_flag", 0, nil),
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   t.GetMessage("stats.export_output_flag", 0, nil),
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			format := cmd.String("format")
+			if !slices.Contains(cost.ExportFormats(), format) {
+				return errors.New(t.GetMessage("stats.error_invalid_format", 0, struct {
+					Value string
+					Valid string
+				}{format, strings.Join(cost.ExportFormats(), ", ")}))
+			}
+			// The whole history is exported unless a date is given
+			period, err := parsePeriod(t, cmd.String("since"), cmd.String("until"), cost.Period{})
+			if err != nil {
+				return err
+			}
+
+			manager, err := cost.NewManager(0)
+			if err != nil {
+				return fmt.Errorf(t.GetMessage("stats.error_init", 0, nil)+": %w", err)
+			}
+
+			output := cmd.String("output")
+			if output == "" {
+				return c.export(manager, os.Stdout, format, period)
+			}
+			file, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf(t.GetMessage("stats.error_export", 0, nil)+": %w", err)
+			}
+			if err := c.export(manager, file, format, period); err != nil {
+				_ = file.Close()
+				return err
+			}
+			return file.Close()
+		},
+	}
+}
+
+func (c *StatsCommand) export(manager *cost.Manager, w io.Writer, format string, period cost.Period) error {
+	export, err := manager.Export(period)
+	if err != nil {
+		return err
+	}
+	return cost.WriteExport(w, format, export)
+}
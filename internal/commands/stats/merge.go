@@ -0,0 +1,88 @@
+package stats
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fatih/color"
+	"github.com/thomas-vilte/matecommit/internal/i18n"
+	"github.com/thomas-vilte/matecommit/internal/services/cost"
+	"github.com/urfave/cli/v3"
+)
+
+// newMergeCommand shows the usage of a team from the histories or exports of its developers.
+func (c *StatsCommand) newMergeCommand(t *i18n.Translations) *cli.Command {
+	return &cli.Command{
+		Name:      "merge",
+		Usage:     t.GetMessage("stats.merge_usage", 0, nil),
+		ArgsUsage: "<files...>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "from-dir",
+				Usage: t.GetMessage("stats.from_dir_flag", 0, nil),
+			},
+			&cli.StringFlag{
+				Name:  "by",
+				Usage: t.GetMessage("stats.by_flag", 0, nil),
+			},
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: t.GetMessage("stats.since_flag", 0, nil),
+			},
+			&cli.StringFlag{
+				Name:  "until",
+				Usage: t.GetMessage("stats.until_flag", 0, nil),
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return c.showTeamStats(t, cmd.Args().Slice(), cmd.String("from-dir"), cmd.String("by"), cmd.String("since"), cmd.String("until"))
+		},
+	}
+}
+
+// showTeamStats merges the given files and those under dir, then prints the usage grouped by user
+// unless --by says otherwise, with the cache hit rate and the forecast of the team.
+func (c *StatsCommand) showTeamStats(t *i18n.Translations, files []string, dir, by, since, until string) error {
+	by, period, err := parseBreakdownFlags(t, by, since, until, cost.GroupByUser)
+	if err != nil {
+		return err
+	}
+
+	if dir != "" {
+		found, err := cost.HistoryFiles(dir)
+		if err != nil {
+			return err
+		}
+		files = append(files, found...)
+	}
+	if len(files) == 0 {
+		return errors.New(t.GetMessage("stats.error_no_histories", 0, nil))
+	}
+
+	manager, summary, err := cost.NewMergedManager(files)
+	if err != nil {
+		return err
+	}
+
+	records := 0
+	for _, source := range summary.Sources {
+		records += source.Records
+	}
+
+	cyan := color.New(color.FgCyan, color.Bold)
+	dim := color.New(color.FgHiBlack)
+
+	_, _ = cyan.Printf("\n👥 %s\n", t.GetMessage("stats.team_title", 0, nil))
+	_, _ = dim.Println(t.GetMessage("stats.team_sources", 0, struct {
+		Files      int
+		Records    int
+		Duplicates int
+	}{len(summary.Sources), records, summary.Duplicates}))
+
+	if err := c.showGroupedBreakdown(manager, t, by, period); err != nil {
+		return err
+	}
+	c.showCacheHitRate(manager, t)
+	c.showForecast(manager, t)
+	return nil
+}
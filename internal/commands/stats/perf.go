@@ -0,0 +1,145 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/thomas-vilte/matecommit/internal/i18n"
+	"github.com/thomas-vilte/matecommit/internal/services/cost"
+	"github.com/urfave/cli/v3"
+)
+
+// newPerfCommand shows the latency and reliability of the AI calls.
+func (c *StatsCommand) newPerfCommand(t *i18n.Translations) *cli.Command {
+	return &cli.Command{
+		Name:  "perf",
+		Usage: t.GetMessage("stats.perf_usage", 0, nil),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: t.GetMessage("stats.since_flag", 0, nil),
+			},
+			&cli.StringFlag{
+				Name:  "until",
+				Usage: t.GetMessage("stats.until_flag", 0, nil),
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			period, err := parsePeriod(t, cmd.String("since"), cmd.String("until"), cost.MonthOf(time.Now()))
+			if err != nil {
+				return err
+			}
+
+			manager, err := cost.NewManager(0)
+			if err != nil {
+				return fmt.Errorf(t.GetMessage("stats.error_init", 0, nil)+": %w", err)
+			}
+			return c.showPerfStats(manager, t, period)
+		},
+	}
+}
+
+// showPerfStats prints the latency percentiles, errors, retries, cache latency and throughput
+// per command and model.
+func (c *StatsCommand) showPerfStats(manager *cost.Manager, t *i18n.Translations, period cost.Period) error {
+	report, err := manager.GetPerfStats(period)
+	if err != nil {
+		return err
+	}
+
+	if len(report.Groups) == 0 {
+		fmt.Printf("\n%s\n\n", t.GetMessage("stats.no_activity", 0, nil))
+		return nil
+	}
+
+	cyan := color.New(color.FgCyan, color.Bold)
+	yellow := color.New(color.FgYellow)
+	red := color.New(color.FgRed)
+	dim := color.New(color.FgHiBlack)
+
+	_, _ = cyan.Printf("\n⏱️  %s - %s\n", t.GetMessage("stats.perf_title", 0, nil), periodLabel(period))
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println()
+
+	commandWidth := len([]rune(t.GetMessage("stats.column_command", 0, nil)))
+	modelWidth := len([]rune(t.GetMessage("stats.column_model", 0, nil)))
+	for _, group := range report.Groups {
+		commandWidth = max(commandWidth, len([]rune(group.Command)))
+		modelWidth = max(modelWidth, len([]rune(group.Model)))
+	}
+
+	fmt.Printf("%-*s │ %-*s │ %6s │ %6s │ %7s │ %7s │ %7s │ %7s │ %9s │ %6s\n",
+		commandWidth, t.GetMessage("stats.column_command", 0, nil),
+		modelWidth, t.GetMessage("stats.column_model", 0, nil),
+		t.GetMessage("stats.column_calls", 0, nil),
+		t.GetMessage("stats.column_errors", 0, nil),
+		t.GetMessage("stats.column_retries", 0, nil),
+		"p50", "p90", "p99",
+		t.GetMessage("stats.column_cache_p50", 0, nil),
+		"tok/s")
+	fmt.Printf("%s─┼─%s─┼─%s─┼─%s─┼─%s─┼─%s─┼─%s─┼─%s─┼─%s─┼─%s\n",
+		strings.Repeat("─", commandWidth),
+		strings.Repeat("─", modelWidth),
+		strings.Repeat("─", 6),
+		strings.Repeat("─", 6),
+		strings.Repeat("─", 7),
+		strings.Repeat("─", 7),
+		strings.Repeat("─", 7),
+		strings.Repeat("─", 7),
+		strings.Repeat("─", 9),
+		strings.Repeat("─", 6))
+
+	for _, group := range report.Groups {
+		errorsColor := dim
+		if group.Errors > 0 {
+			errorsColor = red
+		}
+		fmt.Printf("%-*s │ %-*s │ %6d │ %s │ %7d │ %s │ %s │ %s │ %s │ %6.1f\n",
+			commandWidth, group.Command,
+			modelWidth, group.Model,
+			group.Calls+group.CacheHits,
+			errorsColor.Sprintf("%6d", group.Errors),
+			group.Retries,
+			yellow.Sprint(formatLatency(group.P50Ms, group.Calls, 7)),
+			yellow.Sprint(formatLatency(group.P90Ms, group.Calls, 7)),
+			yellow.Sprint(formatLatency(group.P99Ms, group.Calls, 7)),
+			dim.Sprint(formatLatency(group.CacheHitP50Ms, group.CacheHits, 9)),
+			group.TokensPerSecond)
+	}
+
+	overall := report.Overall
+	fmt.Println()
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	if attempts := overall.Calls + overall.Errors; attempts > 0 {
+		fmt.Println(t.GetMessage("stats.perf_error_rate", 0, struct {
+			Errors  int
+			Calls   int
+			Rate    string
+			Retries int
+		}{overall.Errors, attempts, fmt.Sprintf("%.1f", float64(overall.Errors)/float64(attempts)*100), overall.Retries}))
+	}
+	if overall.CacheHits > 0 && overall.Calls > 0 {
+		fmt.Println(t.GetMessage("stats.perf_cache_latency", 0, struct {
+			Hit  string
+			Miss string
+		}{formatLatency(overall.CacheHitP50Ms, overall.CacheHits, 0), formatLatency(overall.P50Ms, overall.Calls, 0)}))
+	}
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println()
+
+	return nil
+}
+
+// formatLatency right-aligns a latency in the given width, or a dash when no call measured it.
+func formatLatency(ms int64, calls, width int) string {
+	if calls == 0 {
+		return fmt.Sprintf("%*s", width, "-")
+	}
+	if ms >= 10000 {
+		return fmt.Sprintf("%*s", width, fmt.Sprintf("%.1fs", float64(ms)/1000))
+	}
+	return fmt.Sprintf("%*s", width, fmt.Sprintf("%dms", ms))
+}
@@ -2,7 +2,9 @@ package stats
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -40,8 +42,33 @@ func (c *StatsCommand) CreateCommand(t *i18n.Translations, _ *config.Config) *cl
 				Aliases: []string{"f"},
 				Usage:   t.GetMessage("stats.forecast_flag", 0, nil),
 			},
+			&cli.StringFlag{
+				Name:  "by",
+				Usage: t.GetMessage("stats.by_flag", 0, nil),
+			},
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: t.GetMessage("stats.since_flag", 0, nil),
+			},
+			&cli.StringFlag{
+				Name:  "until",
+				Usage: t.GetMessage("stats.until_flag", 0, nil),
+			},
+			&cli.StringFlag{
+				Name:  "from-dir",
+				Usage: t.GetMessage("stats.from_dir_flag", 0, nil),
+			},
+		},
+		Commands: []*cli.Command{
+			c.newExportCommand(t),
+			c.newMergeCommand(t),
+			c.newPerfCommand(t),
 		},
 		Action: func(ctx context.Context, cmd *cli.Command) error {
+			if dir := cmd.String("from-dir"); dir != "" {
+				return c.showTeamStats(t, nil, dir, cmd.String("by"), cmd.String("since"), cmd.String("until"))
+			}
+
 			manager, err := cost.NewManager(0)
 			if err != nil {
 				return fmt.Errorf(t.GetMessage("stats.error_init", 0, nil)+": %w", err)
@@ -51,6 +78,15 @@ func (c *StatsCommand) CreateCommand(t *i18n.Translations, _ *config.Config) *cl
 			showBreakdown := cmd.Bool("breakdown")
 			showForecast := cmd.Bool("forecast")
 
+			by, since, until := cmd.String("by"), cmd.String("since"), cmd.String("until")
+			if by != "" || since != "" || until != "" {
+				by, period, err := parseBreakdownFlags(t, by, since, until, cost.GroupByCommand)
+				if err != nil {
+					return err
+				}
+				return c.showGroupedBreakdown(manager, t, by, period)
+			}
+
 			if showBreakdown {
 				return c.showBreakdown(manager, t)
 			}
@@ -152,7 +188,6 @@ func (c *StatsCommand) showMonthlyStats(manager *cost.Manager, t *i18n.Translati
 	cyan := color.New(color.FgCyan, color.Bold)
 	yellow := color.New(color.FgYellow)
 	dim := color.New(color.FgHiBlack)
-	green := color.New(color.FgGreen)
 
 	_, _ = cyan.Printf("\n📅 %s\n", t.GetMessage("stats.monthly_title", 0, struct{ Month string }{time.Now().Format("January 2006")}))
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
@@ -212,27 +247,9 @@ func (c *StatsCommand) showMonthlyStats(manager *cost.Manager, t *i18n.Translati
 	fmt.Println()
 
 	if showForecast {
-		forecast, err := manager.GetForecast()
-		if err == nil {
-			_, _ = cyan.Println(t.GetMessage("stats.forecast_title", 0, nil))
-			_, _ = dim.Printf("   %s\n", t.GetMessage("stats.forecast_days_label", 0, struct {
-				Current int
-				Total   int
-			}{forecast.DaysElapsed, forecast.DaysInMonth}))
-			_, _ = dim.Printf("   %s\n", t.GetMessage("stats.forecast_daily_avg_label", 0, struct{ Avg float64 }{forecast.DailyAverage}))
-			_, _ = yellow.Printf("   %s\n", t.GetMessage("stats.forecast_projected_label", 0, struct{ Amount float64 }{forecast.ProjectedMonthEnd}))
-			fmt.Println()
-		}
-	}
-
-	hitRate, saved, err := manager.GetCacheStats()
-	if err == nil && hitRate > 0 {
-		_, _ = green.Println(t.GetMessage("stats.cache_hit_rate_label", 0, struct {
-			Rate  float64
-			Saved float64
-		}{hitRate, saved}))
-		fmt.Println()
+		c.showForecast(manager, t)
 	}
+	c.showCacheHitRate(manager, t)
 
 	if !showForecast {
 		_, _ = dim.Println(t.GetMessage("stats.tip_forecast", 0, nil))
@@ -243,6 +260,41 @@ func (c *StatsCommand) showMonthlyStats(manager *cost.Manager, t *i18n.Translati
 	return nil
 }
 
+// showForecast prints the projection of the current month.
+func (c *StatsCommand) showForecast(manager *cost.Manager, t *i18n.Translations) {
+	forecast, err := manager.GetForecast()
+	if err != nil {
+		return
+	}
+
+	cyan := color.New(color.FgCyan, color.Bold)
+	yellow := color.New(color.FgYellow)
+	dim := color.New(color.FgHiBlack)
+
+	_, _ = cyan.Println(t.GetMessage("stats.forecast_title", 0, nil))
+	_, _ = dim.Printf("   %s\n", t.GetMessage("stats.forecast_days_label", 0, struct {
+		Current int
+		Total   int
+	}{forecast.DaysElapsed, forecast.DaysInMonth}))
+	_, _ = dim.Printf("   %s\n", t.GetMessage("stats.forecast_daily_avg_label", 0, struct{ Avg float64 }{forecast.DailyAverage}))
+	_, _ = yellow.Printf("   %s\n", t.GetMessage("stats.forecast_projected_label", 0, struct{ Amount float64 }{forecast.ProjectedMonthEnd}))
+	fmt.Println()
+}
+
+// showCacheHitRate prints the cache hit rate of the current month, when there were hits.
+func (c *StatsCommand) showCacheHitRate(manager *cost.Manager, t *i18n.Translations) {
+	hitRate, saved, err := manager.GetCacheStats()
+	if err != nil || hitRate == 0 {
+		return
+	}
+
+	_, _ = color.New(color.FgGreen).Println(t.GetMessage("stats.cache_hit_rate_label", 0, struct {
+		Rate  float64
+		Saved float64
+	}{hitRate, saved}))
+	fmt.Println()
+}
+
 func (c *StatsCommand) showBreakdown(manager *cost.Manager, t *i18n.Translations) error {
 	breakdown, err := manager.GetBreakdownByCommand()
 	if err != nil {
@@ -329,5 +381,183 @@ func (c *StatsCommand) showBreakdown(manager *cost.Manager, t *i18n.Translations
 		}
 	}
 
+	if estimation, err := manager.GetEstimationStats(); err == nil && len(estimation) > 0 {
+		c.showEstimationStats(estimation, t)
+	}
+
 	return nil
 }
+
+// parseBreakdownFlags validates --by, which defaults to defaultBy, and reads the period of
+// --since and --until, which defaults to the current month.
+func parseBreakdownFlags(t *i18n.Translations, by, since, until, defaultBy string) (string, cost.Period, error) {
+	if by == "" {
+		by = defaultBy
+	}
+	if !slices.Contains(cost.GroupDimensions(), by) {
+		return "", cost.Period{}, errors.New(t.GetMessage("stats.error_invalid_group", 0, struct {
+			Value string
+			Valid string
+		}{by, strings.Join(cost.GroupDimensions(), ", ")}))
+	}
+	period, err := parsePeriod(t, since, until, cost.MonthOf(time.Now()))
+	if err != nil {
+		return "", cost.Period{}, err
+	}
+	return by, period, nil
+}
+
+// parsePeriod reads the --since and --until dates (YYYY-MM-DD, local time); both days are included.
+// Without either, the period is fallback.
+func parsePeriod(t *i18n.Translations, since, until string, fallback cost.Period) (cost.Period, error) {
+	if since == "" && until == "" {
+		return fallback, nil
+	}
+
+	var period cost.Period
+	for _, bound := range []struct {
+		flag, value string
+		target      *time.Time
+		days        int
+	}{
+		{"since", since, &period.Since, 0},
+		{"until", until, &period.Until, 1},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		day, err := time.ParseInLocation("2006-01-02", bound.value, time.Local)
+		if err != nil {
+			return cost.Period{}, errors.New(t.GetMessage("stats.error_invalid_date", 0, struct {
+				Flag  string
+				Value string
+			}{bound.flag, bound.value}))
+		}
+		*bound.target = day.AddDate(0, 0, bound.days)
+	}
+	return period, nil
+}
+
+// periodLabel describes a period for the breakdown title
+func periodLabel(period cost.Period) string {
+	if month := cost.MonthOf(period.Since); period.Since.Equal(month.Since) && period.Until.Equal(month.Until) {
+		return period.Since.Format("January 2006")
+	}
+	from, to := "…", "…"
+	if !period.Since.IsZero() {
+		from = period.Since.Format("2006-01-02")
+	}
+	if !period.Until.IsZero() {
+		to = period.Until.AddDate(0, 0, -1).Format("2006-01-02")
+	}
+	return from + " → " + to
+}
+
+// showGroupedBreakdown prints the usage of a period grouped by one of cost.GroupDimensions.
+func (c *StatsCommand) showGroupedBreakdown(manager *cost.Manager, t *i18n.Translations, by string, period cost.Period) error {
+	breakdown, err := manager.GetBreakdown(by, period)
+	if err != nil {
+		return err
+	}
+
+	if breakdown.TotalCalls == 0 {
+		fmt.Printf("\n%s\n\n", t.GetMessage("stats.no_activity", 0, nil))
+		return nil
+	}
+
+	cyan := color.New(color.FgCyan, color.Bold)
+	yellow := color.New(color.FgYellow)
+	dim := color.New(color.FgHiBlack)
+
+	_, _ = cyan.Printf("\n📊 %s - %s\n", t.GetMessage("stats.usage_breakdown_title", 0, nil), periodLabel(period))
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println()
+
+	header := t.GetMessage("stats.column_"+by, 0, nil)
+	unknown := t.GetMessage("stats.unknown_group", 0, nil)
+	keyWidth := max(10, len([]rune(header)), len([]rune(unknown)))
+	for _, group := range breakdown.Groups {
+		keyWidth = max(keyWidth, len([]rune(group.Key)))
+	}
+
+	fmt.Printf("%-*s │ %8s │ %10s │ %8s\n",
+		keyWidth,
+		header,
+		t.GetMessage("stats.column_calls", 0, nil),
+		t.GetMessage("stats.column_cost", 0, nil),
+		t.GetMessage("stats.column_percent", 0, nil))
+	fmt.Printf("%s─┼─%s─┼─%s─┼─%s\n",
+		strings.Repeat("─", keyWidth),
+		strings.Repeat("─", 8),
+		strings.Repeat("─", 10),
+		strings.Repeat("─", 8))
+
+	for _, group := range breakdown.Groups {
+		percentage := 0.0
+		if breakdown.TotalCost > 0 {
+			percentage = (group.TotalCost / breakdown.TotalCost) * 100
+		}
+
+		key := group.Key
+		if key == "" {
+			key = dim.Sprint(unknown) + strings.Repeat(" ", keyWidth-len([]rune(unknown)))
+		} else {
+			key += strings.Repeat(" ", keyWidth-len([]rune(key)))
+		}
+
+		fmt.Printf("%s │ %s │ %s │ %7s%%\n",
+			key,
+			yellow.Sprintf("%8d", group.CallCount),
+			yellow.Sprintf("$%8.4f", group.TotalCost),
+			fmt.Sprintf("%7.1f", percentage),
+		)
+
+		if group.CacheHitRate > 0 {
+			_, _ = dim.Printf("%s   └─ %s %.0f%%\n",
+				strings.Repeat(" ", keyWidth),
+				t.GetMessage("stats.cache_hits_label", 0, nil),
+				group.CacheHitRate)
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("%s %s │ %s\n",
+		t.GetMessage("stats.total_label", 0, nil),
+		yellow.Sprintf("%d %s", breakdown.TotalCalls, t.GetMessage("stats.calls_text", 0, nil)),
+		yellow.Sprintf("$%.4f USD", breakdown.TotalCost))
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Println()
+
+	return nil
+}
+
+// showEstimationStats prints how far the input token estimates were from the tokens the providers reported.
+func (c *StatsCommand) showEstimationStats(estimation []cost.EstimationStats, t *i18n.Translations) {
+	yellow := color.New(color.FgYellow)
+	dim := color.New(color.FgHiBlack)
+
+	_, _ = dim.Println(t.GetMessage("stats.estimation_label", 0, nil))
+	fmt.Printf("%-10s │ %-6s │ %6s │ %9s │ %8s\n",
+		t.GetMessage("stats.column_provider", 0, nil),
+		t.GetMessage("stats.column_source", 0, nil),
+		t.GetMessage("stats.column_calls", 0, nil),
+		t.GetMessage("stats.column_error", 0, nil),
+		t.GetMessage("stats.column_bias", 0, nil))
+	fmt.Printf("%s─┼─%s─┼─%s─┼─%s─┼─%s\n",
+		strings.Repeat("─", 10),
+		strings.Repeat("─", 6),
+		strings.Repeat("─", 6),
+		strings.Repeat("─", 9),
+		strings.Repeat("─", 8))
+
+	for _, stat := range estimation {
+		fmt.Printf("%-10s │ %-6s │ %6d │ %s │ %s\n",
+			stat.Provider,
+			stat.Source,
+			stat.Calls,
+			yellow.Sprintf("%8.1f%%", stat.MeanAbsError),
+			dim.Sprintf("%+7.1f%%", stat.MeanError))
+	}
+	fmt.Println()
+}
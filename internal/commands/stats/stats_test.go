@@ -2,9 +2,11 @@ package stats
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -484,3 +486,221 @@ func TestShowMonthlyStats_HandlesManagerErrors(t *testing.T) {
 	// Assert
 	assert.Error(t, err, "should return an error when the history is corrupt")
 }
+
+func TestShowBreakdown_EstimationAccuracy(t *testing.T) {
+	// Arrange
+	tempDir := t.TempDir()
+	now := time.Now()
+	manager := setupTestManager(t, tempDir, []cost.ActivityRecord{
+		{Timestamp: now, Command: "suggest", Provider: "gemini", CostUSD: 0.001, TokensInput: 100, TokensEstimated: 120, EstimateSource: cost.EstimateSourceLocal},
+	})
+	trans := setupTestTranslations(t)
+	cmd := NewStatsCommand()
+
+	var buf bytes.Buffer
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	// Act
+	err := cmd.showBreakdown(manager, trans)
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+	_, _ = io.Copy(&buf, r)
+
+	output := buf.String()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, output, "Avg error")
+	assert.Contains(t, output, "gemini")
+	assert.Contains(t, output, "20.0%")
+	assert.Contains(t, output, "+20.0%")
+}
+
+func TestShowGroupedBreakdown(t *testing.T) {
+	// Arrange
+	tempDir := t.TempDir()
+	now := time.Now()
+	manager := setupTestManager(t, tempDir, []cost.ActivityRecord{
+		{Timestamp: now, Command: "suggest", Repo: "acme/api", Branch: "feature/login", CostUSD: 0.003},
+		{Timestamp: now, Command: "suggest", Repo: "acme/web", Branch: "main", CostUSD: 0.001},
+		{Timestamp: now, Command: "suggest", CostUSD: 0.001},
+	})
+	trans := setupTestTranslations(t)
+	cmd := NewStatsCommand()
+
+	var buf bytes.Buffer
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	// Act
+	err := cmd.showGroupedBreakdown(manager, trans, cost.GroupByRepo, cost.MonthOf(now))
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+	_, _ = io.Copy(&buf, r)
+
+	output := buf.String()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, output, "Repository")
+	assert.Contains(t, output, "acme/api")
+	assert.Contains(t, output, "acme/web")
+	assert.Contains(t, output, "60.0%")
+	assert.Contains(t, output, "3 calls")
+}
+
+func TestParsePeriod(t *testing.T) {
+	trans := setupTestTranslations(t)
+
+	t.Run("includes both days", func(t *testing.T) {
+		// Act
+		period, err := parsePeriod(trans, "2025-03-01", "2025-03-15", cost.Period{})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local), period.Since)
+		assert.Equal(t, time.Date(2025, 3, 16, 0, 0, 0, 0, time.Local), period.Until)
+		assert.Equal(t, "2025-03-01 → 2025-03-15", periodLabel(period))
+	})
+
+	t.Run("leaves a missing bound open", func(t *testing.T) {
+		// Act
+		period, err := parsePeriod(trans, "2025-03-01", "", cost.Period{})
+
+		// Assert
+		require.NoError(t, err)
+		assert.True(t, period.Until.IsZero())
+		assert.Equal(t, "2025-03-01 → …", periodLabel(period))
+	})
+
+	t.Run("falls back without dates", func(t *testing.T) {
+		// Act
+		period, err := parsePeriod(trans, "", "", cost.MonthOf(time.Now()))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, cost.MonthOf(time.Now()), period)
+	})
+
+	t.Run("rejects other formats", func(t *testing.T) {
+		// Act
+		_, err := parsePeriod(trans, "", "15/03/2025", cost.Period{})
+
+		// Assert
+		assert.ErrorContains(t, err, "--until")
+	})
+}
+
+func TestExportCommand(t *testing.T) {
+	// Arrange
+	tempDir := t.TempDir()
+	setupTestManager(t, tempDir, []cost.ActivityRecord{
+		{Timestamp: time.Date(2025, 3, 10, 12, 0, 0, 0, time.Local), Command: "suggest", CostUSD: 0.002},
+		{Timestamp: time.Date(2025, 4, 2, 12, 0, 0, 0, time.Local), Command: "suggest", CostUSD: 0.004},
+	})
+	trans := setupTestTranslations(t)
+	output := filepath.Join(tempDir, "usage.jsonl")
+	app := NewStatsCommand().CreateCommand(trans, nil)
+
+	// Act
+	err := app.Run(context.Background(), []string{"stats", "export", "--format", "jsonl", "--since", "2025-04-01", "-o", output})
+
+	// Assert
+	require.NoError(t, err)
+	data, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(data), `"type":"record"`))
+	assert.Contains(t, string(data), `"type":"totals","calls":1,"cost_usd":0.004`)
+
+	t.Run("rejects unknown formats", func(t *testing.T) {
+		// Act
+		err := app.Run(context.Background(), []string{"stats", "export", "--format", "xlsx"})
+
+		// Assert
+		assert.ErrorContains(t, err, "xlsx")
+	})
+}
+
+func TestMergeCommand(t *testing.T) {
+	// Arrange
+	tempDir := t.TempDir()
+	trans := setupTestTranslations(t)
+	now := time.Now()
+	for user, costUSD := range map[string]float64{"alice": 0.003, "bob": 0.001} {
+		var buf bytes.Buffer
+		require.NoError(t, cost.WriteExport(&buf, cost.ExportFormatJSONL, &cost.Export{
+			Version: cost.ExportVersion,
+			Records: []cost.ActivityRecord{{Timestamp: now, Command: "suggest", Hash: user, CostUSD: costUSD}},
+		}))
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, user+".jsonl"), buf.Bytes(), 0644))
+	}
+	app := NewStatsCommand().CreateCommand(trans, nil)
+
+	var buf bytes.Buffer
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	// Act
+	err := app.Run(context.Background(), []string{"stats", "merge", "--from-dir", tempDir})
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+	_, _ = io.Copy(&buf, r)
+
+	output := buf.String()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, output, "User")
+	assert.Contains(t, output, "alice")
+	assert.Contains(t, output, "75.0%")
+	assert.Contains(t, output, "2 calls")
+
+	t.Run("needs histories", func(t *testing.T) {
+		// Act
+		err := app.Run(context.Background(), []string{"stats", "merge"})
+
+		// Assert
+		assert.ErrorContains(t, err, "No histories to merge")
+	})
+}
+
+func TestShowPerfStats(t *testing.T) {
+	// Arrange
+	tempDir := t.TempDir()
+	now := time.Now()
+	manager := setupTestManager(t, tempDir, []cost.ActivityRecord{
+		{Timestamp: now, Command: "suggest", Model: "gemini-2.5-flash", DurationMs: 1200, TokensOutput: 120},
+		{Timestamp: now, Command: "suggest", Model: "gemini-2.5-flash", DurationMs: 4, CacheHit: true},
+		{Timestamp: now, Command: "suggest", Model: "gemini-2.5-flash", DurationMs: 900, Error: "timeout"},
+	})
+	trans := setupTestTranslations(t)
+	cmd := NewStatsCommand()
+
+	var buf bytes.Buffer
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	// Act
+	err := cmd.showPerfStats(manager, trans, cost.MonthOf(now))
+
+	_ = w.Close()
+	os.Stdout = oldStdout
+	_, _ = io.Copy(&buf, r)
+
+	output := buf.String()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, output, "gemini-2.5-flash")
+	assert.Contains(t, output, "100.0")
+	assert.Contains(t, output, "Errors: 1 of 2 calls (50.0%), 0 retries")
+	assert.Contains(t, output, "Cache hits answer in 4ms vs 1200ms from a provider (p50)")
+}
@@ -70,7 +70,7 @@ func (f *SuggestCommandFactory) createFlags(cfg *config.Config, t *i18n.Translat
 		&cli.StringFlag{
 			Name:    "lang",
 			Aliases: []string{"l"},
-			Usage:   t.GetMessage("suggest_lang_flag_usage", 0, nil),
+			Usage:   t.GetMessage("suggest_lang_flag_usage", 0, struct{ Languages string }{strings.Join(config.SupportedLanguages(), ", ")}),
 			Value:   cfg.Language,
 		},
 		&cli.BoolFlag{
@@ -128,7 +128,7 @@ func (f *SuggestCommandFactory) createAction(cfg *config.Config, t *i18n.Transla
 		cfg.Language = command.String("lang")
 
 		if err := t.SetLanguage(cfg.Language); err != nil {
-			_ = t.SetLanguage("en")
+			_ = t.SetLanguage(config.DefaultLanguage)
 		}
 
 		if dryRun {
@@ -3,8 +3,10 @@ package config
 type AI string
 
 const (
-	AIGemini AI = "gemini"
-	AIOpenAI AI = "openai"
+	AIGemini    AI = "gemini"
+	AIOpenAI    AI = "openai"
+	AIAnthropic AI = "anthropic"
+	AILocal     AI = "local"
 )
 
 type Model string
@@ -16,35 +18,108 @@ const (
 	ModelGeminiV3Pro    Model = "gemini-3-pro-preview"
 	ModelGeminiV3Flash  Model = "gemini-3-flash-preview"
 
-	// TODO: Add more models for OpenAI or others...
-	ModelGPTV4o Model = "gpt-4o"
+	ModelGPTV4oMini Model = "gpt-4o-mini"
+	ModelGPTV4o     Model = "gpt-4o"
+
+	ModelClaudeV3Haiku   Model = "claude-3-haiku-20240307"
+	ModelClaudeV35Sonnet Model = "claude-3-5-sonnet-latest"
+
+	ModelLocalLlama31     Model = "llama3.1"
+	ModelLocalQwen25Coder Model = "qwen2.5-coder"
+)
+
+// Keys of AIConfig.Commands, named after the CLI commands
+const (
+	CommandSuggest     = "suggest"
+	CommandSummarizePR = "summarize-pr"
+	CommandRelease     = "release"
+	CommandIssue       = "issue"
+)
+
+// ConfigurableCommands returns the commands that accept their own model and parameters
+func ConfigurableCommands() []string {
+	return []string{
+		CommandSuggest,
+		CommandSummarizePR,
+		CommandRelease,
+		CommandIssue,
+	}
+}
+
+// Modes of AIConfig.TokenCounting
+const (
+	// TokenCountingExact asks the provider to count the prompt, falling back to the local estimate
+	TokenCountingExact = "exact"
+	// TokenCountingEstimate counts the prompt locally, without a network call
+	TokenCountingEstimate = "estimate"
+	// TokenCountingOff skips counting, so no cost estimate is shown before a call
+	TokenCountingOff = "off"
+)
+
+// TokenCountingModes returns the accepted values of ai_config.token_counting
+func TokenCountingModes() []string {
+	return []string{TokenCountingExact, TokenCountingEstimate, TokenCountingOff}
+}
+
+// TokenCountingMode returns the configured token counting mode; empty or unknown values select exact
+func (c AIConfig) TokenCountingMode() string {
+	switch c.TokenCounting {
+	case TokenCountingEstimate, TokenCountingOff:
+		return c.TokenCounting
+	default:
+		return TokenCountingExact
+	}
+}
+
+// Policies of AIConfig.Budget.Policy
+const (
+	// BudgetPolicyWarn shows the exceeded caps and makes the call anyway
+	BudgetPolicyWarn = "warn"
+	// BudgetPolicyConfirm asks before making a call that exceeds a cap
+	BudgetPolicyConfirm = "confirm"
+	// BudgetPolicyBlock refuses calls that exceed a cap
+	BudgetPolicyBlock = "block"
 )
 
+// BudgetPolicies returns the accepted values of ai_config.budget.policy
+func BudgetPolicies() []string {
+	return []string{BudgetPolicyWarn, BudgetPolicyConfirm, BudgetPolicyBlock}
+}
+
+// EffectivePolicy returns the configured budget policy; empty or unknown values block
+func (b BudgetConfig) EffectivePolicy() string {
+	switch b.Policy {
+	case BudgetPolicyWarn, BudgetPolicyConfirm:
+		return b.Policy
+	default:
+		return BudgetPolicyBlock
+	}
+}
+
+// CommandConfig returns the settings of the given command, or the zero value when it has none
+func (c AIConfig) CommandConfig(command string) CommandAIConfig {
+	return c.Commands[command]
+}
+
 func SupportedAIs() []AI {
 	return []AI{
 		AIGemini,
+		AIOpenAI,
+		AIAnthropic,
+		AILocal,
 	}
 }
 
+// ModelsForAI returns the models offered for a provider, as listed in the model registry
 func ModelsForAI(ai AI) []Model {
-	switch ai {
-	case AIGemini:
-		return []Model{
-			ModelGeminiV15Flash,
-			ModelGeminiV25Flash,
-			ModelGeminiV3Flash,
-			ModelGeminiV15Pro,
-			ModelGeminiV3Pro,
-		}
-	default:
-		return []Model{}
+	models := []Model{}
+	for _, info := range DefaultModelRegistry().Models(ai) {
+		models = append(models, info.Model)
 	}
+	return models
 }
 
+// DefaultModelForAI returns the model the registry proposes for a provider
 func DefaultModelForAI(ai AI) Model {
-	models := ModelsForAI(ai)
-	if len(models) == 0 {
-		return ""
-	}
-	return models[0]
+	return DefaultModelRegistry().DefaultModel(ai)
 }
@@ -16,6 +16,8 @@ type (
 		UseEmoji         bool   `json:"use_emoji"`
 		SuggestionsCount int    `json:"suggestions_count"`
 		PathFile         string `json:"path_file"`
+		// LocalPathFile is the repository config merged over the one at PathFile, if any
+		LocalPathFile string `json:"-"`
 
 		AIProviders map[string]AIProviderConfig `json:"ai_providers,omitempty"`
 		AIConfig    AIConfig                    `json:"ai_config"`
@@ -32,6 +34,24 @@ type (
 		AutoFetchTags     bool                 `json:"auto_fetch_tags"`
 		MainPath          string               `json:"main_path,omitempty"`
 		GitFallback       GitConfig            `json:"git_fallback,omitempty"`
+
+		// Redaction masks secrets and personal data in the prompts before they are sent to the AI
+		Redaction RedactionConfig `json:"redaction,omitempty"`
+	}
+
+	RedactionConfig struct {
+		// Disabled turns off the redaction of every prompt
+		Disabled bool `json:"disabled,omitempty"`
+		// DisableEntropy turns off the detector of random-looking strings, which can flag hashes and test fixtures
+		DisableEntropy bool `json:"disable_entropy,omitempty"`
+		// Patterns are extra regular expressions masked next to the built-in detectors.
+		// A named group "secret" masks only that part of the match.
+		Patterns []RedactionPattern `json:"patterns,omitempty"`
+	}
+
+	RedactionPattern struct {
+		Name    string `json:"name"`
+		Pattern string `json:"pattern"`
 	}
 
 	GitConfig struct {
@@ -44,6 +64,8 @@ type (
 		Model       string  `json:"model,omitempty"`
 		Temperature float32 `json:"temperature,omitempty"`
 		MaxTokens   int     `json:"max_tokens,omitempty"`
+		// BaseURL points to a self-hosted endpoint (e.g. Ollama or an OpenAI-compatible server).
+		BaseURL string `json:"base_url,omitempty"`
 	}
 
 	TicketProviderConfig struct {
@@ -58,6 +80,57 @@ type (
 		ActiveAI    AI           `json:"active_ai"`
 		Models      map[AI]Model `json:"models"`
 		BudgetDaily *float64     `json:"budget_daily,omitempty"`
+		// Fallback is tried in order when a call to the active provider fails
+		Fallback []AIFallback `json:"fallback,omitempty"`
+		// Commands overrides the model and generation parameters per command (suggest, summarize-pr, release, issue)
+		Commands map[string]CommandAIConfig `json:"commands,omitempty"`
+		// Routing rules suggest a model per call; the first matching rule wins
+		Routing []RoutingRule `json:"routing,omitempty"`
+		// TokenCounting selects how prompts are counted before a call: exact, estimate or off (empty is exact)
+		TokenCounting string `json:"token_counting,omitempty"`
+		// Budget adds monthly, per-command and per-repository caps to BudgetDaily
+		Budget BudgetConfig `json:"budget,omitempty"`
+	}
+
+	// BudgetConfig caps the AI spend. Zero values are not limited.
+	BudgetConfig struct {
+		Monthly float64 `json:"monthly,omitempty"`
+		// Commands caps the daily spend of each command, keyed like AIConfig.Commands
+		Commands map[string]float64 `json:"commands,omitempty"`
+		// RepoDaily and RepoMonthly cap the spend of the repository whose config sets them
+		RepoDaily   float64 `json:"repo_daily,omitempty"`
+		RepoMonthly float64 `json:"repo_monthly,omitempty"`
+		// Policy is what happens when a call would exceed a cap: warn, confirm or block (empty is block)
+		Policy string `json:"policy,omitempty"`
+	}
+
+	// RoutingRule suggests a model for the calls it matches. Empty or zero conditions match any call.
+	RoutingRule struct {
+		Name string `json:"name"`
+		// Operation is one of the command keys (suggest, summarize-pr, release, issue)
+		Operation string `json:"operation,omitempty"`
+		Provider  AI     `json:"provider,omitempty"`
+		MinTokens int    `json:"min_tokens,omitempty"`
+		MaxTokens int    `json:"max_tokens,omitempty"`
+		MinFiles  int    `json:"min_files,omitempty"`
+		MaxFiles  int    `json:"max_files,omitempty"`
+		Model     Model  `json:"model"`
+		Rationale string `json:"rationale,omitempty"`
+		// RationaleKey is the translation key used by the built-in rules instead of Rationale
+		RationaleKey string `json:"-"`
+	}
+
+	// CommandAIConfig holds the settings of one command. Empty fields use the provider configuration.
+	CommandAIConfig struct {
+		Model       Model   `json:"model,omitempty"`
+		Temperature float32 `json:"temperature,omitempty"`
+		MaxTokens   int     `json:"max_tokens,omitempty"`
+	}
+
+	// AIFallback is one entry of the fallback chain. An empty model uses the provider's default.
+	AIFallback struct {
+		Provider AI    `json:"provider"`
+		Model    Model `json:"model,omitempty"`
 	}
 
 	VCSConfig struct {
@@ -69,7 +142,7 @@ type (
 )
 
 const (
-	defaultLang             = "en"
+	defaultLang             = DefaultLanguage
 	defaultUseEmoji         = true
 	defaultSuggestionsCount = 3
 )
@@ -166,32 +239,50 @@ func GetRepoConfigPath() string {
 	return filepath.Join(repoRoot, ".matecommit", "config.json")
 }
 
-// LoadConfigWithHierarchy loads config with repository-local priority
-// If in a git repo, uses ONLY local config (.matecommit/config.json)
-// If not in a repo, falls back to global config (~/.config/matecommit/config.json)
+// LoadConfigWithHierarchy loads the global config (~/.config/matecommit/config.json) and, inside a git repo
+// that has one, merges the repository-local config (.matecommit/config.json) over it with MergeConfigs
 func LoadConfigWithHierarchy(globalPath string) (*Config, error) {
-	// If in a git repo, use local config ONLY
+	global, err := LoadConfig(globalPath)
+	if err != nil {
+		return nil, err
+	}
+
 	localPath := GetRepoConfigPath()
-	if localPath != "" {
-		cfg, err := LoadConfig(localPath)
-		if err != nil {
-			// If local config doesn't exist, create it
-			if os.IsNotExist(err) {
-				return CreateDefaultConfig(localPath)
-			}
-			return nil, err
-		}
-		return cfg, nil
+	if localPath == "" {
+		return global, nil
+	}
+	if _, err := os.Stat(localPath); os.IsNotExist(err) {
+		return global, nil
 	}
+	local, err := LoadConfig(localPath)
+	if err != nil {
+		return nil, err
+	}
+	return MergeConfigs(global, local), nil
+}
 
-	// Fallback to global only if not in a repo
-	return LoadConfig(globalPath)
+// LoadLocalConfig loads the config of a repository, creating it with CreateDefaultLocalConfig when it does not exist
+func LoadLocalConfig(path string) (*Config, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return CreateDefaultLocalConfig(path)
+	}
+	return LoadConfig(path)
+}
+
+// GlobalOnly returns the global config cfg was loaded from. When a repository config was merged into cfg,
+// the global file is read again, so that saving the result does not copy repository settings into it.
+func GlobalOnly(cfg *Config) (*Config, error) {
+	if cfg.LocalPathFile == "" {
+		return cfg, nil
+	}
+	return LoadConfig(cfg.PathFile)
 }
 
 // MergeConfigs merges local config over global config
 // Non-zero/non-empty values in local override global
 func MergeConfigs(global, local *Config) *Config {
 	result := *global
+	result.LocalPathFile = local.PathFile
 
 	if local.Language != "" {
 		result.Language = local.Language
@@ -221,7 +312,8 @@ func MergeConfigs(global, local *Config) *Config {
 		result.MainPath = local.MainPath
 	}
 	result.AutoFetchTags = local.AutoFetchTags
-	if local.AIConfig.ActiveAI != "" && local.AIConfig.ActiveAI != AIGemini {
+	// Repository configs leave active_ai empty until the repository picks a provider of its own
+	if local.AIConfig.ActiveAI != "" {
 		result.AIConfig.ActiveAI = local.AIConfig.ActiveAI
 	}
 	if len(local.AIConfig.Models) > 0 {
@@ -232,9 +324,53 @@ func MergeConfigs(global, local *Config) *Config {
 			result.AIConfig.Models[k] = v
 		}
 	}
+	if len(local.AIConfig.Fallback) > 0 {
+		result.AIConfig.Fallback = local.AIConfig.Fallback
+	}
 	if local.AIConfig.BudgetDaily != nil {
 		result.AIConfig.BudgetDaily = local.AIConfig.BudgetDaily
 	}
+	// A repository can add patterns and turn detectors off, but not bring back what the global config turned off
+	result.Redaction.Disabled = global.Redaction.Disabled || local.Redaction.Disabled
+	result.Redaction.DisableEntropy = global.Redaction.DisableEntropy || local.Redaction.DisableEntropy
+	if len(local.Redaction.Patterns) > 0 {
+		result.Redaction.Patterns = append(append([]RedactionPattern{}, global.Redaction.Patterns...), local.Redaction.Patterns...)
+	}
+	if len(local.AIConfig.Routing) > 0 {
+		result.AIConfig.Routing = local.AIConfig.Routing
+	}
+	if local.AIConfig.TokenCounting != "" {
+		result.AIConfig.TokenCounting = local.AIConfig.TokenCounting
+	}
+	if local.AIConfig.Budget.Monthly > 0 {
+		result.AIConfig.Budget.Monthly = local.AIConfig.Budget.Monthly
+	}
+	if len(local.AIConfig.Budget.Commands) > 0 {
+		commands := make(map[string]float64, len(result.AIConfig.Budget.Commands)+len(local.AIConfig.Budget.Commands))
+		for k, v := range result.AIConfig.Budget.Commands {
+			commands[k] = v
+		}
+		for k, v := range local.AIConfig.Budget.Commands {
+			commands[k] = v
+		}
+		result.AIConfig.Budget.Commands = commands
+	}
+	// Repository caps only make sense in the repository config
+	result.AIConfig.Budget.RepoDaily = local.AIConfig.Budget.RepoDaily
+	result.AIConfig.Budget.RepoMonthly = local.AIConfig.Budget.RepoMonthly
+	if local.AIConfig.Budget.Policy != "" {
+		result.AIConfig.Budget.Policy = local.AIConfig.Budget.Policy
+	}
+	if len(local.AIConfig.Commands) > 0 {
+		commands := make(map[string]CommandAIConfig, len(result.AIConfig.Commands)+len(local.AIConfig.Commands))
+		for k, v := range result.AIConfig.Commands {
+			commands[k] = v
+		}
+		for k, v := range local.AIConfig.Commands {
+			commands[k] = v
+		}
+		result.AIConfig.Commands = commands
+	}
 	if len(local.AIProviders) > 0 {
 		if result.AIProviders == nil {
 			result.AIProviders = make(map[string]AIProviderConfig)
@@ -269,6 +405,16 @@ func MergeConfigs(global, local *Config) *Config {
 }
 
 func CreateDefaultConfig(path string) (*Config, error) {
+	return createDefaultConfig(path, AIGemini)
+}
+
+// CreateDefaultLocalConfig creates the config of a repository. It has no active provider,
+// so the one of the global config applies until the repository sets its own.
+func CreateDefaultLocalConfig(path string) (*Config, error) {
+	return createDefaultConfig(path, "")
+}
+
+func createDefaultConfig(path string, activeAI AI) (*Config, error) {
 	config := &Config{
 		Language:         defaultLang,
 		UseEmoji:         defaultUseEmoji,
@@ -278,7 +424,7 @@ func CreateDefaultConfig(path string) (*Config, error) {
 		VersionPattern:   "",
 		PathFile:         path,
 		AIConfig: AIConfig{
-			ActiveAI: AIGemini,
+			ActiveAI: activeAI,
 			Models:   make(map[AI]Model),
 		},
 
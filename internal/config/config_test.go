@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/json"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 )
@@ -94,6 +95,76 @@ func TestLoadConfig(t *testing.T) {
 
 }
 
+func TestLoadConfigWithHierarchy(t *testing.T) {
+	home := t.TempDir()
+	repo := t.TempDir()
+	if out, err := exec.Command("git", "init", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v: %s", err, out)
+	}
+	t.Chdir(repo)
+
+	globalPath := filepath.Join(home, ".config", "matecommit", "config.json")
+	global, err := CreateDefaultConfig(globalPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	global.AIConfig.ActiveAI = AIOpenAI
+	global.AIConfig.Budget = BudgetConfig{Monthly: 10, Commands: map[string]float64{CommandSuggest: 1}}
+	if err := SaveConfig(global); err != nil {
+		t.Fatal(err)
+	}
+	localPath := GetRepoConfigPath()
+
+	t.Run("should use the global config as is without a repository config", func(t *testing.T) {
+		cfg, err := LoadConfigWithHierarchy(home)
+		if err != nil {
+			t.Fatalf("LoadConfigWithHierarchy() error = %v", err)
+		}
+
+		if cfg.AIConfig.ActiveAI != AIOpenAI || cfg.LocalPathFile != "" {
+			t.Errorf("got ActiveAI %v and local path %q, want the global config", cfg.AIConfig.ActiveAI, cfg.LocalPathFile)
+		}
+		if _, err := os.Stat(localPath); !os.IsNotExist(err) {
+			t.Errorf("the repository config should not be created, stat error = %v", err)
+		}
+	})
+
+	t.Run("should merge the repository config over the global one", func(t *testing.T) {
+		local, err := CreateDefaultLocalConfig(localPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		local.AIConfig.Budget.RepoDaily = 2
+		if err := SaveLocalConfig(local); err != nil {
+			t.Fatal(err)
+		}
+
+		cfg, err := LoadConfigWithHierarchy(home)
+		if err != nil {
+			t.Fatalf("LoadConfigWithHierarchy() error = %v", err)
+		}
+
+		if cfg.AIConfig.ActiveAI != AIOpenAI {
+			t.Errorf("ActiveAI = %v, want the global %v when the repository does not pick one", cfg.AIConfig.ActiveAI, AIOpenAI)
+		}
+		budget := cfg.AIConfig.Budget
+		if budget.Monthly != 10 || budget.Commands[CommandSuggest] != 1 || budget.RepoDaily != 2 {
+			t.Errorf("budget = %+v, want the global and repository caps together", budget)
+		}
+		if cfg.PathFile != globalPath || cfg.LocalPathFile != localPath {
+			t.Errorf("paths = %q, %q, want %q, %q", cfg.PathFile, cfg.LocalPathFile, globalPath, localPath)
+		}
+
+		globalOnly, err := GlobalOnly(cfg)
+		if err != nil {
+			t.Fatalf("GlobalOnly() error = %v", err)
+		}
+		if globalOnly.AIConfig.Budget.RepoDaily != 0 || globalOnly.LocalPathFile != "" {
+			t.Errorf("GlobalOnly() = %+v, want the global config without the repository one", globalOnly.AIConfig.Budget)
+		}
+	})
+}
+
 func TestSaveConfig(t *testing.T) {
 	t.Run("should create config.json in .config/matecommit directory if it doesn't exist", func(t *testing.T) {
 		tmpDir := t.TempDir()
@@ -425,4 +496,148 @@ func TestMergeConfigs(t *testing.T) {
 			t.Errorf("UseEmoji = %v, want %v", result.UseEmoji, false)
 		}
 	})
+	t.Run("should let local choose gemini over another global provider", func(t *testing.T) {
+		global := &Config{AIConfig: AIConfig{ActiveAI: AIOpenAI}}
+		local := &Config{AIConfig: AIConfig{ActiveAI: AIGemini}}
+
+		result := MergeConfigs(global, local)
+
+		if result.AIConfig.ActiveAI != AIGemini {
+			t.Errorf("ActiveAI = %v, want %v", result.AIConfig.ActiveAI, AIGemini)
+		}
+		if got := MergeConfigs(global, &Config{}).AIConfig.ActiveAI; got != AIOpenAI {
+			t.Errorf("ActiveAI = %v, want the global %v when local is empty", got, AIOpenAI)
+		}
+	})
+
+	t.Run("should keep the global provider under a new repository config", func(t *testing.T) {
+		global := &Config{AIConfig: AIConfig{ActiveAI: AIAnthropic}}
+		local, err := CreateDefaultLocalConfig(filepath.Join(t.TempDir(), ".matecommit", "config.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result := MergeConfigs(global, local)
+
+		if result.AIConfig.ActiveAI != AIAnthropic {
+			t.Errorf("ActiveAI = %v, want the global %v", result.AIConfig.ActiveAI, AIAnthropic)
+		}
+	})
+
+	t.Run("should take the fallback chain from local when it has one", func(t *testing.T) {
+		global := &Config{AIConfig: AIConfig{ActiveAI: AIGemini}}
+		local := &Config{AIConfig: AIConfig{Fallback: []AIFallback{
+			{Provider: AIOpenAI, Model: "gpt-4o-mini"},
+			{Provider: AIAnthropic},
+		}}}
+
+		result := MergeConfigs(global, local)
+
+		if len(result.AIConfig.Fallback) != 2 || result.AIConfig.Fallback[0].Provider != AIOpenAI {
+			t.Errorf("fallback = %+v, want the local chain", result.AIConfig.Fallback)
+		}
+		global.AIConfig.Fallback = []AIFallback{{Provider: AIAnthropic}}
+		if got := MergeConfigs(global, &Config{}).AIConfig.Fallback; len(got) != 1 || got[0].Provider != AIAnthropic {
+			t.Errorf("fallback = %+v, want the global chain when local has none", got)
+		}
+	})
+
+	t.Run("should merge per-command AI settings from local", func(t *testing.T) {
+		global := &Config{
+			Language: "en",
+			AIConfig: AIConfig{Commands: map[string]CommandAIConfig{
+				CommandSuggest: {Model: "gpt-4o-mini"},
+				CommandRelease: {Model: "gpt-4o"},
+			}},
+		}
+		local := &Config{
+			AIConfig: AIConfig{Commands: map[string]CommandAIConfig{
+				CommandRelease: {Model: "claude-3-5-sonnet-latest", Temperature: 0.2},
+			}},
+		}
+
+		result := MergeConfigs(global, local)
+
+		if got := result.AIConfig.CommandConfig(CommandSuggest).Model; got != "gpt-4o-mini" {
+			t.Errorf("suggest model = %v, want %v", got, "gpt-4o-mini")
+		}
+		if got := result.AIConfig.CommandConfig(CommandRelease); got.Model != "claude-3-5-sonnet-latest" || got.Temperature != 0.2 {
+			t.Errorf("release settings = %+v, want the local ones", got)
+		}
+		if got := global.AIConfig.CommandConfig(CommandRelease).Model; got != "gpt-4o" {
+			t.Errorf("global release model = %v, the global config must not change", got)
+		}
+	})
+
+	t.Run("should merge the token counting mode from local", func(t *testing.T) {
+		global := &Config{AIConfig: AIConfig{TokenCounting: TokenCountingOff}}
+
+		if got := MergeConfigs(global, &Config{}).AIConfig.TokenCountingMode(); got != TokenCountingOff {
+			t.Errorf("mode = %v, want the global %v", got, TokenCountingOff)
+		}
+		local := &Config{AIConfig: AIConfig{TokenCounting: TokenCountingEstimate}}
+		if got := MergeConfigs(global, local).AIConfig.TokenCountingMode(); got != TokenCountingEstimate {
+			t.Errorf("mode = %v, want the local %v", got, TokenCountingEstimate)
+		}
+		if got := (AIConfig{TokenCounting: "bogus"}).TokenCountingMode(); got != TokenCountingExact {
+			t.Errorf("unknown mode = %v, want %v", got, TokenCountingExact)
+		}
+	})
+
+	t.Run("should merge the budget caps and take repository caps only from local", func(t *testing.T) {
+		global := &Config{AIConfig: AIConfig{Budget: BudgetConfig{
+			Monthly:   20,
+			Commands:  map[string]float64{CommandRelease: 1, CommandSuggest: 0.5},
+			RepoDaily: 3,
+		}}}
+		local := &Config{AIConfig: AIConfig{Budget: BudgetConfig{
+			Commands:    map[string]float64{CommandRelease: 2},
+			RepoMonthly: 10,
+			Policy:      BudgetPolicyWarn,
+		}}}
+
+		got := MergeConfigs(global, local).AIConfig.Budget
+
+		if got.Monthly != 20 {
+			t.Errorf("monthly = %v, want the global 20", got.Monthly)
+		}
+		if got.Commands[CommandRelease] != 2 || got.Commands[CommandSuggest] != 0.5 {
+			t.Errorf("commands = %v, want release from local and suggest from global", got.Commands)
+		}
+		if got.RepoDaily != 0 || got.RepoMonthly != 10 {
+			t.Errorf("repo caps = %v/%v, want only the local ones", got.RepoDaily, got.RepoMonthly)
+		}
+		if got.EffectivePolicy() != BudgetPolicyWarn {
+			t.Errorf("policy = %v, want %v", got.EffectivePolicy(), BudgetPolicyWarn)
+		}
+		if policy := (BudgetConfig{}).EffectivePolicy(); policy != BudgetPolicyBlock {
+			t.Errorf("default policy = %v, want %v", policy, BudgetPolicyBlock)
+		}
+	})
+
+	t.Run("should add local redaction patterns to the global ones", func(t *testing.T) {
+		global := &Config{
+			Redaction: RedactionConfig{
+				DisableEntropy: true,
+				Patterns:       []RedactionPattern{{Name: "customer-id", Pattern: `CUST-\d+`}},
+			},
+		}
+		local := &Config{
+			Redaction: RedactionConfig{
+				Patterns: []RedactionPattern{{Name: "internal-host", Pattern: `\.corp\.example\.com`}},
+			},
+		}
+
+		result := MergeConfigs(global, local)
+
+		if len(result.Redaction.Patterns) != 2 || result.Redaction.Patterns[1].Name != "internal-host" {
+			t.Errorf("patterns = %+v, want the global and the local one", result.Redaction.Patterns)
+		}
+		if !result.Redaction.DisableEntropy {
+			t.Error("a local config must not turn back on a detector disabled globally")
+		}
+		if len(global.Redaction.Patterns) != 1 {
+			t.Errorf("global patterns = %+v, the global config must not change", global.Redaction.Patterns)
+		}
+	})
 }
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"slices"
+	"strings"
+)
+
+// DefaultLanguage is the fallback of every language without its own interface text or prompts
+const DefaultLanguage = "en"
+
+// SupportedLanguages returns the languages of the interface and the prompts.
+func SupportedLanguages() []string {
+	return []string{"en", "es", "pt", "fr", "de", "it"}
+}
+
+// BaseLanguage returns the supported language of a language tag, dropping its region:
+// pt-BR and pt_BR give pt. Unsupported languages give DefaultLanguage.
+func BaseLanguage(lang string) string {
+	if base := languageSubtag(lang); slices.Contains(SupportedLanguages(), base) {
+		return base
+	}
+	return DefaultLanguage
+}
+
+// IsSupportedLanguage reports whether a language tag, or its base language, is supported.
+func IsSupportedLanguage(lang string) bool {
+	return slices.Contains(SupportedLanguages(), languageSubtag(lang))
+}
+
+// languageSubtag returns the language part of a tag like pt-BR, in lowercase
+func languageSubtag(lang string) string {
+	base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(lang)), "-")
+	base, _, _ = strings.Cut(base, "_")
+	return base
+}
@@ -0,0 +1,34 @@
+package config
+
+import "testing"
+
+func TestBaseLanguage(t *testing.T) {
+	tests := map[string]string{
+		"es":    "es",
+		"pt-BR": "pt",
+		"pt_BR": "pt",
+		"DE-at": "de",
+		" it ":  "it",
+		"ja":    DefaultLanguage,
+		"ja-JP": DefaultLanguage,
+		"":      DefaultLanguage,
+	}
+	for lang, want := range tests {
+		if got := BaseLanguage(lang); got != want {
+			t.Errorf("BaseLanguage(%q) = %q, want %q", lang, got, want)
+		}
+	}
+}
+
+func TestIsSupportedLanguage(t *testing.T) {
+	for _, lang := range append(SupportedLanguages(), "pt-BR", "fr_CA") {
+		if !IsSupportedLanguage(lang) {
+			t.Errorf("IsSupportedLanguage(%q) = false, want true", lang)
+		}
+	}
+	for _, lang := range []string{"", "ja", "zh-CN", "english"} {
+		if IsSupportedLanguage(lang) {
+			t.Errorf("IsSupportedLanguage(%q) = true, want false", lang)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package config
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	// ModelsFileName is the registry override read from the global configuration directory
+	ModelsFileName = "models.json"
+	// WildcardModel holds the defaults of a provider for the models missing from the registry
+	WildcardModel Model = "*"
+	// DefaultContextWindow is assumed for providers missing from the registry
+	DefaultContextWindow = 32_000
+)
+
+//go:embed models.json
+var embeddedModels []byte
+
+// ModelPricing is the price of a model in USD per million tokens.
+type ModelPricing struct {
+	InputPerMillion  float64 `json:"input_per_million"`
+	OutputPerMillion float64 `json:"output_per_million"`
+}
+
+// ModelInfo describes what a model can do and what it costs.
+type ModelInfo struct {
+	Provider AI    `json:"provider"`
+	Model    Model `json:"model"`
+	// Aliases are other names of the model, such as the undated name of a dated version
+	Aliases         []string `json:"aliases,omitempty"`
+	ContextWindow   int      `json:"context_window"`
+	MaxOutputTokens int      `json:"max_output_tokens"`
+	// JSONSchema tells whether the model can be constrained to a JSON schema
+	JSONSchema bool `json:"json_schema"`
+	// Pricing is nil for models that are free to run, like the local ones
+	Pricing *ModelPricing `json:"pricing,omitempty"`
+	// Default marks the model config init proposes for its provider
+	Default    bool `json:"default,omitempty"`
+	Deprecated bool `json:"deprecated,omitempty"`
+	// ReplacedBy is the model to move to when this one is deprecated
+	ReplacedBy Model `json:"replaced_by,omitempty"`
+}
+
+type modelsFile struct {
+	Models []ModelInfo `json:"models"`
+}
+
+// ModelRegistry is the single source of model knowledge: the models offered per provider,
+// their limits, their pricing and their deprecation status.
+type ModelRegistry struct {
+	mu     sync.RWMutex
+	models []ModelInfo
+}
+
+var (
+	defaultRegistryOnce sync.Once
+	defaultRegistry     *ModelRegistry
+)
+
+// DefaultModelRegistry returns the embedded registry with the user override applied.
+// An override that cannot be read is reported and ignored.
+func DefaultModelRegistry() *ModelRegistry {
+	defaultRegistryOnce.Do(func() {
+		registry, err := LoadModelRegistry(UserModelsPath())
+		if err != nil {
+			slog.Warn("ignoring the model registry override", "path", UserModelsPath(), "error", err)
+			registry, _ = LoadModelRegistry("")
+		}
+		defaultRegistry = registry
+	})
+	return defaultRegistry
+}
+
+// UserModelsPath returns the path of the registry override (~/.config/matecommit/models.json),
+// or "" when the home directory is unknown.
+func UserModelsPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "matecommit", ModelsFileName)
+}
+
+// LoadModelRegistry reads the embedded registry and applies the override at overridePath.
+// Entries of the override replace the embedded entry of the same provider and model, or are added.
+// A missing override file is not an error.
+func LoadModelRegistry(overridePath string) (*ModelRegistry, error) {
+	base, err := parseModels(embeddedModels)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding embedded model registry: %w", err)
+	}
+	registry := NewModelRegistry(base)
+
+	if overridePath == "" {
+		return registry, nil
+	}
+	data, err := os.ReadFile(overridePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return registry, nil
+		}
+		return nil, fmt.Errorf("error reading model registry override: %w", err)
+	}
+	overrides, err := parseModels(data)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s: %w", overridePath, err)
+	}
+	for _, info := range overrides {
+		registry.Add(info)
+	}
+	return registry, nil
+}
+
+func parseModels(data []byte) ([]ModelInfo, error) {
+	var file modelsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	for i, info := range file.Models {
+		if info.Provider == "" || info.Model == "" {
+			return nil, fmt.Errorf("model entry %d needs a provider and a model", i)
+		}
+	}
+	return file.Models, nil
+}
+
+// NewModelRegistry creates a registry with the given models, in the order they are offered.
+func NewModelRegistry(models []ModelInfo) *ModelRegistry {
+	return &ModelRegistry{models: append([]ModelInfo{}, models...)}
+}
+
+// Add registers a model, replacing the entry of the same provider and model if there is one.
+func (r *ModelRegistry) Add(info ModelInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, existing := range r.models {
+		if existing.Provider == info.Provider && strings.EqualFold(string(existing.Model), string(info.Model)) {
+			r.models[i] = info
+			return
+		}
+	}
+	r.models = append(r.models, info)
+}
+
+// Lookup returns the entry of a known model. Names are matched case-insensitively, exactly first and
+// then by the longest registered name or alias the model starts with, as long as the rest is a
+// version suffix. Dated and tagged versions (gpt-4o-mini-2024-07-18, qwen2.5-coder:7b) share the
+// entry of their family, while variants such as gemini-2.5-flash-lite are not taken for it.
+func (r *ModelRegistry) Lookup(provider AI, model string) (ModelInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	provider = AI(strings.ToLower(string(provider)))
+	model = strings.ToLower(model)
+
+	var (
+		best      ModelInfo
+		bestMatch string
+	)
+	for _, info := range r.models {
+		if info.Provider != provider || info.Model == WildcardModel {
+			continue
+		}
+		for _, name := range append([]string{string(info.Model)}, info.Aliases...) {
+			name = strings.ToLower(name)
+			if name == model {
+				return info, true
+			}
+			if len(name) > len(bestMatch) && strings.HasPrefix(model, name) && isVersionSuffix(model[len(name):]) {
+				best, bestMatch = info, name
+			}
+		}
+	}
+	return best, bestMatch != ""
+}
+
+// isVersionSuffix reports whether what follows a model name only pins a version of it: a tag
+// (:7b), a date or snapshot number (-20240307, -2024-07-18) or -latest.
+func isVersionSuffix(suffix string) bool {
+	switch {
+	case strings.HasPrefix(suffix, ":"), suffix == "-latest":
+		return true
+	case len(suffix) > 1 && suffix[0] == '-':
+		return suffix[1] >= '0' && suffix[1] <= '9'
+	}
+	return false
+}
+
+// Capabilities returns the entry of a model, falling back to the defaults of its provider
+// and then to DefaultContextWindow for providers the registry does not know.
+func (r *ModelRegistry) Capabilities(provider AI, model string) ModelInfo {
+	if info, ok := r.Lookup(provider, model); ok {
+		return info
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, info := range r.models {
+		if strings.EqualFold(string(info.Provider), string(provider)) && info.Model == WildcardModel {
+			info.Model = Model(model)
+			return info
+		}
+	}
+	return ModelInfo{Provider: provider, Model: Model(model), ContextWindow: DefaultContextWindow}
+}
+
+// HasProvider reports whether the registry lists any model of the provider.
+func (r *ModelRegistry) HasProvider(provider AI) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, info := range r.models {
+		if strings.EqualFold(string(info.Provider), string(provider)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Models returns the models offered for a provider, without the deprecated ones.
+func (r *ModelRegistry) Models(provider AI) []ModelInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var models []ModelInfo
+	for _, info := range r.models {
+		if info.Provider == provider && info.Model != WildcardModel && !info.Deprecated {
+			models = append(models, info)
+		}
+	}
+	return models
+}
+
+// DefaultModel returns the model marked as default for a provider, or its first offered model.
+func (r *ModelRegistry) DefaultModel(provider AI) Model {
+	models := r.Models(provider)
+	for _, info := range models {
+		if info.Default {
+			return info.Model
+		}
+	}
+	if len(models) == 0 {
+		return ""
+	}
+	return models[0].Model
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestModelRegistry_KnowsEveryModelConstant(t *testing.T) {
+	registry, err := LoadModelRegistry("")
+	if err != nil {
+		t.Fatalf("LoadModelRegistry() error = %v", err)
+	}
+
+	constants := map[AI][]Model{
+		AIGemini:    {ModelGeminiV15Pro, ModelGeminiV15Flash, ModelGeminiV25Flash, ModelGeminiV3Pro, ModelGeminiV3Flash},
+		AIOpenAI:    {ModelGPTV4oMini, ModelGPTV4o},
+		AIAnthropic: {ModelClaudeV3Haiku, ModelClaudeV35Sonnet},
+		AILocal:     {ModelLocalLlama31, ModelLocalQwen25Coder},
+	}
+	for provider, models := range constants {
+		for _, model := range models {
+			info, ok := registry.Lookup(provider, string(model))
+			if !ok || info.Model != model {
+				t.Errorf("model %s/%s is missing from the registry", provider, model)
+			}
+			if info.ContextWindow <= 0 || info.MaxOutputTokens <= 0 {
+				t.Errorf("model %s/%s has no limits", provider, model)
+			}
+		}
+		if registry.DefaultModel(provider) == "" {
+			t.Errorf("provider %s has no default model", provider)
+		}
+	}
+}
+
+func TestModelRegistry_Lookup(t *testing.T) {
+	registry, err := LoadModelRegistry("")
+	if err != nil {
+		t.Fatalf("LoadModelRegistry() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		provider  AI
+		model     string
+		wantModel Model
+		wantOK    bool
+	}{
+		{name: "exact name", provider: AIGemini, model: "gemini-2.5-flash", wantModel: ModelGeminiV25Flash, wantOK: true},
+		{name: "alias", provider: AIAnthropic, model: "claude-3-5-sonnet", wantModel: ModelClaudeV35Sonnet, wantOK: true},
+		{name: "tagged version", provider: AILocal, model: "qwen2.5-coder:7b", wantModel: ModelLocalQwen25Coder, wantOK: true},
+		{name: "longest match wins", provider: AIOpenAI, model: "gpt-4o-mini-2024-07-18", wantModel: ModelGPTV4oMini, wantOK: true},
+		{name: "dated version", provider: AIAnthropic, model: "claude-3-5-sonnet-20241022", wantModel: ModelClaudeV35Sonnet, wantOK: true},
+		{name: "dated version of a shorter name", provider: AIOpenAI, model: "gpt-4o-2024-08-06", wantModel: ModelGPTV4o, wantOK: true},
+		{name: "variant is not its base model", provider: AIGemini, model: "gemini-2.5-flash-lite", wantOK: false},
+		{name: "name in the middle", provider: AIOpenAI, model: "ft:gpt-4o-mini:acme", wantOK: false},
+		{name: "case insensitive", provider: "OpenAI", model: "GPT-4o", wantModel: ModelGPTV4o, wantOK: true},
+		{name: "unknown model", provider: AIOpenAI, model: "o9-preview", wantOK: false},
+		{name: "wildcard is not a model", provider: AIOpenAI, model: "*", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, ok := registry.Lookup(tt.provider, tt.model)
+
+			if ok != tt.wantOK || info.Model != tt.wantModel {
+				t.Errorf("Lookup() = (%q, %v), want (%q, %v)", info.Model, ok, tt.wantModel, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestModelRegistry_Capabilities(t *testing.T) {
+	registry, err := LoadModelRegistry("")
+	if err != nil {
+		t.Fatalf("LoadModelRegistry() error = %v", err)
+	}
+
+	if got := registry.Capabilities(AIAnthropic, "claude-4-opus").ContextWindow; got != 200_000 {
+		t.Errorf("unknown model of a known provider: ContextWindow = %d, want the provider default 200000", got)
+	}
+	if got := registry.Capabilities("acme", "acme-1").ContextWindow; got != DefaultContextWindow {
+		t.Errorf("unknown provider: ContextWindow = %d, want %d", got, DefaultContextWindow)
+	}
+}
+
+func TestModelRegistry_ModelsSkipsDeprecated(t *testing.T) {
+	registry, err := LoadModelRegistry("")
+	if err != nil {
+		t.Fatalf("LoadModelRegistry() error = %v", err)
+	}
+
+	for _, info := range registry.Models(AIOpenAI) {
+		if info.Deprecated || info.Model == WildcardModel {
+			t.Errorf("Models() offers %q", info.Model)
+		}
+	}
+	if info, ok := registry.Lookup(AIOpenAI, "gpt-4-turbo"); !ok || !info.Deprecated || info.ReplacedBy != ModelGPTV4o {
+		t.Errorf("gpt-4-turbo should be deprecated in favour of %s, got %+v", ModelGPTV4o, info)
+	}
+}
+
+func TestLoadModelRegistry_Override(t *testing.T) {
+	t.Run("override replaces and adds models", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ModelsFileName)
+		content := `{"models": [
+			{"provider": "openai", "model": "gpt-4o-mini", "context_window": 64000, "max_output_tokens": 4096, "json_schema": true, "default": true},
+			{"provider": "local", "model": "mistral-nemo", "context_window": 128000, "max_output_tokens": 4096}
+		]}`
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		registry, err := LoadModelRegistry(path)
+		if err != nil {
+			t.Fatalf("LoadModelRegistry() error = %v", err)
+		}
+
+		if info, _ := registry.Lookup(AIOpenAI, "gpt-4o-mini"); info.ContextWindow != 64000 || info.Pricing != nil {
+			t.Errorf("override did not replace gpt-4o-mini: %+v", info)
+		}
+		if info, ok := registry.Lookup(AILocal, "mistral-nemo:12b"); !ok || info.ContextWindow != 128000 {
+			t.Errorf("override did not add mistral-nemo: %+v", info)
+		}
+		models := registry.Models(AILocal)
+		if last := models[len(models)-1].Model; last != "mistral-nemo" {
+			t.Errorf("added models should be offered last, got %q", last)
+		}
+	})
+
+	t.Run("missing override file", func(t *testing.T) {
+		if _, err := LoadModelRegistry(filepath.Join(t.TempDir(), ModelsFileName)); err != nil {
+			t.Errorf("LoadModelRegistry() error = %v, want nil", err)
+		}
+	})
+
+	t.Run("invalid override file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ModelsFileName)
+		if err := os.WriteFile(path, []byte(`{"models": [{"model": "no-provider"}]}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := LoadModelRegistry(path); err == nil {
+			t.Error("LoadModelRegistry() error = nil, want an error for an entry without provider")
+		}
+	})
+}
@@ -12,6 +12,7 @@ const (
 	TypeGit           ErrorType = "GIT"
 	TypeInternal      ErrorType = "INTERNAL"
 	TypeUpdate        ErrorType = "UPDATE"
+	TypeBudget        ErrorType = "BUDGET"
 )
 
 // AppError represents a domain-level error with a type and an underlying error
@@ -44,6 +45,15 @@ func (e *AppError) Unwrap() error {
 	return e.Err
 }
 
+// Is matches errors derived from the same sentinel, since WithError and WithContext return copies
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	if !ok {
+		return false
+	}
+	return e.Type == t.Type && e.Message == t.Message
+}
+
 // WithError creates a new AppError with an underlying error
 func (e *AppError) WithError(err error) *AppError {
 	return &AppError{
@@ -223,10 +233,32 @@ var (
 	ErrAIGeneration = NewAppError(TypeAI, "AI generation failed", nil).
 			WithSuggestion("Try again or check your API key configuration")
 
+	ErrAIServerError = NewAppError(TypeAI, "AI provider server error", nil).
+				WithSuggestion("The provider is having trouble, try again in a few minutes or configure a fallback provider")
+
 	ErrInvalidAIOutput = NewAppError(TypeAI, "invalid AI output format", nil).
 				WithSuggestion("This is likely a temporary issue, please try again")
 )
 
+// Budget errors
+var (
+	ErrBudgetExceeded = NewAppError(TypeBudget, "AI budget exceeded", nil).
+		WithSuggestion("Wait for the budget period to reset, raise the limit in ai_config.budget, or set its policy to warn or confirm")
+)
+
+// BudgetExceededError details the cap a call would go over; it is wrapped by ErrBudgetExceeded
+type BudgetExceededError struct {
+	// Scope is daily, monthly, command, repo_daily or repo_monthly
+	Scope     string
+	Limit     float64
+	Spent     float64
+	Estimated float64
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("%s limit of $%.4f: $%.4f spent + $%.4f estimated", e.Scope, e.Limit, e.Spent, e.Estimated)
+}
+
 // Gemini/AI specific errors
 var (
 	ErrGeminiAPIKeyInvalid = NewAppError(TypeAI, "Gemini API key is invalid", nil).
@@ -236,6 +268,30 @@ var (
 				WithSuggestion("Wait for quota to reset or upgrade your Gemini plan")
 )
 
+// OpenAI specific errors
+var (
+	ErrOpenAIAPIKeyInvalid = NewAppError(TypeAI, "OpenAI API key is invalid", nil).
+				WithSuggestion("Get a valid API key at: https://platform.openai.com/api-keys\nThen run: matecommit config init")
+
+	ErrOpenAIQuotaExceeded = NewAppError(TypeAI, "OpenAI API quota exceeded", nil).
+				WithSuggestion("Wait for the rate limit to reset or check your OpenAI billing settings")
+)
+
+// Anthropic specific errors
+var (
+	ErrAnthropicAPIKeyInvalid = NewAppError(TypeAI, "Anthropic API key is invalid", nil).
+					WithSuggestion("Get a valid API key at: https://console.anthropic.com/settings/keys\nThen run: matecommit config init")
+
+	ErrAnthropicQuotaExceeded = NewAppError(TypeAI, "Anthropic API rate limit exceeded", nil).
+					WithSuggestion("Wait for the rate limit to reset or check your Anthropic plan limits")
+)
+
+// Local model errors
+var (
+	ErrLocalAIUnreachable = NewAppError(TypeAI, "Local model server is not reachable", nil).
+		WithSuggestion("Make sure the server is running (e.g. 'ollama serve') and that base_url in the local provider config is correct")
+)
+
 // Update errors
 var (
 	ErrUpdateFailed = NewAppError(TypeUpdate, "Failed to update application", nil).
@@ -130,3 +130,30 @@ func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
 		(len(s) > 0 && (s[:len(substr)] == substr || contains(s[1:], substr))))
 }
+
+func TestAppError_Is(t *testing.T) {
+	wrapped := ErrAIGeneration.WithError(errors.New("status 503")).WithContext("status", 503)
+
+	if !errors.Is(wrapped, ErrAIGeneration) {
+		t.Error("Expected copy of ErrAIGeneration to match the sentinel")
+	}
+	if errors.Is(wrapped, ErrInvalidAIOutput) {
+		t.Error("Expected different sentinel not to match")
+	}
+}
+
+func TestBudgetExceededError(t *testing.T) {
+	err := ErrBudgetExceeded.WithError(&BudgetExceededError{Scope: "monthly", Limit: 20, Spent: 19.5, Estimated: 0.75})
+
+	if !errors.Is(err, ErrBudgetExceeded) {
+		t.Error("Expected the error to match ErrBudgetExceeded")
+	}
+	var details *BudgetExceededError
+	if !errors.As(err, &details) || details.Scope != "monthly" {
+		t.Fatalf("Expected the budget details to be reachable, got %v", details)
+	}
+	want := "BUDGET: AI budget exceeded (monthly limit of $20.0000: $19.5000 spent + $0.7500 estimated)"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
@@ -2,6 +2,7 @@ package git
 
 import (
 	"context"
+	stdErrors "errors"
 	"fmt"
 	"os/exec"
 	"regexp"
@@ -9,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/thomas-vilte/matecommit/internal/errors"
+	"github.com/thomas-vilte/matecommit/internal/ignore"
 	"github.com/thomas-vilte/matecommit/internal/logger"
 	"github.com/thomas-vilte/matecommit/internal/models"
 	"github.com/thomas-vilte/matecommit/internal/regex"
@@ -116,6 +118,18 @@ func (s *GitService) GetDiff(ctx context.Context) (string, error) {
 		}
 	}
 
+	if matcher, err := s.LoadIgnore(ctx); err != nil {
+		log.Warn("could not load ignore file, sending the full diff",
+			"error", err)
+	} else {
+		var omitted []string
+		combinedDiff, omitted = matcher.FilterDiff(combinedDiff)
+		if len(omitted) > 0 {
+			log.Debug("omitted ignored files from diff",
+				"files", omitted)
+		}
+	}
+
 	log.Debug("git diff completed",
 		"staged_size", len(stagedOutput),
 		"unstaged_size", len(unstageOutput),
@@ -124,6 +138,16 @@ func (s *GitService) GetDiff(ctx context.Context) (string, error) {
 	return combinedDiff, nil
 }
 
+// LoadIgnore reads the .matecommitignore file at the root of the repository.
+// Outside a repository, or without the file, the matcher ignores nothing.
+func (s *GitService) LoadIgnore(ctx context.Context) (*ignore.Matcher, error) {
+	root, err := s.GetRepoRoot(ctx)
+	if err != nil {
+		return &ignore.Matcher{}, nil
+	}
+	return ignore.Load(root)
+}
+
 func (s *GitService) CreateCommit(ctx context.Context, message string) error {
 	log := logger.FromContext(ctx)
 
@@ -167,7 +191,7 @@ func (s *GitService) CreateCommit(ctx context.Context, message string) error {
 }
 
 func (s *GitService) AddFileToStaging(ctx context.Context, file string) error {
-	repoRoot, err := s.getRepoRoot(ctx)
+	repoRoot, err := s.GetRepoRoot(ctx)
 	if err != nil {
 		return err
 	}
@@ -372,6 +396,32 @@ func (s *GitService) GetRecentCommitMessages(ctx context.Context, count int) ([]
 	return lines, nil
 }
 
+// GetCommitHistory returns the last limit non-merge commits of the current branch, newest first.
+// Message holds the subject and, when there is one, the body separated by a newline.
+func (s *GitService) GetCommitHistory(ctx context.Context, limit int) ([]models.Commit, error) {
+	cmd := exec.CommandContext(ctx, "git", "log", fmt.Sprintf("-%d", limit), "--no-merges", "--pretty=format:%H%x1f%s%x1f%b%x1e")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, errors.ErrGetCommits.WithError(err).WithContext("count", limit)
+	}
+
+	var commits []models.Commit
+	for _, record := range strings.Split(string(output), "\x1e") {
+		parts := strings.SplitN(strings.TrimLeft(record, "\n"), "\x1f", 3)
+		if len(parts) < 2 {
+			continue
+		}
+		commit := models.Commit{Hash: parts[0], Message: parts[1]}
+		if len(parts) == 3 {
+			if body := strings.TrimSpace(parts[2]); body != "" {
+				commit.Message = parts[1] + "\n" + body
+			}
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
 func (s *GitService) CreateTag(ctx context.Context, version, message string) error {
 	cmd := exec.CommandContext(ctx, "git", "tag", "-a", version, "-m", message)
 	if err := cmd.Run(); err != nil {
@@ -397,7 +447,14 @@ func (s *GitService) Push(ctx context.Context) error {
 	return nil
 }
 
+// GetCommitCount returns how many commits HEAD has, 0 in a repository without commits
 func (s *GitService) GetCommitCount(ctx context.Context) (int, error) {
+	// rev-parse exits with 1 when HEAD does not point to a commit yet, and 128 outside a repository
+	var exitErr *exec.ExitError
+	if err := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", "HEAD").Run(); stdErrors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return 0, nil
+	}
+
 	cmd := exec.CommandContext(ctx, "git", "rev-list", "--count", "HEAD")
 	output, err := cmd.Output()
 	if err != nil {
@@ -441,7 +498,7 @@ func (s *GitService) FetchTags(ctx context.Context) error {
 // ValidateGitConfig checks if git user.name and user.email are configured
 func (s *GitService) ValidateGitConfig(ctx context.Context) error {
 	log := logger.FromContext(ctx)
-	repoRoot, err := s.getRepoRoot(ctx)
+	repoRoot, err := s.GetRepoRoot(ctx)
 	if err != nil {
 		log.Error("failed to get repo root for config validation", "error", err)
 		return errors.ErrNotInGitRepo
@@ -482,7 +539,7 @@ func (s *GitService) ValidateGitConfig(ctx context.Context) error {
 // GetGitUserName returns the configured git user.name
 func (s *GitService) GetGitUserName(ctx context.Context) (string, error) {
 	log := logger.FromContext(ctx)
-	repoRoot, _ := s.getRepoRoot(ctx)
+	repoRoot, _ := s.GetRepoRoot(ctx)
 
 	cmd := exec.CommandContext(ctx, "git", "config", "user.name")
 	if repoRoot != "" {
@@ -504,7 +561,7 @@ func (s *GitService) GetGitUserName(ctx context.Context) (string, error) {
 // GetGitUserEmail returns the configured git user.email
 func (s *GitService) GetGitUserEmail(ctx context.Context) (string, error) {
 	log := logger.FromContext(ctx)
-	repoRoot, _ := s.getRepoRoot(ctx)
+	repoRoot, _ := s.GetRepoRoot(ctx)
 
 	cmd := exec.CommandContext(ctx, "git", "config", "user.email")
 	if repoRoot != "" {
@@ -558,8 +615,8 @@ func detectProvider(host string) string {
 	return "unknown"
 }
 
-// getRepoRoot gets the absolute path to the root of the git repository
-func (s *GitService) getRepoRoot(ctx context.Context) (string, error) {
+// GetRepoRoot gets the absolute path to the root of the git repository
+func (s *GitService) GetRepoRoot(ctx context.Context) (string, error) {
 	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--show-toplevel")
 	output, err := cmd.Output()
 	if err != nil {
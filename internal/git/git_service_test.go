@@ -590,8 +590,12 @@ func TestGitService_NewMethods(t *testing.T) {
 
 		service := NewGitService()
 
-		createCommitHelper(t, "one.txt", "one")
 		count, err := service.GetCommitCount(context.Background())
+		assert.NoError(t, err, "a repository without commits is not an error")
+		assert.Equal(t, 0, count)
+
+		createCommitHelper(t, "one.txt", "one")
+		count, err = service.GetCommitCount(context.Background())
 		assert.NoError(t, err)
 		assert.Equal(t, 1, count)
 
@@ -1037,3 +1041,73 @@ func TestGitService_Fallback(t *testing.T) {
 		assert.Equal(t, "fallback@example.com", email)
 	})
 }
+
+func TestGitService_GetDiff_Ignore(t *testing.T) {
+	// Arrange
+	tempDir := setupTestRepo(t)
+	defer cleanupTestRepo(t, tempDir)
+
+	service := NewGitService()
+	files := map[string]string{
+		".matecommitignore": "go.sum\nvendor/\n",
+		"main.go":           "package main\n",
+		"go.sum":            "example.com/dep v1.0.0 h1:abc=\n",
+		"vendor/dep/dep.go": "package dep\n",
+	}
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatalf("Error creando directorio: %v", err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatalf("Error creando archivo de prueba: %v", err)
+		}
+	}
+	if err := exec.Command("git", "add", ".").Run(); err != nil {
+		t.Fatalf("Error haciendo stage de los archivos: %v", err)
+	}
+
+	// Act
+	diff, err := service.GetDiff(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, diff, "diff --git a/main.go b/main.go")
+	assert.Contains(t, diff, "+package main")
+	assert.Contains(t, diff, "diff --git a/go.sum b/go.sum\n# content omitted by .matecommitignore\n")
+	assert.Contains(t, diff, "diff --git a/vendor/dep/dep.go b/vendor/dep/dep.go\n# content omitted by .matecommitignore\n")
+	assert.NotContains(t, diff, "h1:abc=")
+	assert.NotContains(t, diff, "package dep")
+}
+
+func TestGitService_GetCommitHistory(t *testing.T) {
+	// Arrange
+	tempDir := setupTestRepo(t)
+	defer cleanupTestRepo(t, tempDir)
+
+	service := NewGitService()
+	messages := []string{"feat: first commit", "fix(cli): second commit\n\nExplains the fix.\nIn two lines."}
+	for i, message := range messages {
+		if err := os.WriteFile(fmt.Sprintf("file%d.txt", i), []byte("content"), 0644); err != nil {
+			t.Fatalf("Error creando archivo de prueba: %v", err)
+		}
+		if err := exec.Command("git", "add", ".").Run(); err != nil {
+			t.Fatalf("Error haciendo stage: %v", err)
+		}
+		if err := exec.Command("git", "commit", "-m", message).Run(); err != nil {
+			t.Fatalf("Error creando commit: %v", err)
+		}
+	}
+
+	// Act
+	commits, err := service.GetCommitHistory(context.Background(), 10)
+
+	// Assert
+	assert.NoError(t, err)
+	head, headErr := exec.Command("git", "rev-parse", "HEAD").Output()
+	assert.NoError(t, headErr)
+	if assert.Len(t, commits, 2) {
+		assert.Equal(t, "fix(cli): second commit\nExplains the fix.\nIn two lines.", commits[0].Message)
+		assert.Equal(t, "feat: first commit", commits[1].Message)
+		assert.Equal(t, strings.TrimSpace(string(head)), commits[0].Hash)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
@@ -56,22 +57,46 @@ func NewTranslations(defaultLang string, localesPath string) (*Translations, err
 		bundle.MustParseMessageFileBytes(data, file.Name())
 	}
 
-	localize := i18n.NewLocalizer(bundle, defaultLang)
-
-	return &Translations{
-		bundle:   bundle,
-		localize: localize,
-	}, nil
+	t := &Translations{bundle: bundle}
+	if err := t.SetLanguage(defaultLang); err != nil {
+		// Languages without a locale use the English one
+		t.localize = i18n.NewLocalizer(bundle, language.English.String())
+	}
+	return t, nil
 }
 
+// SetLanguage switches to the locale of a language tag, or to the one of its base language
+// when there is no regional locale: pt-BR uses pt. Keys missing in that locale fall back to English.
 func (t *Translations) SetLanguage(lang string) error {
+	locale, ok := t.resolveLocale(lang)
+	if !ok {
+		return fmt.Errorf("unsupported language '%s'", lang)
+	}
+	t.localize = i18n.NewLocalizer(t.bundle, locale)
+	return nil
+}
+
+// Languages returns the tags of the loaded locales.
+func (t *Translations) Languages() []string {
+	var langs []string
 	for _, tag := range t.bundle.LanguageTags() {
-		if tag.String() == lang {
-			t.localize = i18n.NewLocalizer(t.bundle, lang)
-			return nil
+		langs = append(langs, tag.String())
+	}
+	return langs
+}
+
+// resolveLocale finds the loaded locale of a tag, first by exact match and then by its base language
+func (t *Translations) resolveLocale(lang string) (string, bool) {
+	lang = strings.ReplaceAll(strings.TrimSpace(lang), "_", "-")
+	base, _, _ := strings.Cut(lang, "-")
+	for _, candidate := range []string{lang, base} {
+		for _, tag := range t.bundle.LanguageTags() {
+			if strings.EqualFold(tag.String(), candidate) {
+				return tag.String(), true
+			}
 		}
 	}
-	return fmt.Errorf("unsupported language '%s'", lang)
+	return "", false
 }
 
 func (t *Translations) GetMessage(messageID string, count int, templateData interface{}) string {
@@ -83,6 +108,11 @@ func (t *Translations) GetMessage(messageID string, count int, templateData inte
 		TemplateData: templateData,
 	})
 	if err != nil {
+		// go-i18n returns an error along with the text when it falls back to the English message of a
+		// missing key, or to the "other" form when a locale has no message for the plural form of count
+		if localized != "" {
+			return localized
+		}
 		return "Missing translation: " + messageID
 	}
 	return localized
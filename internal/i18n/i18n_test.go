@@ -2,8 +2,15 @@ package i18n
 
 import (
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/thomas-vilte/matecommit/internal/config"
 )
 
 func TestNewTranslations(t *testing.T) {
@@ -111,6 +118,55 @@ func TestSetLanguage(t *testing.T) {
 			t.Error("SetLanguage() should return error with unsupported language")
 		}
 	})
+
+	t.Run("Should use the base language for a regional tag", func(t *testing.T) {
+		// arrange
+		tmpDir := createTempDir(t)
+		defer func() {
+			if err := os.RemoveAll(tmpDir); err != nil {
+				t.Errorf("error deleting directory: %v", err)
+			}
+		}()
+
+		createTestFile(t, tmpDir, "active.en.toml", `[Test]
+		other = "Test"`)
+		createTestFile(t, tmpDir, "active.pt.toml", `[Test]
+		other = "Teste"`)
+
+		trans, err := NewTranslations("en", tmpDir)
+		if err != nil {
+			t.Fatal("Error in test setup:", err)
+		}
+
+		for _, lang := range []string{"pt-BR", "pt_BR", "PT"} {
+			// act
+			err = trans.SetLanguage(lang)
+
+			// assert
+			if err != nil {
+				t.Errorf("SetLanguage(%q) should not return error, got: %v", lang, err)
+			}
+			if result := trans.GetMessage("Test", 0, nil); result != "Teste" {
+				t.Errorf("GetMessage() with %q = %v, want %v", lang, result, "Teste")
+			}
+		}
+	})
+
+	t.Run("Should fail with an unsupported regional tag", func(t *testing.T) {
+		// arrange
+		trans, err := NewTranslations("en", "")
+		if err != nil {
+			t.Fatal("Error in test setup:", err)
+		}
+
+		// act
+		err = trans.SetLanguage("ja-JP")
+
+		// assert
+		if err == nil {
+			t.Error("SetLanguage() should return error with unsupported language")
+		}
+	})
 }
 
 func TestGetMessage(t *testing.T) {
@@ -230,6 +286,136 @@ func TestGetMessage(t *testing.T) {
 			t.Errorf("GetMessage() = %v, want %v", result, expected)
 		}
 	})
+
+	t.Run("Should fall back to English for keys missing in the locale", func(t *testing.T) {
+		// arrange
+		tmpDir := createTempDir(t)
+		defer func() {
+			if err := os.RemoveAll(tmpDir); err != nil {
+				t.Errorf("error deleting directory: %v", err)
+			}
+		}()
+
+		createTestFile(t, tmpDir, "active.en.toml", `
+		[Test]
+		other = "Test"
+		[OnlyEnglish]
+		other = "Only in English"`)
+		createTestFile(t, tmpDir, "active.fr.toml", `[Test]
+		other = "Essai"`)
+
+		trans, err := NewTranslations("fr", tmpDir)
+		if err != nil {
+			t.Fatal("Error in test setup:", err)
+		}
+
+		// act
+		result := trans.GetMessage("OnlyEnglish", 0, nil)
+
+		// assert
+		expected := "Only in English"
+		if result != expected {
+			t.Errorf("GetMessage() = %v, want %v", result, expected)
+		}
+	})
+}
+
+func TestEmbeddedLocales(t *testing.T) {
+	t.Run("Should embed a locale for every supported language", func(t *testing.T) {
+		// arrange
+		trans, err := NewTranslations(config.DefaultLanguage, "")
+		if err != nil {
+			t.Fatal("Error in test setup:", err)
+		}
+
+		// act
+		loaded := trans.Languages()
+
+		// assert
+		for _, lang := range config.SupportedLanguages() {
+			if !slices.Contains(loaded, lang) {
+				t.Errorf("no embedded locale for supported language %q (loaded: %v)", lang, loaded)
+			}
+		}
+	})
+
+	t.Run("Should define every English key in each locale", func(t *testing.T) {
+		// arrange
+		english := readLocaleKeys(t, "active.en.toml")
+
+		files, err := readEmbeddedLocales()
+		if err != nil {
+			t.Fatal("Error reading embedded locales:", err)
+		}
+
+		for _, file := range files {
+			if file.Name() == "active.en.toml" {
+				continue
+			}
+
+			// act
+			keys := readLocaleKeys(t, file.Name())
+
+			// assert
+			var missing []string
+			for key := range english {
+				if !keys[key] {
+					missing = append(missing, key)
+				}
+			}
+			if len(missing) > 0 {
+				sort.Strings(missing)
+				t.Errorf("%s is missing %d keys:\n  %s", file.Name(), len(missing), strings.Join(missing, "\n  "))
+			}
+		}
+	})
+}
+
+// readLocaleKeys returns the message IDs of an embedded locale file
+func readLocaleKeys(t *testing.T, name string) map[string]bool {
+	t.Helper()
+
+	data, err := localesFS.ReadFile(path.Join("locales", name))
+	if err != nil {
+		t.Fatalf("Could not read %s: %v", name, err)
+	}
+
+	var raw map[string]interface{}
+	if err := toml.Unmarshal(data, &raw); err != nil {
+		t.Fatalf("Could not parse %s: %v", name, err)
+	}
+
+	keys := make(map[string]bool)
+	collectLocaleKeys("", raw, keys)
+	return keys
+}
+
+func collectLocaleKeys(prefix string, table map[string]interface{}, keys map[string]bool) {
+	for key, value := range table {
+		id := key
+		if prefix != "" {
+			id = prefix + "." + key
+		}
+
+		nested, ok := value.(map[string]interface{})
+		if ok && !isPluralMessage(nested) {
+			collectLocaleKeys(id, nested, keys)
+			continue
+		}
+		keys[id] = true
+	}
+}
+
+// isPluralMessage reports whether a table is a message with plural forms rather than a group of messages
+func isPluralMessage(table map[string]interface{}) bool {
+	for key := range table {
+		switch key {
+		case "zero", "one", "two", "few", "many", "other":
+		default:
+			return false
+		}
+	}
+	return true
 }
 
 func TestNewTranslations_Errors(t *testing.T) {
@@ -0,0 +1,226 @@
+package ignore
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// FileName is the ignore file read from the root of the repository.
+const FileName = ".matecommitignore"
+
+// OmittedMarker replaces the content of an ignored file in a diff.
+const OmittedMarker = "# content omitted by " + FileName
+
+// rule is one line of the ignore file.
+type rule struct {
+	pattern *regexp.Regexp
+	negate  bool
+}
+
+// Matcher decides which files are kept out of the AI context, with gitignore syntax.
+// The zero value and a nil Matcher ignore nothing.
+type Matcher struct {
+	rules []rule
+}
+
+// Load reads the ignore file of the repository at root. A missing file ignores nothing.
+func Load(root string) (*Matcher, error) {
+	data, err := os.ReadFile(filepath.Join(root, FileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Matcher{}, nil
+		}
+		return nil, fmt.Errorf("error reading %s: %w", FileName, err)
+	}
+	return Parse(string(data)), nil
+}
+
+// Parse builds a matcher from the content of an ignore file.
+func Parse(content string) *Matcher {
+	m := &Matcher{}
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		negate := false
+		if strings.HasPrefix(line, "!") {
+			negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+			line = line[1:]
+		}
+
+		if re := compile(line); re != nil {
+			m.rules = append(m.rules, rule{pattern: re, negate: negate})
+		}
+	}
+	return m
+}
+
+// Empty reports whether the matcher has no rules.
+func (m *Matcher) Empty() bool {
+	return m == nil || len(m.rules) == 0
+}
+
+// Match reports whether path, relative to the repository root, is ignored.
+// A file is ignored when it or one of its parent directories matches; the last matching rule wins.
+func (m *Matcher) Match(path string) bool {
+	if m.Empty() {
+		return false
+	}
+
+	path = strings.TrimPrefix(filepath.ToSlash(path), "/")
+	ignored := false
+	for _, r := range m.rules {
+		if r.pattern.MatchString(path) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+// FilterDiff replaces the content of the ignored files of a unified diff with OmittedMarker.
+// The "diff --git" line of every file is kept, so the AI still knows the file changed. Skipping ends
+// at the next file or at the first line that is not part of a diff, such as the commit headers of a
+// PR diff. It returns the filtered diff and the paths whose content was omitted.
+func (m *Matcher) FilterDiff(diff string) (string, []string) {
+	if m.Empty() || diff == "" {
+		return diff, nil
+	}
+
+	var (
+		sb       strings.Builder
+		omitted  []string
+		skipping bool
+	)
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			skipping = false
+			if path := diffPath(line); m.Match(path) {
+				skipping = true
+				omitted = append(omitted, path)
+				sb.WriteString(line)
+				if !strings.HasSuffix(line, "\n") {
+					sb.WriteString("\n")
+				}
+				sb.WriteString(OmittedMarker + "\n")
+				continue
+			}
+		}
+		if skipping && !isDiffLine(line) {
+			skipping = false
+		}
+		if !skipping {
+			sb.WriteString(line)
+		}
+	}
+	return sb.String(), omitted
+}
+
+// diffLinePrefixes start the extended header and hunk lines of a file in a git diff
+var diffLinePrefixes = []string{
+	" ", "+", "-", "@@", "\\",
+	"index ", "old mode ", "new mode ", "deleted file mode ", "new file mode ",
+	"similarity index ", "dissimilarity index ", "rename from ", "rename to ",
+	"copy from ", "copy to ", "Binary files ",
+}
+
+// isDiffLine reports whether a line can belong to the diff of a file. Empty lines count, since some
+// tools strip the space of empty context lines.
+func isDiffLine(line string) bool {
+	if strings.TrimRight(line, "\r\n") == "" {
+		return true
+	}
+	for _, prefix := range diffLinePrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// diffPath returns the path of the "b/" side of a "diff --git a/x b/x" line.
+func diffPath(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) < 4 {
+		return ""
+	}
+	return strings.TrimPrefix(fields[3], "b/")
+}
+
+// compile translates a gitignore pattern into a regular expression over slash-separated paths.
+// The expression also matches everything under a matching directory.
+func compile(pattern string) *regexp.Regexp {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	if pattern == "" {
+		return nil
+	}
+
+	// A slash at the start or in the middle anchors the pattern to the root; otherwise it matches at any depth
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var sb strings.Builder
+	sb.WriteString("^")
+	if !anchored {
+		sb.WriteString("(?:.*/)?")
+	}
+	sb.WriteString(globToRegexp(pattern))
+	if dirOnly {
+		sb.WriteString("/.*$")
+	} else {
+		sb.WriteString("(?:/.*)?$")
+	}
+
+	re, err := regexp.Compile(sb.String())
+	if err != nil {
+		return nil
+	}
+	return re
+}
+
+// globToRegexp translates the wildcards of a gitignore pattern (*, ?, **, [...]).
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				sb.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + class + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(glob):
+			i++
+			sb.WriteString(regexp.QuoteMeta(string(glob[i])))
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,139 @@
+package ignore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatcher_Match(t *testing.T) {
+	matcher := Parse(`# lockfiles
+go.sum
+package-lock.json
+
+# generated code
+*.pb.go
+!keep.pb.go
+/build/
+vendor/
+docs/**/*.png
+internal/gen/
+file?.txt
+[Mm]akefile.gen
+\#literal
+`)
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "go.sum", want: true},
+		{path: "tools/go.sum", want: true},
+		{path: "web/package-lock.json", want: true},
+		{path: "api/v1/service.pb.go", want: true},
+		{path: "api/v1/keep.pb.go", want: false},
+		{path: "build/output.bin", want: true},
+		{path: "cmd/build/main.go", want: false},
+		{path: "vendor/github.com/dep/dep.go", want: true},
+		{path: "third_party/vendor/dep.go", want: true},
+		{path: "docs/img/logo.png", want: true},
+		{path: "docs/logo.png", want: true},
+		{path: "internal/gen/types.go", want: true},
+		{path: "pkg/internal/gen/types.go", want: false},
+		{path: "file1.txt", want: true},
+		{path: "file10.txt", want: false},
+		{path: "Makefile.gen", want: true},
+		{path: "#literal", want: true},
+		{path: "main.go", want: false},
+		{path: "vendor.go", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, matcher.Match(tt.path))
+		})
+	}
+}
+
+func TestMatcher_Empty(t *testing.T) {
+	var nilMatcher *Matcher
+
+	assert.True(t, nilMatcher.Empty())
+	assert.False(t, nilMatcher.Match("go.sum"))
+	assert.True(t, Parse("# only comments\n\n").Empty())
+}
+
+func TestMatcher_FilterDiff(t *testing.T) {
+	// Arrange
+	diff := "diff --git a/main.go b/main.go\n" +
+		"index 123..456 100644\n" +
+		"--- a/main.go\n" +
+		"+++ b/main.go\n" +
+		"@@ -1 +1 @@\n" +
+		"-package old\n" +
+		"+package main\n" +
+		"diff --git a/go.sum b/go.sum\n" +
+		"index 789..abc 100644\n" +
+		"--- a/go.sum\n" +
+		"+++ b/go.sum\n" +
+		"@@ -1 +1,2 @@\n" +
+		"+example.com/dep v1.0.0 h1:abc=\n"
+	matcher := Parse("go.sum\n")
+
+	// Act
+	filtered, omitted := matcher.FilterDiff(diff)
+
+	// Assert
+	assert.Equal(t, []string{"go.sum"}, omitted)
+	assert.Contains(t, filtered, "+package main\n")
+	assert.Contains(t, filtered, "diff --git a/go.sum b/go.sum\n"+OmittedMarker+"\n")
+	assert.NotContains(t, filtered, "h1:abc=")
+}
+
+func TestMatcher_FilterDiff_KeepsCommitHeaders(t *testing.T) {
+	// Arrange
+	diff := "\n# Commit: 1111aaaa\n" +
+		"# Message: update dependencies\n\n" +
+		"diff --git a/package-lock.json b/package-lock.json\n" +
+		"@@ -1 +1 @@\n" +
+		"-\"version\": \"1.0.0\"\n" +
+		"+\"version\": \"1.1.0\"\n" +
+		"\n# Commit: 2222bbbb\n" +
+		"# Message: fix login redirect\n\n" +
+		"diff --git a/login.go b/login.go\n" +
+		"@@ -1 +1 @@\n" +
+		"-return \"/\"\n" +
+		"+return next\n"
+	matcher := Parse("package-lock.json\n")
+
+	// Act
+	filtered, omitted := matcher.FilterDiff(diff)
+
+	// Assert
+	assert.Equal(t, []string{"package-lock.json"}, omitted)
+	assert.NotContains(t, filtered, "1.1.0")
+	assert.Contains(t, filtered, "# Commit: 2222bbbb\n# Message: fix login redirect\n")
+	assert.Contains(t, filtered, "+return next\n")
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("missing file ignores nothing", func(t *testing.T) {
+		matcher, err := Load(t.TempDir())
+
+		require.NoError(t, err)
+		assert.True(t, matcher.Empty())
+	})
+
+	t.Run("reads the file at the root", func(t *testing.T) {
+		root := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(root, FileName), []byte("*.lock\n"), 0644))
+
+		matcher, err := Load(root)
+
+		require.NoError(t, err)
+		assert.True(t, matcher.Match("yarn.lock"))
+	})
+}
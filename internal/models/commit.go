@@ -15,6 +15,8 @@ type (
 		TicketInfo    *TicketInfo
 		IssueInfo     *Issue
 		RecentHistory string
+		// Style is the commit convention learned from the history; nil when it is unknown
+		Style *CommitStyle
 	}
 
 	GitChange struct {
@@ -0,0 +1,48 @@
+package models
+
+// Ticket placements of a CommitStyle
+const (
+	TicketPlacementNone   = "none"
+	TicketPlacementPrefix = "prefix"
+	TicketPlacementScope  = "scope"
+	TicketPlacementSuffix = "suffix"
+)
+
+// Casings of a CommitStyle
+const (
+	CasingLower = "lower"
+	CasingUpper = "upper"
+	CasingMixed = "mixed"
+)
+
+type (
+	// CommitStyle describes the commit conventions a repository actually uses, learned from its history.
+	CommitStyle struct {
+		// HistorySize is how many commits the repository had when the profile was computed
+		HistorySize int `json:"history_size"`
+		CommitCount int `json:"commit_count"`
+		// Conventional is the share of subjects written as type(scope): description
+		Conventional float64      `json:"conventional"`
+		Types        []StyleCount `json:"types"`
+		Scopes       []StyleCount `json:"scopes"`
+		// Emoji is the share of subjects that start with an emoji or a :shortcode:
+		Emoji float64 `json:"emoji"`
+		// Casing is the usual case of the first letter of the description
+		Casing string `json:"casing"`
+		// TicketPlacement tells where ticket references (PROJ-123, #42) usually go
+		TicketPlacement string `json:"ticket_placement"`
+		TicketExample   string `json:"ticket_example,omitempty"`
+		// BodyShare is the share of commits that have a body
+		BodyShare        float64 `json:"body_share"`
+		AverageBodyLines int     `json:"average_body_lines"`
+		AverageSubject   int     `json:"average_subject"`
+		// Examples are representative subjects of the history
+		Examples []string `json:"examples"`
+	}
+
+	// StyleCount is how many commits use a type or scope.
+	StyleCount struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+)
@@ -8,4 +8,7 @@ type TokenUsage struct {
 	Model        string  `json:"model,omitempty"`
 	CacheHit     bool    `json:"cache_hit,omitempty"`
 	DurationMs   int64   `json:"duration_ms,omitempty"`
+	Provider     string  `json:"provider,omitempty"`
+	Attempts     int     `json:"attempts,omitempty"`
+	Repairs      int     `json:"repairs,omitempty"`
 }
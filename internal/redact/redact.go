@@ -0,0 +1,270 @@
+package redact
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/thomas-vilte/matecommit/internal/config"
+)
+
+const (
+	// minEntropyLength is the shortest token checked by the entropy detector
+	minEntropyLength = 20
+	// entropyThreshold is the Shannon entropy, in bits per character, above which a token looks random.
+	// Hex strings (commit hashes, checksums) top out at 4 bits and never reach it.
+	entropyThreshold = 4.0
+	// maxWordRun is the average length of the lowercase runs of a random token. Identifiers are made
+	// of words (CostAwareWrapper, max_tokens) and have longer runs than generated keys.
+	maxWordRun = 2.5
+	// entropyDetector is the name the high-entropy findings are reported under
+	entropyDetector = "high-entropy"
+)
+
+// detector finds one kind of secret. When the pattern has a "secret" group only that group is masked,
+// so the key name around it (API_KEY=) stays readable for the model.
+type detector struct {
+	name    string
+	pattern *regexp.Regexp
+	// skip discards matches that are not secrets
+	skip func(value string) bool
+}
+
+// builtinDetectors cover the key formats that show up most in staged diffs.
+var builtinDetectors = []detector{
+	{name: "private-key", pattern: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----(?s:.*?)(?:-----END [A-Z ]*PRIVATE KEY-----|\z)`)},
+	{name: "aws-access-key", pattern: regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA)[0-9A-Z]{16}\b`)},
+	{name: "aws-secret-key", pattern: regexp.MustCompile(`(?i)aws_?secret_?(?:access_?)?key\W{1,5}(?P<secret>[A-Za-z0-9/+=]{40})\b`)},
+	{name: "github-token", pattern: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,255}|github_pat_[A-Za-z0-9_]{22,255})\b`)},
+	{name: "google-api-key", pattern: regexp.MustCompile(`\bAIza[0-9A-Za-z_\-]{35}\b`)},
+	{name: "jwt", pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+	{name: "secret-assignment", pattern: regexp.MustCompile(`(?im)(?:api[_-]?key|secret|token|passw(?:or)?d)["']?\s*[:=]\s*["']?(?P<secret>[^\s"'$(){},;]{8,})(?:["'\s,;]|$)`), skip: isCodeReference},
+	{name: "email", pattern: regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}\b`)},
+}
+
+var (
+	// entropyCandidate matches the tokens the entropy detector looks at
+	entropyCandidate = regexp.MustCompile(`[A-Za-z0-9+/_\-]{20,}={0,2}`)
+	// codeReference matches values that read a variable or field instead of holding a secret (cfg.Token)
+	codeReference = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(?:\.[A-Za-z_][A-Za-z0-9_]*)+$`)
+)
+
+// Finding is one masked value.
+type Finding struct {
+	Detector string
+	// Line is the 1-based line of the prompt the value was found on
+	Line int
+	// Preview shows the first characters of the value and its length, never the whole secret
+	Preview string
+}
+
+// Report lists everything a Redact call masked.
+type Report struct {
+	Findings []Finding
+}
+
+// Empty reports whether nothing was masked.
+func (r Report) Empty() bool {
+	return len(r.Findings) == 0
+}
+
+// CountByDetector returns how many values every detector masked.
+func (r Report) CountByDetector() map[string]int {
+	counts := make(map[string]int)
+	for _, finding := range r.Findings {
+		counts[finding.Detector]++
+	}
+	return counts
+}
+
+// Redactor masks secrets and personal data in the prompts before they leave the machine.
+type Redactor struct {
+	detectors []detector
+	entropy   bool
+	disabled  bool
+}
+
+// New creates a redactor with the built-in detectors plus the patterns of the configuration.
+func New(cfg config.RedactionConfig) (*Redactor, error) {
+	detectors := append([]detector{}, builtinDetectors...)
+	for _, p := range cfg.Patterns {
+		re, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", p.Name, err)
+		}
+		name := p.Name
+		if name == "" {
+			name = "custom"
+		}
+		detectors = append(detectors, detector{name: name, pattern: re})
+	}
+
+	return &Redactor{
+		detectors: detectors,
+		entropy:   !cfg.DisableEntropy,
+		disabled:  cfg.Disabled,
+	}, nil
+}
+
+// Redact replaces every secret of text with a [REDACTED:<detector>] placeholder.
+// Detectors run in order, so a value is reported by the first one that matches it.
+func (r *Redactor) Redact(text string) (string, Report) {
+	var report Report
+	if r == nil || r.disabled {
+		return text, report
+	}
+
+	for _, d := range r.detectors {
+		text = r.apply(text, d, &report)
+	}
+	if r.entropy {
+		text = r.applyEntropy(text, &report)
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		return report.Findings[i].Line < report.Findings[j].Line
+	})
+	return text, report
+}
+
+// apply masks the matches of a pattern, or only their "secret" group when the pattern has one.
+func (r *Redactor) apply(text string, d detector, report *Report) string {
+	secretGroup := d.pattern.SubexpIndex("secret")
+	matches := d.pattern.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if secretGroup > 0 {
+			start, end = m[2*secretGroup], m[2*secretGroup+1]
+			if start < 0 {
+				continue
+			}
+		}
+		if isPlaceholder(text[start:end]) || (d.skip != nil && d.skip(text[start:end])) {
+			continue
+		}
+
+		report.Findings = append(report.Findings, Finding{
+			Detector: d.name,
+			Line:     strings.Count(text[:start], "\n") + 1,
+			Preview:  preview(text[start:end]),
+		})
+		sb.WriteString(text[last:start])
+		sb.WriteString(placeholder(d.name))
+		last = end
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
+
+// applyEntropy masks long tokens that mix cases and digits and look random.
+func (r *Redactor) applyEntropy(text string, report *Report) string {
+	var sb strings.Builder
+	last := 0
+	for _, m := range entropyCandidate.FindAllStringIndex(text, -1) {
+		token := text[m[0]:m[1]]
+		if !looksRandom(token) {
+			continue
+		}
+
+		report.Findings = append(report.Findings, Finding{
+			Detector: entropyDetector,
+			Line:     strings.Count(text[:m[0]], "\n") + 1,
+			Preview:  preview(token),
+		})
+		sb.WriteString(text[last:m[0]])
+		sb.WriteString(placeholder(entropyDetector))
+		last = m[1]
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
+
+// looksRandom reports whether a token has the shape of a generated key rather than an identifier.
+func looksRandom(token string) bool {
+	if len(token) < minEntropyLength {
+		return false
+	}
+
+	var hasUpper, hasLower, hasDigit bool
+	for _, c := range token {
+		switch {
+		case c >= 'A' && c <= 'Z':
+			hasUpper = true
+		case c >= 'a' && c <= 'z':
+			hasLower = true
+		case c >= '0' && c <= '9':
+			hasDigit = true
+		}
+	}
+	if !hasUpper || !hasLower || !hasDigit {
+		return false
+	}
+
+	return shannonEntropy(token) > entropyThreshold && averageLowercaseRun(token) < maxWordRun
+}
+
+// averageLowercaseRun returns the average length of the runs of lowercase letters of s.
+func averageLowercaseRun(s string) float64 {
+	runs, total, current := 0, 0, 0
+	for _, c := range s + " " {
+		if c >= 'a' && c <= 'z' {
+			current++
+			continue
+		}
+		if current > 0 {
+			runs++
+			total += current
+			current = 0
+		}
+	}
+	if runs == 0 {
+		return 0
+	}
+	return float64(total) / float64(runs)
+}
+
+// shannonEntropy returns the entropy of s in bits per character.
+func shannonEntropy(s string) float64 {
+	counts := make(map[rune]int)
+	for _, c := range s {
+		counts[c]++
+	}
+
+	var entropy float64
+	length := float64(len(s))
+	for _, count := range counts {
+		p := float64(count) / length
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// isCodeReference reports whether an assigned value is an expression such as cfg.Token.
+func isCodeReference(value string) bool {
+	return codeReference.MatchString(value)
+}
+
+func placeholder(name string) string {
+	return "[REDACTED:" + name + "]"
+}
+
+func isPlaceholder(s string) bool {
+	return strings.HasPrefix(s, "[REDACTED:")
+}
+
+// preview keeps the first characters of a secret so the user can recognize it.
+func preview(secret string) string {
+	first := strings.SplitN(secret, "\n", 2)[0]
+	if len(first) > 4 {
+		first = first[:4]
+	}
+	return fmt.Sprintf("%s… (%d chars)", first, len(secret))
+}
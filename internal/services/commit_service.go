@@ -27,12 +27,18 @@ type commitGitService interface {
 	GetCurrentBranch(ctx context.Context) (string, error)
 }
 
+// commitStyleProfiler learns the commit conventions of the repository.
+type commitStyleProfiler interface {
+	Profile(ctx context.Context) (*models.CommitStyle, error)
+}
+
 type CommitService struct {
 	git           commitGitService
 	ai            ai.CommitSummarizer
 	ticketManager tickets.TicketManager
 	vcsClient     vcs.VCSClient
 	config        *config.Config
+	styleProfiler commitStyleProfiler
 }
 
 type Option func(*CommitService)
@@ -55,6 +61,13 @@ func WithConfig(cfg *config.Config) Option {
 	}
 }
 
+// WithStyleProfiler makes the suggestions follow the commit style learned from the history.
+func WithStyleProfiler(profiler commitStyleProfiler) Option {
+	return func(s *CommitService) {
+		s.styleProfiler = profiler
+	}
+}
+
 func NewCommitService(gitSvc commitGitService, aiSvc ai.CommitSummarizer, opts ...Option) *CommitService {
 	s := &CommitService{
 		git: gitSvc,
@@ -140,6 +153,16 @@ func (s *CommitService) buildCommitInfo(ctx context.Context, issueNumber int, pr
 		RecentHistory: strings.Join(recentHistory, "\n"),
 	}
 
+	if s.styleProfiler != nil {
+		style, err := s.styleProfiler.Profile(ctx)
+		if err != nil {
+			log.Warn("failed to learn the commit style, using the default guidelines",
+				"error", err)
+		} else {
+			commitInfo.Style = style
+		}
+	}
+
 	if s.config != nil && s.config.UseTicket {
 		ticketID, err := s.getTicketIDFromBranch(ctx)
 		if err != nil {
@@ -293,6 +293,45 @@ func TestCommitService_GenerateSuggestions(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("learned commit style is passed to the AI", func(t *testing.T) {
+		mockGit, mockAI, _, _, cfg := setupTest(t)
+		cfg.UseTicket = false
+		style := &models.CommitStyle{CommitCount: 20, Conventional: 1}
+
+		mockGit.On("GetChangedFiles", mock.Anything).Return([]string{"f.go"}, nil)
+		mockGit.On("GetDiff", mock.Anything).Return("diff", nil)
+		mockGit.On("GetRecentCommitMessages", mock.Anything, 10).Return([]string{"history"}, nil)
+		mockGit.On("GetCurrentBranch", mock.Anything).Return("main", nil)
+		mockGit.On("GetRecentCommitMessages", mock.Anything, 5).Return([]string{}, nil)
+
+		mockAI.On("GenerateSuggestions", mock.Anything, mock.MatchedBy(func(info models.CommitInfo) bool {
+			return info.Style == style
+		}), 3).Return([]models.CommitSuggestion{}, nil)
+
+		service := NewCommitService(mockGit, mockAI, WithConfig(cfg), WithStyleProfiler(stubStyleProfiler{style: style}))
+		_, err := service.GenerateSuggestions(context.Background(), 3, 0, func(e models.ProgressEvent) {})
+		assert.NoError(t, err)
+		mockAI.AssertExpectations(t)
+	})
+
+	t.Run("style profiler errors fall back to the default guidelines", func(t *testing.T) {
+		mockGit, mockAI, _, _, cfg := setupTest(t)
+		cfg.UseTicket = false
+
+		mockGit.On("GetChangedFiles", mock.Anything).Return([]string{"f.go"}, nil)
+		mockGit.On("GetDiff", mock.Anything).Return("diff", nil)
+		mockGit.On("GetRecentCommitMessages", mock.Anything, 10).Return([]string{"history"}, nil)
+		mockGit.On("GetCurrentBranch", mock.Anything).Return("main", nil)
+		mockGit.On("GetRecentCommitMessages", mock.Anything, 5).Return([]string{}, nil)
+
+		mockAI.On("GenerateSuggestions", mock.Anything, mock.MatchedBy(func(info models.CommitInfo) bool {
+			return info.Style == nil
+		}), 3).Return([]models.CommitSuggestion{}, nil)
+
+		service := NewCommitService(mockGit, mockAI, WithConfig(cfg), WithStyleProfiler(stubStyleProfiler{err: errors.New("no HEAD")}))
+		_, err := service.GenerateSuggestions(context.Background(), 3, 0, func(e models.ProgressEvent) {})
+		assert.NoError(t, err)
+	})
 }
 
 func TestCommitService_GenerateSuggestionsWithIssue(t *testing.T) {
@@ -359,3 +398,12 @@ func TestCommitService_IssueDetection(t *testing.T) {
 		mockVCS.AssertCalled(t, "GetIssue", mock.Anything, 123)
 	})
 }
+
+type stubStyleProfiler struct {
+	style *models.CommitStyle
+	err   error
+}
+
+func (s stubStyleProfiler) Profile(ctx context.Context) (*models.CommitStyle, error) {
+	return s.style, s.err
+}
@@ -0,0 +1,140 @@
+package cost
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Dimensions of GetBreakdown
+const (
+	GroupByCommand = "command"
+	GroupByRepo    = "repo"
+	GroupByBranch  = "branch"
+	GroupByModel   = "model"
+	GroupByDay     = "day"
+	// GroupByUser is only meaningful for merged histories
+	GroupByUser = "user"
+)
+
+// GroupDimensions lists the dimensions accepted by GetBreakdown
+func GroupDimensions() []string {
+	return []string{GroupByCommand, GroupByRepo, GroupByBranch, GroupByModel, GroupByDay, GroupByUser}
+}
+
+// Period selects the records timestamped in [Since, Until); zero bounds are open
+type Period struct {
+	Since time.Time
+	Until time.Time
+}
+
+// MonthOf returns the calendar month that contains t
+func MonthOf(t time.Time) Period {
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	return Period{Since: start, Until: start.AddDate(0, 1, 0)}
+}
+
+// Contains reports whether t falls inside the period
+func (p Period) Contains(t time.Time) bool {
+	return (p.Since.IsZero() || !t.Before(p.Since)) && (p.Until.IsZero() || t.Before(p.Until))
+}
+
+// GroupStats is the usage of the calls that share one value of a dimension. Key is empty for
+// calls recorded without it, such as those made by older versions or outside a repository.
+type GroupStats struct {
+	Key          string
+	CallCount    int
+	TotalCost    float64
+	TotalTokens  int
+	AvgCost      float64
+	CacheHitRate float64
+}
+
+type GroupBreakdown struct {
+	By         string
+	Groups     []GroupStats
+	TotalCalls int
+	TotalCost  float64
+}
+
+// GetBreakdown returns the usage of a period grouped by one of GroupDimensions. Days are listed in
+// chronological order and every other dimension from the most to the least expensive.
+func (m *Manager) GetBreakdown(by string, period Period) (*GroupBreakdown, error) {
+	keyOf, err := groupKey(by)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := m.loadRange(period.Since, period.Until)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]*GroupStats)
+	breakdown := &GroupBreakdown{By: by}
+	for _, record := range records {
+		// Failed fallback attempts are kept for auditing but are not calls
+		if !period.Contains(record.Timestamp) || record.Error != "" {
+			continue
+		}
+
+		breakdown.TotalCost += record.CostUSD
+
+		key := keyOf(record)
+		stats, exists := groups[key]
+		if !exists {
+			stats = &GroupStats{Key: key}
+			groups[key] = stats
+		}
+		stats.TotalCost += record.CostUSD
+		stats.TotalTokens += record.TokensInput + record.TokensOutput
+
+		// Repairs add to the cost of the call they fix, as in GetCacheStats
+		if record.Repair > 0 {
+			continue
+		}
+		breakdown.TotalCalls++
+		stats.CallCount++
+		if record.CacheHit {
+			stats.CacheHitRate++
+		}
+	}
+
+	breakdown.Groups = make([]GroupStats, 0, len(groups))
+	for _, stats := range groups {
+		if stats.CallCount > 0 {
+			stats.AvgCost = stats.TotalCost / float64(stats.CallCount)
+			stats.CacheHitRate = (stats.CacheHitRate / float64(stats.CallCount)) * 100
+		}
+		breakdown.Groups = append(breakdown.Groups, *stats)
+	}
+
+	sort.Slice(breakdown.Groups, func(i, j int) bool {
+		a, b := breakdown.Groups[i], breakdown.Groups[j]
+		if by != GroupByDay && a.TotalCost != b.TotalCost {
+			return a.TotalCost > b.TotalCost
+		}
+		return a.Key < b.Key
+	})
+	return breakdown, nil
+}
+
+// groupKey returns the function that reads a dimension from a record
+func groupKey(by string) (func(ActivityRecord) string, error) {
+	switch by {
+	case GroupByCommand:
+		return func(r ActivityRecord) string { return r.Command }, nil
+	case GroupByRepo:
+		return func(r ActivityRecord) string { return r.Repo }, nil
+	case GroupByBranch:
+		return func(r ActivityRecord) string { return r.Branch }, nil
+	case GroupByModel:
+		return func(r ActivityRecord) string { return r.Model }, nil
+	case GroupByUser:
+		return func(r ActivityRecord) string { return r.User }, nil
+	case GroupByDay:
+		return func(r ActivityRecord) string { return r.Timestamp.Local().Format("2006-01-02") }, nil
+	default:
+		return nil, fmt.Errorf("unknown breakdown dimension %q", by)
+	}
+}
@@ -0,0 +1,75 @@
+package cost
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_GetBreakdown(t *testing.T) {
+	m, _ := newTestHistoryManager(t)
+	now := time.Now()
+	lastMonth := time.Date(now.Year(), now.Month(), 1, 12, 0, 0, 0, now.Location()).AddDate(0, -1, 0)
+	records := []ActivityRecord{
+		{Timestamp: lastMonth, Command: "suggest", Repo: "acme/api", Branch: "main", Model: "gemini-2.5-flash", CostUSD: 4},
+		{Timestamp: now, Command: "suggest", Repo: "acme/api", Branch: "feature/login", Model: "gemini-2.5-flash", CostUSD: 1, CacheHit: true},
+		{Timestamp: now, Command: "summarize-pr", Repo: "acme/web", Branch: "main", Model: "gpt-4o", CostUSD: 2},
+		{Timestamp: now, Command: "summarize-pr", Repo: "acme/web", Branch: "main", Model: "gpt-4o", CostUSD: 0.25, Repair: 1},
+		{Timestamp: now, Command: "suggest", CostUSD: 0.5},
+		{Timestamp: now, Command: "suggest", Repo: "acme/api", CostUSD: 0, Error: "timeout"},
+	}
+	for _, record := range records {
+		require.NoError(t, m.SaveActivity(record))
+	}
+	// Moves last month into its own file, so the period has to reach it
+	require.NoError(t, m.history().rotate(now.AddDate(0, 1, 0)))
+
+	t.Run("groups the month by repository", func(t *testing.T) {
+		// Act
+		breakdown, err := m.GetBreakdown(GroupByRepo, MonthOf(now))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 3, breakdown.TotalCalls, "failed attempts and repairs are not calls")
+		assert.InDelta(t, 3.75, breakdown.TotalCost, 1e-9)
+		require.Len(t, breakdown.Groups, 3)
+		assert.Equal(t, "acme/web", breakdown.Groups[0].Key)
+		assert.Equal(t, 1, breakdown.Groups[0].CallCount)
+		assert.InDelta(t, 2.25, breakdown.Groups[0].AvgCost, 1e-9, "the repair is part of the call it fixes")
+		assert.Equal(t, "acme/api", breakdown.Groups[1].Key)
+		assert.Equal(t, 100.0, breakdown.Groups[1].CacheHitRate)
+		assert.Equal(t, "", breakdown.Groups[2].Key, "calls without a repository are grouped apart")
+	})
+
+	t.Run("filters by period across rotated months", func(t *testing.T) {
+		// Act
+		breakdown, err := m.GetBreakdown(GroupByBranch, Period{Since: lastMonth.Add(-time.Hour)})
+
+		// Assert
+		require.NoError(t, err)
+		require.NotEmpty(t, breakdown.Groups)
+		assert.Equal(t, "main", breakdown.Groups[0].Key)
+		assert.InDelta(t, 6.25, breakdown.Groups[0].TotalCost, 1e-9)
+	})
+
+	t.Run("lists days in chronological order", func(t *testing.T) {
+		// Act
+		breakdown, err := m.GetBreakdown(GroupByDay, Period{})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, breakdown.Groups, 2)
+		assert.Equal(t, lastMonth.Format("2006-01-02"), breakdown.Groups[0].Key)
+		assert.Equal(t, now.Format("2006-01-02"), breakdown.Groups[1].Key)
+	})
+
+	t.Run("rejects unknown dimensions", func(t *testing.T) {
+		// Act
+		_, err := m.GetBreakdown("weekday", Period{})
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,99 @@
+package cost
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Scopes of a BudgetCheck
+const (
+	BudgetScopeDaily       = "daily"
+	BudgetScopeMonthly     = "monthly"
+	BudgetScopeCommand     = "command"
+	BudgetScopeRepoDaily   = "repo_daily"
+	BudgetScopeRepoMonthly = "repo_monthly"
+)
+
+// BudgetLimits are the caps checked before a call on top of the daily budget of the manager.
+// Zero values are not limited.
+type BudgetLimits struct {
+	Monthly float64
+	// CommandDaily caps what the command of the call spent today
+	CommandDaily float64
+	// RepoDaily and RepoMonthly cap what the repository of the call spent
+	RepoDaily   float64
+	RepoMonthly float64
+}
+
+// BudgetCheck is one cap a call would go over
+type BudgetCheck struct {
+	Scope     string
+	Limit     float64
+	Spent     float64
+	Estimated float64
+}
+
+// CheckLimits returns the caps that a call of the given command and repository would exceed with
+// its estimated cost, in the order of the scopes above. Repository caps are skipped when repo is empty.
+func (m *Manager) CheckLimits(limits BudgetLimits, command, repo string, estimatedCost float64) ([]BudgetCheck, error) {
+	now := time.Now()
+	records, err := m.loadMonth(now)
+	if err != nil {
+		return nil, err
+	}
+
+	today := now.Format("2006-01-02")
+	currentMonth := now.Format(monthFormat)
+	var daily, monthly, commandDaily, repoDaily, repoMonthly float64
+	for _, record := range records {
+		if record.Timestamp.Format(monthFormat) != currentMonth {
+			continue
+		}
+		isToday := record.Timestamp.Format("2006-01-02") == today
+		inRepo := repo != "" && record.Repo == repo
+
+		monthly += record.CostUSD
+		if inRepo {
+			repoMonthly += record.CostUSD
+		}
+		if !isToday {
+			continue
+		}
+		daily += record.CostUSD
+		if record.Command == command {
+			commandDaily += record.CostUSD
+		}
+		if inRepo {
+			repoDaily += record.CostUSD
+		}
+	}
+
+	candidates := []BudgetCheck{
+		{Scope: BudgetScopeDaily, Limit: m.budgetDaily, Spent: daily},
+		{Scope: BudgetScopeMonthly, Limit: limits.Monthly, Spent: monthly},
+		{Scope: BudgetScopeCommand, Limit: limits.CommandDaily, Spent: commandDaily},
+	}
+	if repo != "" {
+		candidates = append(candidates,
+			BudgetCheck{Scope: BudgetScopeRepoDaily, Limit: limits.RepoDaily, Spent: repoDaily},
+			BudgetCheck{Scope: BudgetScopeRepoMonthly, Limit: limits.RepoMonthly, Spent: repoMonthly},
+		)
+	}
+
+	var exceeded []BudgetCheck
+	for _, check := range candidates {
+		if check.Limit <= 0 || check.Spent+estimatedCost <= check.Limit {
+			continue
+		}
+		check.Estimated = estimatedCost
+		exceeded = append(exceeded, check)
+	}
+
+	slog.Debug("budget limits checked",
+		"command", command,
+		"repo", repo,
+		"estimated_cost", estimatedCost,
+		"exceeded", len(exceeded))
+
+	return exceeded, nil
+}
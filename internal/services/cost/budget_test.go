@@ -0,0 +1,102 @@
+package cost
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_CheckLimits(t *testing.T) {
+	now := time.Now()
+	records := []ActivityRecord{
+		{Timestamp: now, Command: "generate-release", Repo: "acme/api", CostUSD: 0.8},
+		{Timestamp: now, Command: "suggest-commits", Repo: "acme/web", CostUSD: 0.5},
+	}
+	// Earlier days of the month only count for the monthly caps
+	if now.Day() > 1 {
+		records = append(records, ActivityRecord{Timestamp: now.AddDate(0, 0, -1), Command: "generate-release", Repo: "acme/api", CostUSD: 5})
+	}
+
+	tests := []struct {
+		name       string
+		daily      float64
+		limits     BudgetLimits
+		command    string
+		repo       string
+		estimated  float64
+		wantScopes []string
+	}{
+		{
+			name:      "nothing configured",
+			command:   "generate-release",
+			estimated: 10,
+		},
+		{
+			name:       "daily budget of the manager",
+			daily:      1.5,
+			command:    "suggest-commits",
+			estimated:  0.3,
+			wantScopes: []string{BudgetScopeDaily},
+		},
+		{
+			name:       "command cap only counts its own calls",
+			limits:     BudgetLimits{CommandDaily: 1},
+			command:    "generate-release",
+			estimated:  0.3,
+			wantScopes: []string{BudgetScopeCommand},
+		},
+		{
+			name:      "command cap of another command",
+			limits:    BudgetLimits{CommandDaily: 1},
+			command:   "suggest-commits",
+			estimated: 0.3,
+		},
+		{
+			name:       "repository cap only counts its own calls",
+			limits:     BudgetLimits{RepoDaily: 1},
+			command:    "suggest-commits",
+			repo:       "acme/api",
+			estimated:  0.3,
+			wantScopes: []string{BudgetScopeRepoDaily},
+		},
+		{
+			name:      "repository caps are skipped without a repository",
+			limits:    BudgetLimits{RepoDaily: 0.1, RepoMonthly: 0.1},
+			command:   "suggest-commits",
+			estimated: 0.3,
+		},
+		{
+			name:       "several caps at once",
+			daily:      1,
+			limits:     BudgetLimits{Monthly: 1, CommandDaily: 0.5},
+			command:    "generate-release",
+			estimated:  0.1,
+			wantScopes: []string{BudgetScopeDaily, BudgetScopeMonthly, BudgetScopeCommand},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			m, _ := newTestHistoryManager(t)
+			m.budgetDaily = tt.daily
+			for _, record := range records {
+				require.NoError(t, m.SaveActivity(record))
+			}
+
+			// Act
+			exceeded, err := m.CheckLimits(tt.limits, tt.command, tt.repo, tt.estimated)
+
+			// Assert
+			require.NoError(t, err)
+			scopes := make([]string, 0, len(exceeded))
+			for _, check := range exceeded {
+				scopes = append(scopes, check.Scope)
+				assert.Equal(t, tt.estimated, check.Estimated)
+			}
+			assert.ElementsMatch(t, tt.wantScopes, scopes)
+		})
+	}
+}
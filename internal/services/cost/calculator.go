@@ -3,6 +3,8 @@ package cost
 import (
 	"fmt"
 	"strings"
+
+	"github.com/thomas-vilte/matecommit/internal/config"
 )
 
 type PricingTable struct {
@@ -10,59 +12,25 @@ type PricingTable struct {
 	OutputPricePerMillion float64
 }
 
-type ProviderPricing map[string]map[string]PricingTable
-
-// https://ai.google.dev/gemini-api/docs/pricing
-var pricing = ProviderPricing{
-	"gemini": {
-		"gemini-1.5-flash":       {InputPricePerMillion: 0.075, OutputPricePerMillion: 0.30},
-		"gemini-1.5-pro":         {InputPricePerMillion: 1.25, OutputPricePerMillion: 5.00},
-		"gemini-2.5-flash":       {InputPricePerMillion: 0.10, OutputPricePerMillion: 0.40},
-		"gemini-3-flash-preview": {InputPricePerMillion: 0.50, OutputPricePerMillion: 3.00},
-		"gemini-3-pro-preview":   {InputPricePerMillion: 2.00, OutputPricePerMillion: 12.00},
-	},
-	"openai": {
-		"gpt-4o":      {InputPricePerMillion: 2.50, OutputPricePerMillion: 10.00},
-		"gpt-4o-mini": {InputPricePerMillion: 0.15, OutputPricePerMillion: 0.60},
-		"gpt-4-turbo": {InputPricePerMillion: 10.00, OutputPricePerMillion: 30.00},
-	},
-	"anthropic": {
-		"claude-3-5-sonnet": {InputPricePerMillion: 3.00, OutputPricePerMillion: 15.00},
-		"claude-3-haiku":    {InputPricePerMillion: 0.25, OutputPricePerMillion: 1.25},
-	},
+// Calculator prices token usage with the pricing of the model registry.
+type Calculator struct {
+	registry *config.ModelRegistry
 }
 
-type Calculator struct{}
-
 func NewCalculator() *Calculator {
-	return &Calculator{}
+	return &Calculator{registry: config.DefaultModelRegistry()}
 }
 
-// EstimateCost calculates the estimated cost based on provider, model, and tokens
+// EstimateCost calculates the estimated cost based on provider, model, and tokens.
+// Models without pricing in the registry cost nothing.
 func (c *Calculator) EstimateCost(provider, model string, inputTokens, outputTokens int) float64 {
-	provider = strings.ToLower(provider)
-	model = strings.ToLower(model)
-
-	providerPricing, exists := pricing[provider]
-	if !exists {
+	info, exists := c.registry.Lookup(config.AI(provider), model)
+	if !exists || info.Pricing == nil {
 		return 0
 	}
 
-	modelPricing, exists := providerPricing[model]
-	if !exists {
-		for modelName, prices := range providerPricing {
-			if strings.Contains(model, modelName) {
-				modelPricing = prices
-				break
-			}
-		}
-		if modelPricing.InputPricePerMillion == 0 {
-			return 0
-		}
-	}
-
-	inputCost := (float64(inputTokens) / 1_000_000) * modelPricing.InputPricePerMillion
-	outputCost := (float64(outputTokens) / 1_000_000) * modelPricing.OutputPricePerMillion
+	inputCost := (float64(inputTokens) / 1_000_000) * info.Pricing.InputPerMillion
+	outputCost := (float64(outputTokens) / 1_000_000) * info.Pricing.OutputPerMillion
 
 	return inputCost + outputCost
 }
@@ -72,26 +40,40 @@ func (c *Calculator) GetPricing(provider, model string) (PricingTable, error) {
 	provider = strings.ToLower(provider)
 	model = strings.ToLower(model)
 
-	providerPricing, exists := pricing[provider]
-	if !exists {
+	if !c.registry.HasProvider(config.AI(provider)) {
 		return PricingTable{}, fmt.Errorf("provider %s not found", provider)
 	}
 
-	modelPricing, exists := providerPricing[model]
+	info, exists := c.registry.Lookup(config.AI(provider), model)
 	if !exists {
 		return PricingTable{}, fmt.Errorf("model %s not found for provider %s", model, provider)
 	}
+	if info.Pricing == nil {
+		return PricingTable{}, nil
+	}
 
-	return modelPricing, nil
+	return PricingTable{
+		InputPricePerMillion:  info.Pricing.InputPerMillion,
+		OutputPricePerMillion: info.Pricing.OutputPerMillion,
+	}, nil
 }
 
-// AddPricing allows adding pricing dynamically (useful for testing or new models)
+// AddPricing allows adding pricing dynamically (useful for testing or new models).
+// A model missing from the registry is added with the limits of its provider.
 func (c *Calculator) AddPricing(provider, model string, table PricingTable) {
 	provider = strings.ToLower(provider)
 	model = strings.ToLower(model)
 
-	if _, exists := pricing[provider]; !exists {
-		pricing[provider] = make(map[string]PricingTable)
+	info := c.registry.Capabilities(config.AI(provider), model)
+	if !strings.EqualFold(string(info.Model), model) {
+		info.Aliases = nil
+		info.Default = false
+	}
+	info.Provider = config.AI(provider)
+	info.Model = config.Model(model)
+	info.Pricing = &config.ModelPricing{
+		InputPerMillion:  table.InputPricePerMillion,
+		OutputPerMillion: table.OutputPricePerMillion,
 	}
-	pricing[provider][model] = table
+	c.registry.Add(info)
 }
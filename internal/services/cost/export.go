@@ -0,0 +1,267 @@
+package cost
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ExportVersion is increased when a field of the export is renamed, removed or changes meaning.
+// New fields may be added without changing it.
+const ExportVersion = 1
+
+// Formats of WriteExport
+const (
+	ExportFormatCSV   = "csv"
+	ExportFormatJSON  = "json"
+	ExportFormatJSONL = "jsonl"
+)
+
+// ExportFormats lists the formats accepted by WriteExport
+func ExportFormats() []string {
+	return []string{ExportFormatCSV, ExportFormatJSON, ExportFormatJSONL}
+}
+
+// Types of the JSON lines and of the CSV rows of an export
+const (
+	exportRowHeader   = "export"
+	exportRowRecord   = "record"
+	exportRowCommand  = "command"
+	exportRowTotals   = "totals"
+	exportRowForecast = "forecast"
+)
+
+// Export is the usage of a period in the layout written by WriteExport. Records include failed
+// fallback attempts (those with an error); ByCommand and Totals leave them out, like stats does.
+type Export struct {
+	Version     int       `json:"version"`
+	GeneratedAt time.Time `json:"generated_at"`
+	// Since and Until bound the records; Until is exclusive. They are omitted when open.
+	Since     *time.Time       `json:"since,omitempty"`
+	Until     *time.Time       `json:"until,omitempty"`
+	Records   []ActivityRecord `json:"records"`
+	ByCommand []ExportCommand  `json:"by_command"`
+	Totals    ExportTotals     `json:"totals"`
+	Forecast  ExportForecast   `json:"forecast"`
+}
+
+// ExportCommand is the usage of one command in the period
+type ExportCommand struct {
+	Command    string  `json:"command"`
+	Calls      int     `json:"calls"`
+	CostUSD    float64 `json:"cost_usd"`
+	Tokens     int     `json:"tokens"`
+	AvgCostUSD float64 `json:"avg_cost_usd"`
+	// CacheHitRate is the percentage of calls answered from the cache
+	CacheHitRate float64 `json:"cache_hit_rate"`
+}
+
+type ExportTotals struct {
+	Calls   int     `json:"calls"`
+	CostUSD float64 `json:"cost_usd"`
+}
+
+// ExportForecast is the projection of the current month, whatever the period of the export
+type ExportForecast struct {
+	MonthToDateUSD       float64 `json:"month_to_date_usd"`
+	DailyAverageUSD      float64 `json:"daily_average_usd"`
+	ProjectedMonthEndUSD float64 `json:"projected_month_end_usd"`
+	DaysElapsed          int     `json:"days_elapsed"`
+	DaysInMonth          int     `json:"days_in_month"`
+}
+
+// Export collects the records of a period with the breakdown by command and the forecast.
+func (m *Manager) Export(period Period) (*Export, error) {
+	records, err := m.loadRange(period.Since, period.Until)
+	if err != nil {
+		return nil, err
+	}
+	breakdown, err := m.GetBreakdown(GroupByCommand, period)
+	if err != nil {
+		return nil, err
+	}
+	forecast, err := m.GetForecast()
+	if err != nil {
+		return nil, err
+	}
+
+	export := &Export{
+		Version:     ExportVersion,
+		GeneratedAt: time.Now(),
+		Records:     make([]ActivityRecord, 0, len(records)),
+		ByCommand:   make([]ExportCommand, 0, len(breakdown.Groups)),
+		Totals:      ExportTotals{Calls: breakdown.TotalCalls, CostUSD: breakdown.TotalCost},
+		Forecast: ExportForecast{
+			MonthToDateUSD:       forecast.MonthToDate,
+			DailyAverageUSD:      forecast.DailyAverage,
+			ProjectedMonthEndUSD: forecast.ProjectedMonthEnd,
+			DaysElapsed:          forecast.DaysElapsed,
+			DaysInMonth:          forecast.DaysInMonth,
+		},
+	}
+	if !period.Since.IsZero() {
+		export.Since = &period.Since
+	}
+	if !period.Until.IsZero() {
+		export.Until = &period.Until
+	}
+	for _, record := range records {
+		if period.Contains(record.Timestamp) {
+			export.Records = append(export.Records, record)
+		}
+	}
+	for _, group := range breakdown.Groups {
+		export.ByCommand = append(export.ByCommand, ExportCommand{
+			Command:      group.Key,
+			Calls:        group.CallCount,
+			CostUSD:      group.TotalCost,
+			Tokens:       group.TotalTokens,
+			AvgCostUSD:   group.AvgCost,
+			CacheHitRate: group.CacheHitRate,
+		})
+	}
+	return export, nil
+}
+
+// WriteExport writes an export in one of ExportFormats:
+//   - json: the Export as a single document
+//   - jsonl: one object per line, each with a "type" of export (the header), record, command,
+//     totals or forecast and the fields of that part
+//   - csv: one row per line of jsonl, with the type in the first column and csvColumns as header
+func WriteExport(w io.Writer, format string, export *Export) error {
+	switch format {
+	case ExportFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(export)
+	case ExportFormatJSONL:
+		return writeJSONL(w, export)
+	case ExportFormatCSV:
+		return writeCSV(w, export)
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+func writeJSONL(w io.Writer, export *Export) error {
+	encoder := json.NewEncoder(w)
+	lines := []any{struct {
+		Type        string     `json:"type"`
+		Version     int        `json:"version"`
+		GeneratedAt time.Time  `json:"generated_at"`
+		Since       *time.Time `json:"since,omitempty"`
+		Until       *time.Time `json:"until,omitempty"`
+	}{exportRowHeader, export.Version, export.GeneratedAt, export.Since, export.Until}}
+	for _, record := range export.Records {
+		lines = append(lines, struct {
+			Type string `json:"type"`
+			ActivityRecord
+		}{exportRowRecord, record})
+	}
+	for _, command := range export.ByCommand {
+		lines = append(lines, struct {
+			Type string `json:"type"`
+			ExportCommand
+		}{exportRowCommand, command})
+	}
+	lines = append(lines,
+		struct {
+			Type string `json:"type"`
+			ExportTotals
+		}{exportRowTotals, export.Totals},
+		struct {
+			Type string `json:"type"`
+			ExportForecast
+		}{exportRowForecast, export.Forecast},
+	)
+
+	for _, line := range lines {
+		if err := encoder.Encode(line); err != nil {
+			return fmt.Errorf("error writing export: %w", err)
+		}
+	}
+	return nil
+}
+
+// csvColumns is the header of the CSV export. Each row fills the columns of its type and leaves the
+// others empty; columns are only ever added at the end.
+var csvColumns = []string{
+	"type", "timestamp", "command", "provider", "model", "repo", "branch", "workdir",
+	"tokens_input", "tokens_output", "cost_usd", "duration_ms", "cache_hit", "hash", "attempt", "repair", "error",
+	"calls", "tokens", "avg_cost_usd", "cache_hit_rate",
+	"daily_average_usd", "projected_month_end_usd", "days_elapsed", "days_in_month",
+	"user",
+}
+
+func writeCSV(w io.Writer, export *Export) error {
+	writer := csv.NewWriter(w)
+	write := func(values map[string]string) {
+		row := make([]string, len(csvColumns))
+		for i, column := range csvColumns {
+			row[i] = values[column]
+		}
+		_ = writer.Write(row)
+	}
+
+	_ = writer.Write(csvColumns)
+	for _, r := range export.Records {
+		write(map[string]string{
+			"type":          exportRowRecord,
+			"timestamp":     r.Timestamp.Format(time.RFC3339Nano),
+			"command":       r.Command,
+			"provider":      r.Provider,
+			"model":         r.Model,
+			"repo":          r.Repo,
+			"branch":        r.Branch,
+			"workdir":       r.Workdir,
+			"tokens_input":  strconv.Itoa(r.TokensInput),
+			"tokens_output": strconv.Itoa(r.TokensOutput),
+			"cost_usd":      formatUSD(r.CostUSD),
+			"duration_ms":   strconv.FormatInt(r.DurationMs, 10),
+			"cache_hit":     strconv.FormatBool(r.CacheHit),
+			"hash":          r.Hash,
+			"attempt":       strconv.Itoa(r.Attempt),
+			"repair":        strconv.Itoa(r.Repair),
+			"error":         r.Error,
+			"user":          r.User,
+		})
+	}
+	for _, c := range export.ByCommand {
+		write(map[string]string{
+			"type":           exportRowCommand,
+			"command":        c.Command,
+			"calls":          strconv.Itoa(c.Calls),
+			"cost_usd":       formatUSD(c.CostUSD),
+			"tokens":         strconv.Itoa(c.Tokens),
+			"avg_cost_usd":   formatUSD(c.AvgCostUSD),
+			"cache_hit_rate": strconv.FormatFloat(c.CacheHitRate, 'f', 2, 64),
+		})
+	}
+	write(map[string]string{
+		"type":     exportRowTotals,
+		"calls":    strconv.Itoa(export.Totals.Calls),
+		"cost_usd": formatUSD(export.Totals.CostUSD),
+	})
+	write(map[string]string{
+		"type":                    exportRowForecast,
+		"cost_usd":                formatUSD(export.Forecast.MonthToDateUSD),
+		"daily_average_usd":       formatUSD(export.Forecast.DailyAverageUSD),
+		"projected_month_end_usd": formatUSD(export.Forecast.ProjectedMonthEndUSD),
+		"days_elapsed":            strconv.Itoa(export.Forecast.DaysElapsed),
+		"days_in_month":           strconv.Itoa(export.Forecast.DaysInMonth),
+	})
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("error writing export: %w", err)
+	}
+	return nil
+}
+
+// formatUSD keeps the precision of the history, since single calls often cost fractions of a cent
+func formatUSD(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 6, 64)
+}
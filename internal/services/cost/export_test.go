@@ -0,0 +1,107 @@
+package cost
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestExport(t *testing.T) *Export {
+	t.Helper()
+	m, _ := newTestHistoryManager(t)
+	now := time.Now()
+	for _, record := range []ActivityRecord{
+		{Timestamp: now.AddDate(-1, 0, 0), Command: "suggest", CostUSD: 9},
+		{Timestamp: now, Command: "suggest", Repo: "acme/api", Branch: "main", TokensInput: 100, TokensOutput: 20, CostUSD: 0.002},
+		{Timestamp: now, Command: "summarize-pr", CostUSD: 0.003, CacheHit: true},
+		{Timestamp: now, Command: "suggest", Error: "timeout"},
+	} {
+		require.NoError(t, m.SaveActivity(record))
+	}
+
+	export, err := m.Export(MonthOf(now))
+	require.NoError(t, err)
+	return export
+}
+
+func TestManager_Export(t *testing.T) {
+	// Act
+	export := newTestExport(t)
+
+	// Assert
+	assert.Equal(t, ExportVersion, export.Version)
+	require.NotNil(t, export.Since)
+	assert.Len(t, export.Records, 3, "records outside the period are left out, failed attempts are kept")
+	assert.Equal(t, ExportTotals{Calls: 2, CostUSD: 0.005}, export.Totals)
+	require.Len(t, export.ByCommand, 2)
+	assert.Equal(t, "summarize-pr", export.ByCommand[0].Command)
+	assert.Equal(t, 100.0, export.ByCommand[0].CacheHitRate)
+	assert.Equal(t, 120, export.ByCommand[1].Tokens)
+	assert.InDelta(t, 0.005, export.Forecast.MonthToDateUSD, 1e-9)
+}
+
+func TestWriteExport(t *testing.T) {
+	export := newTestExport(t)
+
+	t.Run("json", func(t *testing.T) {
+		// Act
+		var buf bytes.Buffer
+		require.NoError(t, WriteExport(&buf, ExportFormatJSON, export))
+
+		// Assert
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		for _, field := range []string{"version", "generated_at", "since", "until", "records", "by_command", "totals", "forecast"} {
+			assert.Contains(t, decoded, field)
+		}
+	})
+
+	t.Run("jsonl", func(t *testing.T) {
+		// Act
+		var buf bytes.Buffer
+		require.NoError(t, WriteExport(&buf, ExportFormatJSONL, export))
+
+		// Assert
+		var types []string
+		scanner := bufio.NewScanner(&buf)
+		for scanner.Scan() {
+			var line map[string]any
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+			types = append(types, line["type"].(string))
+			if line["type"] == "record" && line["repo"] == "acme/api" {
+				assert.Equal(t, "main", line["branch"])
+			}
+		}
+		assert.Equal(t, []string{"export", "record", "record", "record", "command", "command", "totals", "forecast"}, types)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		// Act
+		var buf bytes.Buffer
+		require.NoError(t, WriteExport(&buf, ExportFormatCSV, export))
+
+		// Assert
+		rows, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 1+3+2+2)
+		assert.Equal(t, csvColumns, rows[0])
+		totals := rows[len(rows)-2]
+		assert.Equal(t, "totals", totals[0])
+		assert.Equal(t, "0.005000", totals[10])
+		assert.Equal(t, "2", totals[17])
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		// Act
+		err := WriteExport(&bytes.Buffer{}, "xlsx", export)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
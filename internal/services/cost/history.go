@@ -0,0 +1,360 @@
+package cost
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// activeHistoryFile receives the records of the current month, one JSON object per line
+	activeHistoryFile = "history.jsonl"
+	// historyLockFile is locked while the history is read or written
+	historyLockFile = "history.lock"
+	// monthFormat names the rotated files, e.g. history-2025-01.jsonl
+	monthFormat = "2006-01"
+)
+
+// historyStore keeps the activity records as JSON lines.
+//
+// New records are appended to history.jsonl, so saving never rewrites the history. When the first
+// record of a new month is saved, the records of the previous months move to one file per month
+// (history-2025-01.jsonl), compacted and sorted. The JSON array used by older versions (history.json)
+// is still read, and moved into the store on the first save.
+type historyStore struct {
+	dir        string
+	legacyPath string
+}
+
+// history returns the store kept next to the legacy history file.
+func (m *Manager) history() *historyStore {
+	return &historyStore{dir: filepath.Dir(m.historyPath), legacyPath: m.historyPath}
+}
+
+// append adds a record to the history, rotating the previous months first.
+func (s *historyStore) append(record ActivityRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("error serializing activity record: %w", err)
+	}
+
+	unlock, err := s.lock(true)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	now := time.Now()
+	if err := s.migrateLegacy(now); err != nil {
+		return err
+	}
+	if err := s.rotate(now); err != nil {
+		// The record is still saved; rotation is retried on the next save
+		slog.Warn("failed to rotate activity history",
+			"dir", s.dir,
+			"error", err)
+	}
+
+	file, err := os.OpenFile(s.activePath(), os.O_CREATE|os.O_APPEND|os.O_RDWR, 0644)
+	if err != nil {
+		return fmt.Errorf("error opening history: %w", err)
+	}
+	// A line cut short by a crash must not swallow the new record
+	if endsMidLine(file) {
+		line = append([]byte{'\n'}, line...)
+	}
+	// A single write keeps the line whole when several processes append at once
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("error saving history: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error saving history: %w", err)
+	}
+	return nil
+}
+
+// loadRange returns the records of the files that may hold times in [since, until); zero bounds are open.
+// It only skips whole rotated months, and the active file can hold older records until it is rotated,
+// so callers still filter by timestamp. Records are in chronological order.
+func (s *historyStore) loadRange(since, until time.Time) ([]ActivityRecord, error) {
+	return s.loadFiles(func(path string) bool {
+		name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), "history-"), ".jsonl")
+		start, err := time.ParseInLocation(monthFormat, name, time.Local)
+		if err != nil {
+			return true
+		}
+		end := start.AddDate(0, 1, 0)
+		return (since.IsZero() || end.After(since)) && (until.IsZero() || start.Before(until))
+	})
+}
+
+func (s *historyStore) loadFiles(includeMonth func(path string) bool) ([]ActivityRecord, error) {
+	unlock, err := s.lock(false)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	records, err := s.readLegacy()
+	if err != nil {
+		return nil, err
+	}
+
+	months, err := s.monthFiles()
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range append(months, s.activePath()) {
+		if path != s.activePath() && !includeMonth(path) {
+			continue
+		}
+		fileRecords, err := readLines(path)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, fileRecords...)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp.Before(records[j].Timestamp)
+	})
+	return records, nil
+}
+
+// rotate moves the records of past months out of the active file. It only reads the active file
+// when it was last written in an earlier month.
+func (s *historyStore) rotate(now time.Time) error {
+	info, err := os.Stat(s.activePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("error reading history: %w", err)
+	}
+	if info.ModTime().Format(monthFormat) == now.Format(monthFormat) {
+		return nil
+	}
+
+	records, err := readLines(s.activePath())
+	if err != nil {
+		return err
+	}
+	kept, err := s.archive(records, now)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("activity history rotated",
+		"archived", len(records)-len(kept),
+		"kept", len(kept))
+	return compact(s.activePath(), kept)
+}
+
+// archive adds the records of past months to their monthly files and returns those of the current month.
+func (s *historyStore) archive(records []ActivityRecord, now time.Time) ([]ActivityRecord, error) {
+	current := now.Format(monthFormat)
+	byMonth := make(map[string][]ActivityRecord)
+	var kept []ActivityRecord
+	for _, record := range records {
+		month := record.Timestamp.Format(monthFormat)
+		if month == current {
+			kept = append(kept, record)
+			continue
+		}
+		byMonth[month] = append(byMonth[month], record)
+	}
+
+	for month, monthRecords := range byMonth {
+		path := s.monthPath(month)
+		existing, err := readLines(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := compact(path, append(existing, monthRecords...)); err != nil {
+			return nil, err
+		}
+	}
+	return kept, nil
+}
+
+// migrateLegacy moves the records of the JSON array used by older versions into the store.
+// A history that cannot be parsed is kept aside instead of being lost.
+func (s *historyStore) migrateLegacy(now time.Time) error {
+	records, err := s.readLegacy()
+	if err != nil {
+		slog.Warn("legacy activity history is corrupt, keeping a copy",
+			"path", s.legacyPath,
+			"error", err)
+		if err := os.Rename(s.legacyPath, s.legacyPath+".corrupt"); err != nil {
+			return fmt.Errorf("error moving corrupt history: %w", err)
+		}
+		return nil
+	}
+	if records == nil {
+		return nil
+	}
+
+	kept, err := s.archive(records, now)
+	if err != nil {
+		return err
+	}
+	existing, err := readLines(s.activePath())
+	if err != nil {
+		return err
+	}
+	if err := compact(s.activePath(), append(kept, existing...)); err != nil {
+		return err
+	}
+	if err := os.Remove(s.legacyPath); err != nil {
+		return fmt.Errorf("error removing legacy history: %w", err)
+	}
+
+	slog.Info("activity history migrated to JSON lines",
+		"records", len(records))
+	return nil
+}
+
+// readLegacy reads the JSON array of older versions; it returns nil when there is none.
+func (s *historyStore) readLegacy() ([]ActivityRecord, error) {
+	data, err := os.ReadFile(s.legacyPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading history: %w", err)
+	}
+
+	records := []ActivityRecord{}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("error deserializing history: %w", err)
+	}
+	return records, nil
+}
+
+// monthFiles lists the rotated files from the oldest month to the newest.
+func (s *historyStore) monthFiles() ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(s.dir, "history-*.jsonl"))
+	if err != nil {
+		return nil, fmt.Errorf("error listing history: %w", err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func (s *historyStore) activePath() string {
+	return filepath.Join(s.dir, activeHistoryFile)
+}
+
+// monthPath returns the rotated file of a month formatted with monthFormat.
+func (s *historyStore) monthPath(month string) string {
+	return filepath.Join(s.dir, "history-"+month+".jsonl")
+}
+
+// lock takes the advisory lock of the history; writers take it exclusively.
+func (s *historyStore) lock(exclusive bool) (func(), error) {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return nil, fmt.Errorf("error creating history directory: %w", err)
+	}
+	file, err := os.OpenFile(filepath.Join(s.dir, historyLockFile), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("error opening history lock: %w", err)
+	}
+	if err := lockFile(file, exclusive); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("error locking history: %w", err)
+	}
+
+	return func() {
+		if err := unlockFile(file); err != nil {
+			slog.Warn("failed to unlock activity history",
+				"error", err)
+		}
+		_ = file.Close()
+	}, nil
+}
+
+// endsMidLine reports whether a non-empty file does not end with a line break.
+func endsMidLine(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil || info.Size() == 0 {
+		return false
+	}
+	last := make([]byte, 1)
+	if _, err := file.ReadAt(last, info.Size()-1); err != nil {
+		return false
+	}
+	return last[0] != '\n'
+}
+
+// readLines reads a JSON lines file. Lines that cannot be parsed, such as the tail of a write
+// interrupted by a crash, are skipped.
+func readLines(path string) ([]ActivityRecord, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading history: %w", err)
+	}
+
+	var records []ActivityRecord
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var record ActivityRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			slog.Warn("skipping malformed activity record",
+				"path", path,
+				"line", lineNumber,
+				"error", err)
+			continue
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading history: %w", err)
+	}
+	return records, nil
+}
+
+// compact rewrites a JSON lines file with the given records sorted by time. The file is replaced
+// atomically, so a crash leaves either the old or the new version.
+func compact(path string, records []ActivityRecord) error {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp.Before(records[j].Timestamp)
+	})
+
+	var buf strings.Builder
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("error serializing activity record: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(buf.String()), 0644); err != nil {
+		return fmt.Errorf("error writing history: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("error writing history: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package cost
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHistoryManager(t *testing.T) (*Manager, string) {
+	t.Helper()
+	dir := t.TempDir()
+	return &Manager{historyPath: filepath.Join(dir, "history.json")}, dir
+}
+
+func readRawLines(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func TestHistoryStore_Append(t *testing.T) {
+	t.Run("appends one line per record", func(t *testing.T) {
+		// Arrange
+		m, dir := newTestHistoryManager(t)
+		now := time.Now()
+
+		// Act
+		require.NoError(t, m.SaveActivity(ActivityRecord{Timestamp: now, Command: "suggest", CostUSD: 0.01}))
+		require.NoError(t, m.SaveActivity(ActivityRecord{Timestamp: now, Command: "summarize-pr", CostUSD: 0.02}))
+
+		// Assert
+		lines := readRawLines(t, filepath.Join(dir, activeHistoryFile))
+		require.Len(t, lines, 2)
+		var record ActivityRecord
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &record))
+		assert.Equal(t, "summarize-pr", record.Command)
+	})
+
+	t.Run("keeps every record of concurrent saves", func(t *testing.T) {
+		// Arrange
+		m, _ := newTestHistoryManager(t)
+		const writers = 20
+		var wg sync.WaitGroup
+
+		// Act
+		for i := 0; i < writers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				// Each save uses its own manager, like separate terminals do
+				other := &Manager{historyPath: m.historyPath}
+				assert.NoError(t, other.SaveActivity(ActivityRecord{Timestamp: time.Now(), Command: "suggest", CostUSD: 0.01}))
+			}()
+		}
+		wg.Wait()
+
+		// Assert
+		records, err := m.GetHistory()
+		require.NoError(t, err)
+		assert.Len(t, records, writers)
+	})
+}
+
+func TestHistoryStore_Rotation(t *testing.T) {
+	// Arrange
+	m, dir := newTestHistoryManager(t)
+	now := time.Now()
+	lastMonth := time.Date(now.Year(), now.Month(), 1, 12, 0, 0, 0, now.Location()).AddDate(0, -1, 0)
+	activePath := filepath.Join(dir, activeHistoryFile)
+	require.NoError(t, compact(activePath, []ActivityRecord{
+		{Timestamp: lastMonth, Command: "suggest", CostUSD: 1},
+		{Timestamp: lastMonth.Add(time.Hour), Command: "suggest", CostUSD: 2},
+	}))
+	require.NoError(t, os.Chtimes(activePath, lastMonth, lastMonth))
+
+	// Act
+	err := m.SaveActivity(ActivityRecord{Timestamp: now, Command: "suggest", CostUSD: 0.5})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, readRawLines(t, activePath), 1)
+	assert.Len(t, readRawLines(t, filepath.Join(dir, "history-"+lastMonth.Format(monthFormat)+".jsonl")), 2)
+
+	history, err := m.GetHistory()
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.True(t, history[0].Timestamp.Equal(lastMonth), "records should be in chronological order")
+
+	monthly, err := m.GetMonthlyTotal()
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, monthly)
+}
+
+func TestHistoryStore_LegacyMigration(t *testing.T) {
+	t.Run("moves the JSON array into the store", func(t *testing.T) {
+		// Arrange
+		m, dir := newTestHistoryManager(t)
+		now := time.Now()
+		lastMonth := now.AddDate(0, -1, 0)
+		data, err := json.Marshal([]ActivityRecord{
+			{Timestamp: lastMonth, Command: "suggest", CostUSD: 1},
+			{Timestamp: now, Command: "suggest", CostUSD: 2},
+		})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(m.historyPath, data, 0644))
+
+		// Act
+		err = m.SaveActivity(ActivityRecord{Timestamp: now, Command: "suggest", CostUSD: 3})
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoFileExists(t, m.historyPath)
+		assert.FileExists(t, filepath.Join(dir, "history-"+lastMonth.Format(monthFormat)+".jsonl"))
+		history, err := m.GetHistory()
+		require.NoError(t, err)
+		assert.Len(t, history, 3)
+	})
+
+	t.Run("keeps a corrupt history aside", func(t *testing.T) {
+		// Arrange
+		m, _ := newTestHistoryManager(t)
+		require.NoError(t, os.WriteFile(m.historyPath, []byte("corrupted"), 0644))
+
+		// Act
+		err := m.SaveActivity(ActivityRecord{Timestamp: time.Now(), Command: "suggest"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.FileExists(t, m.historyPath+".corrupt")
+		history, err := m.GetHistory()
+		require.NoError(t, err)
+		assert.Len(t, history, 1)
+	})
+}
+
+func TestHistoryStore_RecoversFromPartialLines(t *testing.T) {
+	// Arrange
+	m, dir := newTestHistoryManager(t)
+	require.NoError(t, m.SaveActivity(ActivityRecord{Timestamp: time.Now(), Command: "suggest"}))
+	file, err := os.OpenFile(filepath.Join(dir, activeHistoryFile), os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = file.WriteString(`{"timestamp":"2025-`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	// Act
+	require.NoError(t, m.SaveActivity(ActivityRecord{Timestamp: time.Now(), Command: "summarize-pr"}))
+	history, err := m.GetHistory()
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "summarize-pr", history[1].Command)
+}
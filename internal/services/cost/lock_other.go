@@ -0,0 +1,15 @@
+//go:build !unix && !windows
+
+package cost
+
+import "os"
+
+// Platforms without file locks rely on the single write of each append.
+
+func lockFile(*os.File, bool) error {
+	return nil
+}
+
+func unlockFile(*os.File) error {
+	return nil
+}
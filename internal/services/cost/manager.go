@@ -1,11 +1,12 @@
 package cost
 
 import (
-	"encoding/json"
 	"fmt"
 	"log/slog"
+	"math"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"time"
 )
@@ -21,8 +22,32 @@ type ActivityRecord struct {
 	DurationMs   int64     `json:"duration_ms"`
 	CacheHit     bool      `json:"cache_hit"`
 	Hash         string    `json:"hash"`
+	// Attempt is the position in the fallback chain (1 is the active provider)
+	Attempt int `json:"attempt,omitempty"`
+	// Repair numbers the calls that asked the model to fix an answer that failed schema validation
+	Repair int    `json:"repair,omitempty"`
+	Error  string `json:"error,omitempty"`
+	// TokensEstimated is the input count used for the cost estimate shown before the call
+	TokensEstimated int `json:"tokens_estimated,omitempty"`
+	// EstimateSource tells whether TokensEstimated came from the provider API or the local estimator
+	EstimateSource string `json:"estimate_source,omitempty"`
+	// Repo is the repository the call was made in (owner/name, or its root directory without a remote)
+	Repo string `json:"repo,omitempty"`
+	// Branch is the branch checked out when the call was made
+	Branch string `json:"branch,omitempty"`
+	// Workdir is the directory the command ran from
+	Workdir string `json:"workdir,omitempty"`
+	// User is the developer the record belongs to; it is only set in merged histories
+	User string `json:"user,omitempty"`
 }
 
+// Values of ActivityRecord.EstimateSource
+const (
+	EstimateSourceAPI   = "api"
+	EstimateSourceLocal = "local"
+)
+
+// BudgetStatus is the daily budget usage reported by CheckBudget
 type BudgetStatus struct {
 	IsExceeded   bool
 	PercentUsed  float64
@@ -34,8 +59,11 @@ type BudgetStatus struct {
 }
 
 type Manager struct {
+	// historyPath is the history.json of older versions; the JSON lines history lives next to it
 	historyPath string
 	budgetDaily float64
+	// merged replaces the history on disk in the read only managers built by NewMergedManager
+	merged []ActivityRecord
 }
 
 type CommandStats struct {
@@ -53,6 +81,17 @@ type StatsBreakdown struct {
 	TotalCost  float64
 }
 
+// EstimationStats measures how far the input estimates of one provider and source were from the actual usage
+type EstimationStats struct {
+	Provider string
+	Source   string
+	Calls    int
+	// MeanError is the average error in percent of the actual input; positive means the estimates were too high
+	MeanError float64
+	// MeanAbsError is the average error in percent regardless of its sign
+	MeanAbsError float64
+}
+
 type ForecastStats struct {
 	MonthToDate       float64
 	DaysInMonth       int
@@ -78,6 +117,21 @@ func NewManager(budgetDaily float64) (*Manager, error) {
 	}, nil
 }
 
+// loadRange returns the records that may fall in [since, until), in chronological order;
+// callers filter them by timestamp.
+func (m *Manager) loadRange(since, until time.Time) ([]ActivityRecord, error) {
+	if m.merged != nil {
+		return slices.Clone(m.merged), nil
+	}
+	return m.history().loadRange(since, until)
+}
+
+// loadMonth returns the records that may fall in the month of t
+func (m *Manager) loadMonth(t time.Time) ([]ActivityRecord, error) {
+	period := MonthOf(t)
+	return m.loadRange(period.Since, period.Until)
+}
+
 // SaveActivity saves an activity record
 func (m *Manager) SaveActivity(record ActivityRecord) error {
 	slog.Debug("saving activity record",
@@ -89,86 +143,58 @@ func (m *Manager) SaveActivity(record ActivityRecord) error {
 		"cost_usd", record.CostUSD,
 		"cache_hit", record.CacheHit)
 
-	records, err := m.loadHistory()
-	if err != nil {
-		records = []ActivityRecord{}
+	if m.merged != nil {
+		return fmt.Errorf("merged histories are read only")
 	}
-
-	records = append(records, record)
-
-	data, err := json.MarshalIndent(records, "", "  ")
-	if err != nil {
-		slog.Error("failed to serialize activity history",
-			"error", err)
-		return fmt.Errorf("error serializing history: %w", err)
-	}
-
-	if err := os.WriteFile(m.historyPath, data, 0644); err != nil {
+	if err := m.history().append(record); err != nil {
 		slog.Error("failed to write activity history",
-			"path", m.historyPath,
+			"dir", filepath.Dir(m.historyPath),
 			"error", err)
-		return fmt.Errorf("error saving history: %w", err)
+		return err
 	}
 
-	slog.Debug("activity record saved successfully",
-		"total_records", len(records))
+	slog.Debug("activity record saved successfully")
 
 	return nil
 }
 
 // CheckBudget checks if the estimated cost exceeds the daily budget
+//
+// Deprecated: use CheckLimits, which also applies the monthly, per-command and per-repository caps.
 func (m *Manager) CheckBudget(estimatedCost float64) (*BudgetStatus, error) {
-	slog.Debug("checking budget",
-		"estimated_cost", estimatedCost,
-		"budget_daily", m.budgetDaily)
-
 	if m.budgetDaily <= 0 {
-		slog.Debug("no budget limit configured")
 		return &BudgetStatus{}, nil
 	}
 
 	todayTotal, err := m.GetDailyTotal()
 	if err != nil {
-		slog.Error("failed to get daily total",
-			"error", err)
 		return nil, err
 	}
-
-	percentUsed := (todayTotal / m.budgetDaily) * 100
-	newPercent := ((todayTotal + estimatedCost) / m.budgetDaily) * 100
+	exceeded, err := m.CheckLimits(BudgetLimits{}, "", "", estimatedCost)
+	if err != nil {
+		return nil, err
+	}
 
 	status := &BudgetStatus{
-		IsExceeded:  newPercent > 100,
-		PercentUsed: percentUsed,
+		IsExceeded:  len(exceeded) > 0,
+		PercentUsed: (todayTotal / m.budgetDaily) * 100,
 		TodayTotal:  todayTotal,
 		Estimated:   estimatedCost,
 		Limit:       m.budgetDaily,
 	}
-
-	if percentUsed >= 90 {
-		status.IsWarning = true
-		status.WarningLevel = 90
-	} else if percentUsed >= 75 {
-		status.IsWarning = true
-		status.WarningLevel = 75
-	} else if percentUsed >= 50 {
-		status.IsWarning = true
-		status.WarningLevel = 50
+	for _, level := range []int{90, 75, 50} {
+		if status.PercentUsed >= float64(level) {
+			status.IsWarning = true
+			status.WarningLevel = level
+			break
+		}
 	}
-
-	slog.Info("budget check completed",
-		"today_total", todayTotal,
-		"estimated_cost", estimatedCost,
-		"percent_used", percentUsed,
-		"is_exceeded", status.IsExceeded,
-		"is_warning", status.IsWarning)
-
 	return status, nil
 }
 
 // GetDailyTotal gets the total spent today
 func (m *Manager) GetDailyTotal() (float64, error) {
-	records, err := m.loadHistory()
+	records, err := m.loadMonth(time.Now())
 	if err != nil {
 		return 0, nil
 	}
@@ -187,7 +213,7 @@ func (m *Manager) GetDailyTotal() (float64, error) {
 
 // GetMonthlyTotal gets the total spent this month
 func (m *Manager) GetMonthlyTotal() (float64, error) {
-	records, err := m.loadHistory()
+	records, err := m.loadMonth(time.Now())
 	if err != nil {
 		return 0, nil
 	}
@@ -204,66 +230,81 @@ func (m *Manager) GetMonthlyTotal() (float64, error) {
 	return total, nil
 }
 
-// GetHistory gets all records
+// GetHistory gets all records, including the rotated months, from the oldest to the newest
 func (m *Manager) GetHistory() ([]ActivityRecord, error) {
-	return m.loadHistory()
+	return m.loadRange(time.Time{}, time.Time{})
 }
 
-// GetBreakdownByCommand returns usage statistics grouped by command
+// GetBreakdownByCommand returns the usage statistics of this month grouped by command
 func (m *Manager) GetBreakdownByCommand() (*StatsBreakdown, error) {
-	records, err := m.GetHistory()
+	grouped, err := m.GetBreakdown(GroupByCommand, MonthOf(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+
+	breakdown := &StatsBreakdown{
+		ByCommand:  make([]CommandStats, 0, len(grouped.Groups)),
+		TotalCalls: grouped.TotalCalls,
+		TotalCost:  grouped.TotalCost,
+	}
+	for _, group := range grouped.Groups {
+		breakdown.ByCommand = append(breakdown.ByCommand, CommandStats{
+			Command:      group.Key,
+			CallCount:    group.CallCount,
+			TotalCost:    group.TotalCost,
+			TotalTokens:  group.TotalTokens,
+			AvgCost:      group.AvgCost,
+			CacheHitRate: group.CacheHitRate,
+		})
+	}
+	return breakdown, nil
+}
+
+// GetEstimationStats compares the input estimates of this month with the tokens the providers reported,
+// grouped by provider and estimate source
+func (m *Manager) GetEstimationStats() ([]EstimationStats, error) {
+	records, err := m.loadMonth(time.Now())
 	if err != nil {
 		return nil, err
 	}
 
 	currentMonth := time.Now().Format("2006-01")
-	commandMap := make(map[string]*CommandStats)
-	totalCalls := 0
-	totalCost := 0.0
+	type key struct{ provider, source string }
+	statsMap := make(map[key]*EstimationStats)
 
 	for _, record := range records {
-		if record.Timestamp.Format("2006-01") != currentMonth {
+		if record.Timestamp.Format("2006-01") != currentMonth || record.Error != "" ||
+			record.TokensEstimated <= 0 || record.TokensInput <= 0 {
 			continue
 		}
 
-		totalCalls++
-		totalCost += record.CostUSD
-
-		stats, exists := commandMap[record.Command]
+		k := key{record.Provider, record.EstimateSource}
+		stats, exists := statsMap[k]
 		if !exists {
-			stats = &CommandStats{
-				Command: record.Command,
-			}
-			commandMap[record.Command] = stats
+			stats = &EstimationStats{Provider: record.Provider, Source: record.EstimateSource}
+			statsMap[k] = stats
 		}
 
-		stats.CallCount++
-		stats.TotalCost += record.CostUSD
-		stats.TotalTokens += record.TokensInput + record.TokensOutput
-
-		if record.CacheHit {
-			stats.CacheHitRate++
-		}
+		errorPercent := float64(record.TokensEstimated-record.TokensInput) / float64(record.TokensInput) * 100
+		stats.Calls++
+		stats.MeanError += errorPercent
+		stats.MeanAbsError += math.Abs(errorPercent)
 	}
 
-	breakdown := &StatsBreakdown{
-		ByCommand:  make([]CommandStats, 0, len(commandMap)),
-		TotalCalls: totalCalls,
-		TotalCost:  totalCost,
+	result := make([]EstimationStats, 0, len(statsMap))
+	for _, stats := range statsMap {
+		stats.MeanError /= float64(stats.Calls)
+		stats.MeanAbsError /= float64(stats.Calls)
+		result = append(result, *stats)
 	}
 
-	for _, stats := range commandMap {
-		if stats.CallCount > 0 {
-			stats.AvgCost = stats.TotalCost / float64(stats.CallCount)
-			stats.CacheHitRate = (stats.CacheHitRate / float64(stats.CallCount)) * 100
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Provider != result[j].Provider {
+			return result[i].Provider < result[j].Provider
 		}
-		breakdown.ByCommand = append(breakdown.ByCommand, *stats)
-	}
-
-	sort.Slice(breakdown.ByCommand, func(i, j int) bool {
-		return breakdown.ByCommand[i].TotalCost > breakdown.ByCommand[j].TotalCost
+		return result[i].Source < result[j].Source
 	})
-	return breakdown, nil
+	return result, nil
 }
 
 // GetForecast calculates projected monthly spending
@@ -295,7 +336,7 @@ func (m *Manager) GetForecast() (*ForecastStats, error) {
 
 // GetCacheStats returns cache hit statistics
 func (m *Manager) GetCacheStats() (hitRate float64, totalSaved float64, err error) {
-	records, err := m.GetHistory()
+	records, err := m.loadMonth(time.Now())
 	if err != nil {
 		return 0, 0, err
 	}
@@ -306,7 +347,8 @@ func (m *Manager) GetCacheStats() (hitRate float64, totalSaved float64, err erro
 	var saved float64
 
 	for _, record := range records {
-		if record.Timestamp.Format("2006-01") != currentMonth {
+		// Failed fallback attempts are kept for auditing, and repairs belong to the call they fix
+		if record.Timestamp.Format("2006-01") != currentMonth || record.Error != "" || record.Repair > 0 {
 			continue
 		}
 
@@ -323,20 +365,3 @@ func (m *Manager) GetCacheStats() (hitRate float64, totalSaved float64, err erro
 
 	return hitRate, saved, nil
 }
-
-func (m *Manager) loadHistory() ([]ActivityRecord, error) {
-	data, err := os.ReadFile(m.historyPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return []ActivityRecord{}, nil
-		}
-		return nil, fmt.Errorf("error reading history: %w", err)
-	}
-
-	var records []ActivityRecord
-	if err := json.Unmarshal(data, &records); err != nil {
-		return nil, fmt.Errorf("error deserializing history: %w", err)
-	}
-
-	return records, nil
-}
@@ -467,6 +467,32 @@ func TestGetCacheStats(t *testing.T) {
 		assert.Equal(t, 0.0, saved)
 	})
 
+	t.Run("should not count repairs and failed attempts as calls", func(t *testing.T) {
+		// Arrange
+		tmpDir := t.TempDir()
+		manager, err := NewManager(0)
+		require.NoError(t, err)
+		manager.historyPath = tmpDir + "/history.json"
+
+		now := time.Now()
+		records := []ActivityRecord{
+			{Timestamp: now, Command: "suggest", CostUSD: 0.003},
+			{Timestamp: now, Command: "suggest", CostUSD: 0.001, Repair: 1},
+			{Timestamp: now, Command: "suggest", Attempt: 1, Error: "timeout"},
+			{Timestamp: now, Command: "suggest", CacheHit: true},
+		}
+		for _, r := range records {
+			require.NoError(t, manager.SaveActivity(r))
+		}
+
+		// Act
+		hitRate, _, err := manager.GetCacheStats()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 50.0, hitRate)
+	})
+
 	t.Run("should only count current month", func(t *testing.T) {
 		// Arrange
 		tmpDir := t.TempDir()
@@ -496,3 +522,50 @@ func TestGetCacheStats(t *testing.T) {
 		assert.Equal(t, 0.002, saved)
 	})
 }
+
+func TestGetEstimationStats(t *testing.T) {
+	t.Run("should average the error per provider and source", func(t *testing.T) {
+		// Arrange
+		manager, tempDir := setupTestManager(t, 0)
+		defer func() { _ = os.RemoveAll(tempDir) }()
+
+		now := time.Now()
+		records := []ActivityRecord{
+			{Timestamp: now, Provider: "openai", TokensInput: 100, TokensEstimated: 110, EstimateSource: EstimateSourceLocal},
+			{Timestamp: now, Provider: "openai", TokensInput: 200, TokensEstimated: 180, EstimateSource: EstimateSourceLocal},
+			{Timestamp: now, Provider: "anthropic", TokensInput: 100, TokensEstimated: 100, EstimateSource: EstimateSourceAPI},
+			{Timestamp: now, Provider: "openai", TokensInput: 100, Error: "timeout", TokensEstimated: 500, EstimateSource: EstimateSourceLocal},
+			{Timestamp: now, Provider: "openai", CacheHit: true},
+			{Timestamp: now.AddDate(0, -2, 0), Provider: "openai", TokensInput: 100, TokensEstimated: 300, EstimateSource: EstimateSourceLocal},
+		}
+		for _, r := range records {
+			require.NoError(t, manager.SaveActivity(r))
+		}
+
+		// Act
+		stats, err := manager.GetEstimationStats()
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, stats, 2)
+		assert.Equal(t, EstimationStats{Provider: "anthropic", Source: EstimateSourceAPI, Calls: 1}, stats[0])
+		assert.Equal(t, "openai", stats[1].Provider)
+		assert.Equal(t, 2, stats[1].Calls)
+		assert.InDelta(t, 0.0, stats[1].MeanError, 0.001, "+10% and -10% cancel out")
+		assert.InDelta(t, 10.0, stats[1].MeanAbsError, 0.001)
+	})
+
+	t.Run("should handle a history without estimates", func(t *testing.T) {
+		// Arrange
+		manager, tempDir := setupTestManager(t, 0)
+		defer func() { _ = os.RemoveAll(tempDir) }()
+		require.NoError(t, manager.SaveActivity(ActivityRecord{Timestamp: time.Now(), TokensInput: 100}))
+
+		// Act
+		stats, err := manager.GetEstimationStats()
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, stats)
+	})
+}
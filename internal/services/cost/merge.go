@@ -0,0 +1,267 @@
+package cost
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MergeSource is one file of a merged history
+type MergeSource struct {
+	Path    string
+	User    string
+	Records int
+}
+
+// MergeSummary describes what NewMergedManager read
+type MergeSummary struct {
+	Sources []MergeSource
+	// Duplicates counts the records skipped because another source already had them
+	Duplicates int
+}
+
+// NewMergedManager returns a read only manager over several histories or exports, so the team
+// usage goes through the same aggregation as the local one. Records without a user are attributed
+// to the user of their file (see SourceUser), and records with the same hash and timestamp are
+// counted once.
+func NewMergedManager(paths []string) (*Manager, *MergeSummary, error) {
+	summary := &MergeSummary{}
+	seen := make(map[string]bool)
+	merged := []ActivityRecord{}
+
+	for _, path := range paths {
+		records, err := ReadRecords(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		source := MergeSource{Path: path, User: SourceUser(path)}
+		for _, record := range records {
+			key := record.Hash + "|" + record.Timestamp.UTC().Format(time.RFC3339Nano)
+			if seen[key] {
+				summary.Duplicates++
+				continue
+			}
+			seen[key] = true
+			if record.User == "" {
+				record.User = source.User
+			}
+			merged = append(merged, record)
+			source.Records++
+		}
+		summary.Sources = append(summary.Sources, source)
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Timestamp.Before(merged[j].Timestamp)
+	})
+
+	slog.Debug("histories merged",
+		"sources", len(summary.Sources),
+		"records", len(merged),
+		"duplicates", summary.Duplicates)
+
+	return &Manager{merged: merged}, summary, nil
+}
+
+// HistoryFiles lists the files under dir that ReadRecords accepts, in lexical order. The response
+// cache of a copied ~/.matecommit is skipped.
+func HistoryFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() && path != dir && entry.Name() == "cache" {
+			return filepath.SkipDir
+		}
+		switch filepath.Ext(path) {
+		case ".json", ".jsonl", ".csv":
+			if !entry.IsDir() {
+				files = append(files, path)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing histories in %s: %w", dir, err)
+	}
+	return files, nil
+}
+
+// SourceUser names the developer of a file: its name without the extension, or the name of its
+// directory for the files of a copied ~/.matecommit (history.json, history.jsonl, history-2025-01.jsonl).
+func SourceUser(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if name == "history" || strings.HasPrefix(name, "history-") {
+		dir := filepath.Base(filepath.Dir(path))
+		if dir == ".matecommit" {
+			dir = filepath.Base(filepath.Dir(filepath.Dir(path)))
+		}
+		return dir
+	}
+	return name
+}
+
+// ReadRecords reads the records of a history or an export written by WriteExport: the JSON array
+// of older versions, JSON lines, a json export or a csv export. Like the history, lines that cannot
+// be parsed are skipped, and so are those that are not activity records, such as a config file.
+func ReadRecords(path string) ([]ActivityRecord, error) {
+	records, err := readRecords(path)
+	if err != nil {
+		return nil, err
+	}
+
+	activity := records[:0]
+	for _, record := range records {
+		if record.Timestamp.IsZero() || (record.Command == "" && record.Provider == "") {
+			continue
+		}
+		activity = append(activity, record)
+	}
+	if skipped := len(records) - len(activity); skipped > 0 {
+		slog.Debug("skipping entries that are not activity records",
+			"path", path,
+			"skipped", skipped)
+	}
+	return activity, nil
+}
+
+func readRecords(path string) ([]ActivityRecord, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case len(trimmed) == 0:
+		return nil, nil
+	case trimmed[0] == '[':
+		var records []ActivityRecord
+		if err := json.Unmarshal(trimmed, &records); err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", path, err)
+		}
+		return records, nil
+	case trimmed[0] == '{':
+		var export Export
+		if err := json.Unmarshal(trimmed, &export); err == nil && export.Version > 0 {
+			return export.Records, nil
+		}
+		// An indented document, such as config.json, is not JSON lines
+		if bytes.ContainsRune(trimmed, '\n') && json.Valid(trimmed) {
+			return nil, nil
+		}
+		return readRecordLines(path, trimmed), nil
+	default:
+		return readRecordCSV(path, trimmed)
+	}
+}
+
+// readRecordLines reads history files and jsonl exports, whose record lines have a "type" of record.
+func readRecordLines(path string, data []byte) []ActivityRecord {
+	var records []ActivityRecord
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var record struct {
+			Type string `json:"type"`
+			ActivityRecord
+		}
+		if err := json.Unmarshal(line, &record); err != nil {
+			slog.Warn("skipping malformed activity record",
+				"path", path,
+				"line", lineNumber,
+				"error", err)
+			continue
+		}
+		if record.Type == "" || record.Type == exportRowRecord {
+			records = append(records, record.ActivityRecord)
+		}
+	}
+	return records
+}
+
+// readRecordCSV reads the record rows of a csv export, matching the columns by name.
+func readRecordCSV(path string, data []byte) ([]ActivityRecord, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	// Exports written by newer versions may have more columns
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	if len(rows) == 0 || len(rows[0]) == 0 || rows[0][0] != "type" {
+		return nil, fmt.Errorf("error reading %s: not a history or an export", path)
+	}
+
+	columns := make(map[string]int, len(rows[0]))
+	for i, name := range rows[0] {
+		columns[name] = i
+	}
+
+	var records []ActivityRecord
+	for i, row := range rows[1:] {
+		get := func(name string) string {
+			if index, ok := columns[name]; ok && index < len(row) {
+				return row[index]
+			}
+			return ""
+		}
+		if get("type") != exportRowRecord {
+			continue
+		}
+
+		timestamp, err := time.Parse(time.RFC3339Nano, get("timestamp"))
+		if err != nil {
+			slog.Warn("skipping malformed activity record",
+				"path", path,
+				"line", i+2,
+				"error", err)
+			continue
+		}
+		tokensInput, _ := strconv.Atoi(get("tokens_input"))
+		tokensOutput, _ := strconv.Atoi(get("tokens_output"))
+		costUSD, _ := strconv.ParseFloat(get("cost_usd"), 64)
+		durationMs, _ := strconv.ParseInt(get("duration_ms"), 10, 64)
+		cacheHit, _ := strconv.ParseBool(get("cache_hit"))
+		attempt, _ := strconv.Atoi(get("attempt"))
+		repair, _ := strconv.Atoi(get("repair"))
+
+		records = append(records, ActivityRecord{
+			Timestamp:    timestamp,
+			Command:      get("command"),
+			Provider:     get("provider"),
+			Model:        get("model"),
+			TokensInput:  tokensInput,
+			TokensOutput: tokensOutput,
+			CostUSD:      costUSD,
+			DurationMs:   durationMs,
+			CacheHit:     cacheHit,
+			Hash:         get("hash"),
+			Attempt:      attempt,
+			Repair:       repair,
+			Error:        get("error"),
+			Repo:         get("repo"),
+			Branch:       get("branch"),
+			Workdir:      get("workdir"),
+			User:         get("user"),
+		})
+	}
+	return records, nil
+}
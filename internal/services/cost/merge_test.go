@@ -0,0 +1,181 @@
+package cost
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeExportFile(t *testing.T, path, format string, records []ActivityRecord) {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, WriteExport(&buf, format, &Export{Version: ExportVersion, Records: records}))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+}
+
+func TestReadRecords(t *testing.T) {
+	now := time.Now().Round(0)
+	records := []ActivityRecord{
+		{Timestamp: now, Command: "suggest", Hash: "a", CostUSD: 0.25, Repo: "acme/api", CacheHit: true},
+		{Timestamp: now.Add(time.Second), Command: "summarize-pr", Hash: "b", CostUSD: 0.5, Error: "timeout"},
+	}
+	dir := t.TempDir()
+
+	for _, format := range ExportFormats() {
+		t.Run(format+" export", func(t *testing.T) {
+			// Arrange
+			path := filepath.Join(dir, "alice."+format)
+			writeExportFile(t, path, format, records)
+
+			// Act
+			got, err := ReadRecords(path)
+
+			// Assert
+			require.NoError(t, err)
+			require.Len(t, got, 2)
+			assert.True(t, got[0].Timestamp.Equal(now))
+			assert.Equal(t, records[0].Hash, got[0].Hash)
+			assert.Equal(t, records[0].CostUSD, got[0].CostUSD)
+			assert.True(t, got[0].CacheHit)
+			assert.Equal(t, "acme/api", got[0].Repo)
+			assert.Equal(t, "timeout", got[1].Error)
+		})
+	}
+
+	t.Run("legacy history", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(dir, "history.json")
+		data, err := json.Marshal(records)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, data, 0644))
+
+		// Act
+		got, err := ReadRecords(path)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Len(t, got, 2)
+	})
+
+	t.Run("skips JSON that is not an activity record", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(dir, "config.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"language":"en","use_emoji":true}`), 0644))
+
+		// Act
+		got, err := ReadRecords(path)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("rejects other files", func(t *testing.T) {
+		// Arrange
+		path := filepath.Join(dir, "notes.csv")
+		require.NoError(t, os.WriteFile(path, []byte("name,total\nalice,3\n"), 0644))
+
+		// Act
+		_, err := ReadRecords(path)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
+
+func TestSourceUser(t *testing.T) {
+	tests := map[string]string{
+		"team/alice.jsonl":                             "alice",
+		"team/bob/history.jsonl":                       "bob",
+		"team/carol/.matecommit/history-2025-01.jsonl": "carol",
+		"team/dave/history.json":                       "dave",
+	}
+	for path, want := range tests {
+		assert.Equal(t, want, SourceUser(filepath.FromSlash(path)), path)
+	}
+}
+
+func TestNewMergedManager(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	now := time.Now()
+	shared := ActivityRecord{Timestamp: now, Command: "suggest", Hash: "shared", CostUSD: 1}
+	writeExportFile(t, filepath.Join(dir, "alice.json"), ExportFormatJSON, []ActivityRecord{
+		shared,
+		{Timestamp: now, Command: "suggest", Hash: "a", CostUSD: 2, CacheHit: true},
+	})
+	// The same call exported twice, e.g. from an overlapping period
+	writeExportFile(t, filepath.Join(dir, "alice", "history.csv"), ExportFormatCSV, []ActivityRecord{shared})
+	writeExportFile(t, filepath.Join(dir, "bob", "history.jsonl"), ExportFormatJSONL, []ActivityRecord{
+		{Timestamp: now, Command: "summarize-pr", Hash: "b", CostUSD: 4},
+	})
+	files, err := HistoryFiles(dir)
+	require.NoError(t, err)
+
+	// Act
+	m, summary, err := NewMergedManager(files)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, summary.Sources, 3)
+	assert.Equal(t, 1, summary.Duplicates)
+
+	breakdown, err := m.GetBreakdown(GroupByUser, MonthOf(now))
+	require.NoError(t, err)
+	assert.Equal(t, 3, breakdown.TotalCalls)
+	require.Len(t, breakdown.Groups, 2)
+	assert.Equal(t, "bob", breakdown.Groups[0].Key)
+	assert.Equal(t, "alice", breakdown.Groups[1].Key)
+	assert.Equal(t, 50.0, breakdown.Groups[1].CacheHitRate)
+
+	monthly, err := m.GetMonthlyTotal()
+	require.NoError(t, err)
+	assert.Equal(t, 7.0, monthly)
+
+	assert.Error(t, m.SaveActivity(shared), "merged histories are read only")
+}
+
+func TestNewMergedManager_CopiedMatecommitDir(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	home := filepath.Join(dir, "carol", ".matecommit")
+	now := time.Now()
+	writeExportFile(t, filepath.Join(home, "history.jsonl"), ExportFormatJSONL, []ActivityRecord{
+		{Timestamp: now, Command: "suggest", Provider: "gemini", Hash: "c", CostUSD: 1},
+	})
+	require.NoError(t, os.MkdirAll(filepath.Join(home, "cache"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(home, "cache", "abc123.json"),
+		[]byte(`{"hash":"abc123","response":"\"feat: x\"","created_at":"2025-01-01T00:00:00Z","command":"suggest-commits"}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config.json"), []byte(`{"language":"en"}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(home, "style.json"), []byte("{\n  \"head\": \"abc\"\n}\n"), 0644))
+
+	files, err := HistoryFiles(dir)
+	require.NoError(t, err)
+
+	// Act
+	m, summary, err := NewMergedManager(files)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotContains(t, files, filepath.Join(home, "cache", "abc123.json"))
+	records := 0
+	for _, source := range summary.Sources {
+		records += source.Records
+	}
+	assert.Equal(t, 1, records)
+	perf, err := m.GetPerfStats(Period{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, perf.Overall.Calls)
+	breakdown, err := m.GetBreakdown(GroupByUser, MonthOf(now))
+	require.NoError(t, err)
+	assert.Equal(t, 1, breakdown.TotalCalls)
+	require.Len(t, breakdown.Groups, 1)
+	assert.Equal(t, "carol", breakdown.Groups[0].Key)
+}
@@ -0,0 +1,120 @@
+package cost
+
+import (
+	"math"
+	"sort"
+)
+
+// PerfStats is the latency and reliability of a set of calls. Latencies are in milliseconds and,
+// except for CacheHitP50Ms, only measure the calls a provider answered.
+type PerfStats struct {
+	Command string
+	Model   string
+	// Calls counts the answers of a provider, repairs included
+	Calls     int
+	CacheHits int
+	Errors    int
+	// Retries counts the fallback attempts and the repair calls, failed or not
+	Retries       int
+	P50Ms         int64
+	P90Ms         int64
+	P99Ms         int64
+	CacheHitP50Ms int64
+	// TokensPerSecond is the output throughput of the providers
+	TokensPerSecond float64
+}
+
+// PerfReport is the latency and reliability of a period, per command and model and overall
+type PerfReport struct {
+	Groups  []PerfStats
+	Overall PerfStats
+}
+
+// GetPerfStats measures the calls of a period grouped by command and model, from the most to the
+// least used.
+func (m *Manager) GetPerfStats(period Period) (*PerfReport, error) {
+	records, err := m.loadRange(period.Since, period.Until)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct{ command, model string }
+	groups := make(map[key][]ActivityRecord)
+	var all []ActivityRecord
+	for _, record := range records {
+		if !period.Contains(record.Timestamp) {
+			continue
+		}
+		k := key{record.Command, record.Model}
+		groups[k] = append(groups[k], record)
+		all = append(all, record)
+	}
+
+	report := &PerfReport{
+		Groups:  make([]PerfStats, 0, len(groups)),
+		Overall: measure(all),
+	}
+	for k, groupRecords := range groups {
+		stats := measure(groupRecords)
+		stats.Command = k.command
+		stats.Model = k.model
+		report.Groups = append(report.Groups, stats)
+	}
+
+	sort.Slice(report.Groups, func(i, j int) bool {
+		a, b := report.Groups[i], report.Groups[j]
+		if totalA, totalB := a.Calls+a.CacheHits+a.Errors, b.Calls+b.CacheHits+b.Errors; totalA != totalB {
+			return totalA > totalB
+		}
+		if a.Command != b.Command {
+			return a.Command < b.Command
+		}
+		return a.Model < b.Model
+	})
+	return report, nil
+}
+
+func measure(records []ActivityRecord) PerfStats {
+	var stats PerfStats
+	var latencies, cacheLatencies []int64
+	var outputTokens int
+	var providerMs int64
+
+	for _, record := range records {
+		if record.Attempt > 1 || record.Repair > 0 {
+			stats.Retries++
+		}
+		switch {
+		case record.Error != "":
+			stats.Errors++
+		case record.CacheHit:
+			stats.CacheHits++
+			cacheLatencies = append(cacheLatencies, record.DurationMs)
+		default:
+			stats.Calls++
+			latencies = append(latencies, record.DurationMs)
+			outputTokens += record.TokensOutput
+			providerMs += record.DurationMs
+		}
+	}
+
+	stats.P50Ms = percentile(latencies, 50)
+	stats.P90Ms = percentile(latencies, 90)
+	stats.P99Ms = percentile(latencies, 99)
+	stats.CacheHitP50Ms = percentile(cacheLatencies, 50)
+	if providerMs > 0 {
+		stats.TokensPerSecond = float64(outputTokens) / (float64(providerMs) / 1000)
+	}
+	return stats
+}
+
+// percentile returns the nearest-rank percentile of the values, or 0 without values.
+func percentile(values []int64, p float64) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]int64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank-1, 0)]
+}
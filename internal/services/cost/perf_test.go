@@ -0,0 +1,56 @@
+package cost
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentile(t *testing.T) {
+	values := []int64{100, 900, 200, 800, 300, 700, 400, 600, 500, 1000}
+
+	assert.Equal(t, int64(500), percentile(values, 50))
+	assert.Equal(t, int64(900), percentile(values, 90))
+	assert.Equal(t, int64(1000), percentile(values, 99))
+	assert.Equal(t, int64(0), percentile(nil, 50))
+}
+
+func TestManager_GetPerfStats(t *testing.T) {
+	// Arrange
+	m, _ := newTestHistoryManager(t)
+	now := time.Now()
+	for _, record := range []ActivityRecord{
+		{Timestamp: now, Command: "suggest", Model: "gemini-2.5-flash", DurationMs: 1000, TokensOutput: 50},
+		{Timestamp: now, Command: "suggest", Model: "gemini-2.5-flash", DurationMs: 3000, TokensOutput: 150},
+		{Timestamp: now, Command: "suggest", Model: "gemini-2.5-flash", DurationMs: 2000, TokensOutput: 100, Repair: 1},
+		{Timestamp: now, Command: "suggest", Model: "gemini-2.5-flash", DurationMs: 5, CacheHit: true},
+		{Timestamp: now, Command: "suggest", Model: "gemini-2.5-flash", DurationMs: 30000, Error: "timeout"},
+		{Timestamp: now, Command: "suggest", Model: "gpt-4o", DurationMs: 800, TokensOutput: 40, Attempt: 2},
+		{Timestamp: now.AddDate(-1, 0, 0), Command: "suggest", Model: "gemini-2.5-flash", DurationMs: 99999},
+	} {
+		require.NoError(t, m.SaveActivity(record))
+	}
+
+	// Act
+	report, err := m.GetPerfStats(MonthOf(now))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, report.Groups, 2)
+
+	gemini := report.Groups[0]
+	assert.Equal(t, "gemini-2.5-flash", gemini.Model)
+	assert.Equal(t, 3, gemini.Calls)
+	assert.Equal(t, 1, gemini.CacheHits)
+	assert.Equal(t, 1, gemini.Errors)
+	assert.Equal(t, 1, gemini.Retries)
+	assert.Equal(t, int64(2000), gemini.P50Ms, "failed calls are not part of the latency")
+	assert.Equal(t, int64(3000), gemini.P99Ms)
+	assert.Equal(t, int64(5), gemini.CacheHitP50Ms)
+	assert.InDelta(t, 50, gemini.TokensPerSecond, 1e-9)
+
+	assert.Equal(t, 4, report.Overall.Calls)
+	assert.Equal(t, 2, report.Overall.Retries)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/thomas-vilte/matecommit/internal/ai"
 	"github.com/thomas-vilte/matecommit/internal/ai/gemini"
 	"github.com/thomas-vilte/matecommit/internal/config"
 	domainErrors "github.com/thomas-vilte/matecommit/internal/errors"
@@ -407,7 +408,11 @@ func setupServices(t *testing.T, testConfig TestConfig) (*PRService, error) {
 	}
 
 	ctx := context.Background()
-	geminiSummarizer, err := gemini.NewGeminiPRSummarizer(ctx, cfg, nil)
+	geminiClient, err := gemini.NewClient(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	geminiSummarizer, err := ai.NewPRSummarizerService(geminiClient, cfg, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1,42 +1,147 @@
 package routing
 
-type ModelSelector struct{}
+import "github.com/thomas-vilte/matecommit/internal/config"
 
-func NewModelSelector() *ModelSelector {
-	return &ModelSelector{}
+// Request describes the call to route
+type Request struct {
+	// Operation is the command key (suggest, summarize-pr, release, issue)
+	Operation       string
+	Provider        string
+	EstimatedTokens int
+	// FileCount is the number of files in the diff; 0 means unknown
+	FileCount int
 }
 
-// SelectBestModel selects the optimal model based on the operation and token count
-//
-// Smart Routing Strategy:
-//   - Small operations (< 1k tokens): Flash-Lite (most economical)
-//   - Medium operations (1k-10k tokens): Flash (balance cost/quality)
-//   - Large operations (> 10k tokens): 3.0 Flash (better context, avoids hallucinations)
-//   - Releases/Issues: 3.0 Flash (maximum writing quality)
-//
-// SelectBestModel selects the optimal model based on the operation and token count
-func (m *ModelSelector) SelectBestModel(operation string, estimatedTokens int) string {
-	if operation == "generate-release" || operation == "generate-issue" {
-		return "gemini-3-pro-preview"
+type ModelSelector struct {
+	rules    []config.RoutingRule
+	registry *config.ModelRegistry
+}
+
+// NewModelSelector creates a selector for the given rules; without rules the built-in strategy is used
+func NewModelSelector(rules []config.RoutingRule) *ModelSelector {
+	if len(rules) == 0 {
+		rules = DefaultRules()
+	}
+	return &ModelSelector{rules: rules, registry: config.DefaultModelRegistry()}
+}
+
+// DefaultRules returns the built-in Gemini strategy:
+//   - Releases/Issues: 3.0 Pro (maximum writing quality)
+//   - Large operations (> 15k tokens): 3.0 Flash (better context, avoids hallucinations)
+//   - Everything else: 2.5 Flash (balance cost/quality)
+func DefaultRules() []config.RoutingRule {
+	return []config.RoutingRule{
+		{
+			Name:         "release-quality",
+			Operation:    config.CommandRelease,
+			Provider:     config.AIGemini,
+			Model:        config.ModelGeminiV3Pro,
+			RationaleKey: "routing.reason_high_quality",
+		},
+		{
+			Name:         "issue-quality",
+			Operation:    config.CommandIssue,
+			Provider:     config.AIGemini,
+			Model:        config.ModelGeminiV3Pro,
+			RationaleKey: "routing.reason_high_quality",
+		},
+		{
+			Name:         "large-context",
+			Provider:     config.AIGemini,
+			MinTokens:    15001,
+			Model:        config.ModelGeminiV3Flash,
+			RationaleKey: "routing.reason_large",
+		},
+		{
+			Name:         "default",
+			Provider:     config.AIGemini,
+			Model:        config.ModelGeminiV25Flash,
+			RationaleKey: "routing.reason_default",
+		},
+	}
+}
+
+// Rules returns the rules in evaluation order
+func (m *ModelSelector) Rules() []config.RoutingRule {
+	return m.rules
+}
+
+// Select returns the first rule that matches the request and its position in Rules.
+// A rule is skipped when the request does not fit the context window of its model,
+// and a deprecated model is replaced by its successor in the model registry, so the
+// returned rule can differ from the one at its position.
+func (m *ModelSelector) Select(req Request) (config.RoutingRule, int, bool) {
+	for i, rule := range m.rules {
+		if !matches(rule, req) {
+			continue
+		}
+		info, known := m.modelInfo(rule, req)
+		if known && req.EstimatedTokens > info.ContextWindow {
+			continue
+		}
+		if known && info.Deprecated && info.ReplacedBy != "" {
+			rule.Model = info.ReplacedBy
+		}
+		return rule, i, true
+	}
+	return config.RoutingRule{}, -1, false
+}
+
+// modelInfo returns the registry entry of the model of a rule, if the registry knows it
+func (m *ModelSelector) modelInfo(rule config.RoutingRule, req Request) (config.ModelInfo, bool) {
+	if rule.Model == "" || m.registry == nil {
+		return config.ModelInfo{}, false
+	}
+	provider := rule.Provider
+	if provider == "" {
+		provider = config.AI(req.Provider)
 	}
+	return m.registry.Lookup(provider, string(rule.Model))
+}
 
-	if estimatedTokens > 15000 {
-		return "gemini-3-flash-preview"
+// SelectBestModel returns the model of the first matching rule, or "" when no rule matches
+func (m *ModelSelector) SelectBestModel(req Request) string {
+	rule, _, ok := m.Select(req)
+	if !ok {
+		return ""
 	}
+	return string(rule.Model)
+}
 
-	return "gemini-2.5-flash"
+// GetRationale returns the explanation of a rule. Built-in rules return a translation key (isKey),
+// configured rules return their rationale text, or their name when it has none.
+func (m *ModelSelector) GetRationale(rule config.RoutingRule) (rationale string, isKey bool) {
+	if rule.RationaleKey != "" {
+		return rule.RationaleKey, true
+	}
+	if rule.Rationale != "" {
+		return rule.Rationale, false
+	}
+	return rule.Name, false
 }
 
-// GetRationale returns the translation key that explains why a model was chosen
-func (m *ModelSelector) GetRationale(selectedModel string) string {
-	switch selectedModel {
-	case "gemini-1.5-flash":
-		return "routing.reason_balance"
-	case "gemini-3-flash-preview":
-		return "routing.reason_large"
-	case "gemini-3-pro-preview":
-		return "routing.reason_high_quality"
-	default:
-		return "routing.reason_default"
+func matches(rule config.RoutingRule, req Request) bool {
+	if rule.Operation != "" && rule.Operation != req.Operation {
+		return false
+	}
+	if rule.Provider != "" && string(rule.Provider) != req.Provider {
+		return false
+	}
+	if rule.MinTokens > 0 && req.EstimatedTokens < rule.MinTokens {
+		return false
+	}
+	if rule.MaxTokens > 0 && req.EstimatedTokens > rule.MaxTokens {
+		return false
+	}
+	// File conditions only apply when the caller knows the diff
+	if (rule.MinFiles > 0 || rule.MaxFiles > 0) && req.FileCount <= 0 {
+		return false
+	}
+	if rule.MinFiles > 0 && req.FileCount < rule.MinFiles {
+		return false
+	}
+	if rule.MaxFiles > 0 && req.FileCount > rule.MaxFiles {
+		return false
 	}
+	return true
 }
@@ -2,70 +2,89 @@ package routing
 
 import (
 	"testing"
+
+	"github.com/thomas-vilte/matecommit/internal/config"
 )
 
 func TestNewModelSelector(t *testing.T) {
 	// Act
-	selector := NewModelSelector()
+	selector := NewModelSelector(nil)
 
 	// Assert
 	if selector == nil {
 		t.Fatal("NewModelSelector() returned nil")
 	}
+	if len(selector.Rules()) != len(DefaultRules()) {
+		t.Errorf("expected the built-in rules without configuration, got %d rules", len(selector.Rules()))
+	}
 }
 
 func TestModelSelector_SelectBestModel(t *testing.T) {
 	tests := []struct {
 		name            string
 		operation       string
+		provider        string
 		estimatedTokens int
 		want            string
 	}{
 		{
 			name:            "Generate release operation should return high quality model",
-			operation:       "generate-release",
+			operation:       "release",
+			provider:        "gemini",
 			estimatedTokens: 100,
 			want:            "gemini-3-pro-preview",
 		},
 		{
 			name:            "Generate issue operation should return high quality model",
-			operation:       "generate-issue",
+			operation:       "issue",
+			provider:        "gemini",
 			estimatedTokens: 100,
 			want:            "gemini-3-pro-preview",
 		},
 		{
 			name:            "High token count should return flash-preview model",
-			operation:       "summarize",
+			operation:       "summarize-pr",
+			provider:        "gemini",
 			estimatedTokens: 20000,
 			want:            "gemini-3-flash-preview",
 		},
 		{
 			name:            "Boundary token count should return flash-preview model",
-			operation:       "summarize",
+			operation:       "summarize-pr",
+			provider:        "gemini",
 			estimatedTokens: 15001,
 			want:            "gemini-3-flash-preview",
 		},
 		{
 			name:            "Exact boundary token count should return default model",
-			operation:       "summarize",
+			operation:       "summarize-pr",
+			provider:        "gemini",
 			estimatedTokens: 15000,
 			want:            "gemini-2.5-flash",
 		},
 		{
 			name:            "Small token count should return default model",
-			operation:       "summarize",
+			operation:       "suggest",
+			provider:        "gemini",
 			estimatedTokens: 500,
 			want:            "gemini-2.5-flash",
 		},
+		{
+			name:            "Built-in rules do not route other providers",
+			operation:       "release",
+			provider:        "openai",
+			estimatedTokens: 500,
+			want:            "",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Arrange
-			m := &ModelSelector{}
+			m := NewModelSelector(nil)
 
 			// Act
-			got := m.SelectBestModel(tt.operation, tt.estimatedTokens)
+			got := m.SelectBestModel(Request{Operation: tt.operation, Provider: tt.provider, EstimatedTokens: tt.estimatedTokens})
 
 			// Assert
 			if got != tt.want {
@@ -75,51 +94,147 @@ func TestModelSelector_SelectBestModel(t *testing.T) {
 	}
 }
 
-func TestModelSelector_GetRationale(t *testing.T) {
+func TestModelSelector_Select_ConfiguredRules(t *testing.T) {
+	rules := []config.RoutingRule{
+		{Name: "big-refactor", Operation: "suggest", MinFiles: 20, Model: "gpt-4o", Rationale: "Many files changed"},
+		{Name: "openai-large", Provider: "openai", MinTokens: 10000, Model: "gpt-4o", Rationale: "Large diff"},
+		{Name: "release-writer", Operation: "release", Model: "claude-3-5-sonnet-latest"},
+		{Name: "small-commits", Operation: "suggest", MaxTokens: 2000, MaxFiles: 3, Model: "gpt-4o-mini"},
+	}
+
 	tests := []struct {
-		name          string
-		selectedModel string
-		want          string
+		name     string
+		request  Request
+		wantRule string
 	}{
 		{
-			name:          "High quality model rationale",
-			selectedModel: "gemini-3-pro-preview",
-			want:          "routing.reason_high_quality",
+			name:     "matches on file count",
+			request:  Request{Operation: "suggest", Provider: "openai", EstimatedTokens: 500, FileCount: 25},
+			wantRule: "big-refactor",
 		},
 		{
-			name:          "Large context model rationale",
-			selectedModel: "gemini-3-flash-preview",
-			want:          "routing.reason_large",
+			name:     "matches on provider and tokens",
+			request:  Request{Operation: "summarize-pr", Provider: "openai", EstimatedTokens: 20000},
+			wantRule: "openai-large",
 		},
 		{
-			name:          "Balance model rationale (from comments, even if mismatched in code constant)",
-			selectedModel: "gemini-1.5-flash",
-			want:          "routing.reason_balance",
+			name:     "provider condition excludes other providers",
+			request:  Request{Operation: "summarize-pr", Provider: "anthropic", EstimatedTokens: 20000},
+			wantRule: "",
 		},
 		{
-			name:          "Unknown model should return default rationale",
-			selectedModel: "unknown-model",
-			want:          "routing.reason_default",
+			name:     "matches on operation only",
+			request:  Request{Operation: "release", Provider: "anthropic", EstimatedTokens: 100},
+			wantRule: "release-writer",
 		},
 		{
-			name:          "Default model used in SelectBestModel should return default rationale",
-			selectedModel: "gemini-2.5-flash",
-			want:          "routing.reason_default",
+			name:     "matches all upper bounds",
+			request:  Request{Operation: "suggest", Provider: "anthropic", EstimatedTokens: 1500, FileCount: 2},
+			wantRule: "small-commits",
+		},
+		{
+			name:     "file conditions need a known file count",
+			request:  Request{Operation: "suggest", Provider: "anthropic", EstimatedTokens: 1500},
+			wantRule: "",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Arrange
-			m := &ModelSelector{}
+			m := NewModelSelector(rules)
 
 			// Act
-			got := m.GetRationale(tt.selectedModel)
+			rule, _, ok := m.Select(tt.request)
 
 			// Assert
-			if got != tt.want {
-				t.Errorf("ModelSelector.GetRationale() = %v, want %v", got, tt.want)
+			if tt.wantRule == "" {
+				if ok {
+					t.Errorf("expected no rule to match, got %q", rule.Name)
+				}
+				return
+			}
+			if !ok || rule.Name != tt.wantRule {
+				t.Errorf("ModelSelector.Select() = %q (matched %v), want %q", rule.Name, ok, tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestModelSelector_GetRationale(t *testing.T) {
+	tests := []struct {
+		name      string
+		rule      config.RoutingRule
+		want      string
+		wantIsKey bool
+	}{
+		{
+			name:      "Built-in rule returns its translation key",
+			rule:      DefaultRules()[0],
+			want:      "routing.reason_high_quality",
+			wantIsKey: true,
+		},
+		{
+			name: "Configured rule returns its rationale",
+			rule: config.RoutingRule{Name: "big-diff", Rationale: "Big diffs need a bigger context window"},
+			want: "Big diffs need a bigger context window",
+		},
+		{
+			name: "Configured rule without rationale returns its name",
+			rule: config.RoutingRule{Name: "big-diff"},
+			want: "big-diff",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			m := NewModelSelector(nil)
+
+			// Act
+			got, isKey := m.GetRationale(tt.rule)
+
+			// Assert
+			if got != tt.want || isKey != tt.wantIsKey {
+				t.Errorf("ModelSelector.GetRationale() = (%v, %v), want (%v, %v)", got, isKey, tt.want, tt.wantIsKey)
 			}
 		})
 	}
 }
+
+func TestModelSelector_Select_ModelRegistry(t *testing.T) {
+	rules := []config.RoutingRule{
+		{Name: "small-local", Provider: "local", Model: "llama3.1"},
+		{Name: "legacy", Provider: "openai", Model: "gpt-4-turbo"},
+		{Name: "fallback", Model: "qwen2.5-coder"},
+	}
+
+	t.Run("skips a rule whose model cannot fit the request", func(t *testing.T) {
+		// Arrange
+		m := NewModelSelector(rules)
+
+		// Act
+		rule, _, ok := m.Select(Request{Operation: "suggest", Provider: "local", EstimatedTokens: 20000})
+
+		// Assert
+		if !ok || rule.Name != "fallback" {
+			t.Errorf("ModelSelector.Select() = %q (matched %v), want %q", rule.Name, ok, "fallback")
+		}
+	})
+
+	t.Run("replaces a deprecated model", func(t *testing.T) {
+		// Arrange
+		m := NewModelSelector(rules)
+
+		// Act
+		rule, index, ok := m.Select(Request{Operation: "suggest", Provider: "openai", EstimatedTokens: 500})
+
+		// Assert
+		if !ok || rule.Model != config.ModelGPTV4o {
+			t.Errorf("ModelSelector.Select() model = %q (matched %v), want %q", rule.Model, ok, config.ModelGPTV4o)
+		}
+		if index != 1 {
+			t.Errorf("ModelSelector.Select() index = %d, want 1", index)
+		}
+	})
+}
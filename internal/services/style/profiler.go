@@ -0,0 +1,318 @@
+package style
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/thomas-vilte/matecommit/internal/models"
+)
+
+const (
+	// ProfileFile is the cache of the profile, stored in the .matecommit directory of the repository
+	ProfileFile = "style.json"
+	// historyLimit is how many commits are scanned to build the profile
+	historyLimit = 200
+	// minCommits is the shortest history worth profiling; below it the defaults of the prompt apply
+	minCommits = 5
+	// refreshCommits is how many new commits make a cached profile stale; a few more rarely change the style
+	refreshCommits = 25
+	// maxExamples is how many subjects are shown to the model
+	maxExamples = 5
+	// maxRanked is how many types and scopes are kept
+	maxRanked = 8
+	// majority is the share a convention needs to be reported as the rule of the repository
+	majority = 0.7
+)
+
+var (
+	// conventionalSubject matches "type(scope)!: description", with an optional leading emoji or ticket
+	conventionalSubject = regexp.MustCompile(`^(?:\[[A-Z][A-Z0-9]+-\d+\]\s*|[A-Z][A-Z0-9]+-\d+:?\s+)?([a-z]+)(?:\(([^)]+)\))?!?:\s+(.+)$`)
+	ticketReference     = regexp.MustCompile(`\b[A-Z][A-Z0-9]+-\d+\b|#\d+\b`)
+	emojiShortcode      = regexp.MustCompile(`^:[a-z0-9_+-]+:`)
+)
+
+// historySource is the part of the git service the profiler reads from.
+type historySource interface {
+	GetCommitHistory(ctx context.Context, limit int) ([]models.Commit, error)
+	GetCommitCount(ctx context.Context) (int, error)
+	GetRepoRoot(ctx context.Context) (string, error)
+}
+
+// Profiler learns the commit style of the repository and caches it until refreshCommits more commits are made.
+type Profiler struct {
+	git historySource
+}
+
+// NewProfiler creates a profiler that reads the history through git.
+func NewProfiler(git historySource) *Profiler {
+	return &Profiler{git: git}
+}
+
+// Profile returns the commit style of the repository, from the cache while the history has not grown much since.
+// It returns nil without error when the history is too short to tell a style apart, including repositories without commits.
+func (p *Profiler) Profile(ctx context.Context) (*models.CommitStyle, error) {
+	count, err := p.git.GetCommitCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if count < minCommits {
+		return nil, nil
+	}
+	root, err := p.git.GetRepoRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cachePath := filepath.Join(root, ".matecommit", ProfileFile)
+
+	if cached, err := loadProfile(cachePath); err == nil && count >= cached.HistorySize && count-cached.HistorySize < refreshCommits {
+		slog.Debug("using cached commit style", "history_size", cached.HistorySize, "commits", count)
+		return cached, nil
+	}
+
+	commits, err := p.git.GetCommitHistory(ctx, historyLimit)
+	if err != nil {
+		return nil, err
+	}
+	profile := Analyze(commits)
+	if profile == nil {
+		return nil, nil
+	}
+	profile.HistorySize = count
+
+	if err := saveProfile(cachePath, profile); err != nil {
+		slog.Warn("failed to cache commit style", "path", cachePath, "error", err)
+	}
+	return profile, nil
+}
+
+// subject is a commit subject split in its conventional parts.
+type subject struct {
+	text         string
+	conventional bool
+	commitType   string
+	scope        string
+	description  string
+	emoji        bool
+	ticket       string
+	placement    string
+}
+
+// Analyze derives the commit style of a history, newest commit first.
+// It returns nil when there are fewer than minCommits usable commits.
+func Analyze(commits []models.Commit) *models.CommitStyle {
+	var (
+		subjects                            []subject
+		withBody, bodyLines, subjectLengths int
+	)
+	for _, commit := range commits {
+		title, body, _ := strings.Cut(strings.TrimSpace(commit.Message), "\n")
+		title = strings.TrimSpace(title)
+		if title == "" || strings.HasPrefix(title, "Merge ") || strings.HasPrefix(title, "Revert ") {
+			continue
+		}
+		subjects = append(subjects, parseSubject(title))
+		subjectLengths += utf8.RuneCountInString(title)
+		if body = strings.TrimSpace(body); body != "" {
+			withBody++
+			bodyLines += len(strings.Split(body, "\n"))
+		}
+	}
+	if len(subjects) < minCommits {
+		return nil
+	}
+
+	total := float64(len(subjects))
+	types := make(map[string]int)
+	scopes := make(map[string]int)
+	placements := make(map[string]int)
+	var conventional, emoji, lower, upper, tickets int
+	ticketExample := ""
+	for _, s := range subjects {
+		if s.conventional {
+			conventional++
+			types[s.commitType]++
+			if s.scope != "" {
+				scopes[s.scope]++
+			}
+		}
+		if s.emoji {
+			emoji++
+		}
+		switch first, _ := utf8.DecodeRuneInString(s.description); {
+		case unicode.IsLower(first):
+			lower++
+		case unicode.IsUpper(first):
+			upper++
+		}
+		if s.ticket != "" {
+			tickets++
+			placements[s.placement]++
+			if ticketExample == "" {
+				ticketExample = s.ticket
+			}
+		}
+	}
+
+	profile := &models.CommitStyle{
+		CommitCount:     len(subjects),
+		Conventional:    float64(conventional) / total,
+		Types:           rank(types),
+		Scopes:          rank(scopes),
+		Emoji:           float64(emoji) / total,
+		Casing:          models.CasingMixed,
+		TicketPlacement: models.TicketPlacementNone,
+		BodyShare:       float64(withBody) / total,
+		AverageSubject:  subjectLengths / len(subjects),
+	}
+	if withBody > 0 {
+		profile.AverageBodyLines = bodyLines / withBody
+	}
+	if cased := lower + upper; cased > 0 {
+		if float64(lower)/float64(cased) >= majority {
+			profile.Casing = models.CasingLower
+		} else if float64(upper)/float64(cased) >= majority {
+			profile.Casing = models.CasingUpper
+		}
+	}
+	// Tickets become a rule once a third of the history carries one
+	if float64(tickets)/total >= 0.3 {
+		if top := rank(placements); len(top) > 0 {
+			profile.TicketPlacement = top[0].Name
+			profile.TicketExample = ticketExample
+		}
+	}
+	profile.Examples = pickExamples(subjects, profile)
+
+	return profile
+}
+
+// parseSubject splits a commit subject in its conventional parts and finds its ticket reference.
+func parseSubject(text string) subject {
+	s := subject{text: text, description: text}
+
+	rest := text
+	if emojiShortcode.MatchString(rest) {
+		s.emoji = true
+		rest = strings.TrimSpace(emojiShortcode.ReplaceAllString(rest, ""))
+	} else if first, size := utf8.DecodeRuneInString(rest); isEmoji(first) {
+		s.emoji = true
+		rest = strings.TrimSpace(strings.TrimLeft(rest[size:], "️"))
+	}
+	s.description = rest
+
+	if m := conventionalSubject.FindStringSubmatch(rest); m != nil {
+		s.conventional = true
+		s.commitType = m[1]
+		s.scope = m[2]
+		s.description = m[3]
+	}
+
+	if loc := ticketReference.FindStringIndex(rest); loc != nil {
+		s.ticket = rest[loc[0]:loc[1]]
+		switch {
+		case s.scope != "" && strings.Contains(s.scope, s.ticket):
+			s.placement = models.TicketPlacementScope
+		case loc[0] <= 1:
+			s.placement = models.TicketPlacementPrefix
+		default:
+			s.placement = models.TicketPlacementSuffix
+		}
+		if s.placement != models.TicketPlacementSuffix && strings.HasPrefix(s.description, s.ticket) {
+			s.description = strings.TrimLeft(strings.TrimPrefix(s.description, s.ticket), ":] ")
+		}
+	}
+
+	return s
+}
+
+// pickExamples chooses the most recent subjects that follow the derived rules, one per type when possible.
+func pickExamples(subjects []subject, profile *models.CommitStyle) []string {
+	follows := func(s subject) bool {
+		if profile.Conventional >= majority && !s.conventional {
+			return false
+		}
+		if profile.TicketPlacement != models.TicketPlacementNone && s.ticket == "" {
+			return false
+		}
+		return utf8.RuneCountInString(s.text) <= 100
+	}
+
+	var examples []string
+	seenTypes := make(map[string]bool)
+	picked := make(map[string]bool)
+	for _, s := range subjects {
+		if len(examples) == maxExamples {
+			break
+		}
+		if follows(s) && !seenTypes[s.commitType] && !picked[s.text] {
+			seenTypes[s.commitType] = true
+			picked[s.text] = true
+			examples = append(examples, s.text)
+		}
+	}
+	for _, s := range subjects {
+		if len(examples) == maxExamples {
+			break
+		}
+		if follows(s) && !picked[s.text] {
+			picked[s.text] = true
+			examples = append(examples, s.text)
+		}
+	}
+	return examples
+}
+
+// rank sorts counts from the most used, by name on ties, and keeps the first maxRanked.
+func rank(counts map[string]int) []models.StyleCount {
+	ranked := make([]models.StyleCount, 0, len(counts))
+	for name, count := range counts {
+		ranked = append(ranked, models.StyleCount{Name: name, Count: count})
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Count != ranked[j].Count {
+			return ranked[i].Count > ranked[j].Count
+		}
+		return ranked[i].Name < ranked[j].Name
+	})
+	if len(ranked) > maxRanked {
+		ranked = ranked[:maxRanked]
+	}
+	return ranked
+}
+
+// isEmoji reports whether r is a pictographic symbol, as gitmoji subjects start with.
+func isEmoji(r rune) bool {
+	return r >= 0x2190 && (unicode.Is(unicode.So, r) || unicode.Is(unicode.Sk, r))
+}
+
+func loadProfile(path string) (*models.CommitStyle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var profile models.CommitStyle
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func saveProfile(path string, profile *models.CommitStyle) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating .matecommit directory: %w", err)
+	}
+	data, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
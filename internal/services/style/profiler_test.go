@@ -0,0 +1,188 @@
+package style
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomas-vilte/matecommit/internal/models"
+)
+
+type fakeHistory struct {
+	root    string
+	count   int
+	commits []models.Commit
+	calls   int
+}
+
+func (f *fakeHistory) GetCommitHistory(ctx context.Context, limit int) ([]models.Commit, error) {
+	f.calls++
+	return f.commits, nil
+}
+
+func (f *fakeHistory) GetCommitCount(ctx context.Context) (int, error) {
+	return f.count, nil
+}
+
+func (f *fakeHistory) GetRepoRoot(ctx context.Context) (string, error) {
+	return f.root, nil
+}
+
+func commitsOf(messages ...string) []models.Commit {
+	commits := make([]models.Commit, len(messages))
+	for i, message := range messages {
+		commits[i] = models.Commit{Message: message}
+	}
+	return commits
+}
+
+func TestParseSubject(t *testing.T) {
+	tests := []struct {
+		text string
+		want subject
+	}{
+		{
+			text: "feat(ai): add routing rules",
+			want: subject{conventional: true, commitType: "feat", scope: "ai", description: "add routing rules"},
+		},
+		{
+			text: "fix!: drop the legacy flag (#42)",
+			want: subject{conventional: true, commitType: "fix", description: "drop the legacy flag (#42)", ticket: "#42", placement: models.TicketPlacementSuffix},
+		},
+		{
+			text: "[PROJ-12] feat: add login",
+			want: subject{conventional: true, commitType: "feat", description: "add login", ticket: "PROJ-12", placement: models.TicketPlacementPrefix},
+		},
+		{
+			text: "feat(PROJ-7): add login",
+			want: subject{conventional: true, commitType: "feat", scope: "PROJ-7", description: "add login", ticket: "PROJ-7", placement: models.TicketPlacementScope},
+		},
+		{
+			text: "✨ feat: add dark mode",
+			want: subject{conventional: true, commitType: "feat", description: "add dark mode", emoji: true},
+		},
+		{
+			text: ":bug: Fix crash on startup",
+			want: subject{description: "Fix crash on startup", emoji: true},
+		},
+		{
+			text: "Update README",
+			want: subject{description: "Update README"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got := parseSubject(tt.text)
+
+			tt.want.text = tt.text
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	t.Run("conventional history with tickets", func(t *testing.T) {
+		// Arrange
+		commits := commitsOf(
+			"feat(cli): add stats export (#12)\n\nAdds the export subcommand.\nWrites CSV and JSON.",
+			"fix(ai): handle empty responses (#11)",
+			"Merge branch 'main' into feature",
+			"feat(ai): add fallback providers (#10)\n\nTries the next provider on 5xx errors.",
+			"docs: document the routing rules (#9)",
+			"fix(cli): keep the spinner on errors (#8)",
+			"refactor(ai): move schemas to their own file (#7)",
+		)
+
+		// Act
+		profile := Analyze(commits)
+
+		// Assert
+		require.NotNil(t, profile)
+		assert.Equal(t, 6, profile.CommitCount)
+		assert.Equal(t, 1.0, profile.Conventional)
+		assert.Equal(t, []models.StyleCount{{Name: "feat", Count: 2}, {Name: "fix", Count: 2}, {Name: "docs", Count: 1}, {Name: "refactor", Count: 1}}, profile.Types)
+		assert.Equal(t, []models.StyleCount{{Name: "ai", Count: 3}, {Name: "cli", Count: 2}}, profile.Scopes)
+		assert.Equal(t, models.CasingLower, profile.Casing)
+		assert.Equal(t, models.TicketPlacementSuffix, profile.TicketPlacement)
+		assert.Equal(t, "#12", profile.TicketExample)
+		assert.Zero(t, profile.Emoji)
+		assert.InDelta(t, 2.0/6, profile.BodyShare, 0.001)
+		assert.Equal(t, 1, profile.AverageBodyLines)
+		assert.Equal(t, []string{
+			"feat(cli): add stats export (#12)",
+			"fix(ai): handle empty responses (#11)",
+			"docs: document the routing rules (#9)",
+			"refactor(ai): move schemas to their own file (#7)",
+			"feat(ai): add fallback providers (#10)",
+		}, profile.Examples)
+	})
+
+	t.Run("free-form history with emojis", func(t *testing.T) {
+		profile := Analyze(commitsOf(
+			"✨ Add dark mode",
+			"🐛 Fix crash on startup",
+			"📝 Update the install guide",
+			"♻️ Simplify the config loader",
+			"✅ Cover the parser with tests",
+		))
+
+		require.NotNil(t, profile)
+		assert.Zero(t, profile.Conventional)
+		assert.Equal(t, 1.0, profile.Emoji)
+		assert.Equal(t, models.CasingUpper, profile.Casing)
+		assert.Equal(t, models.TicketPlacementNone, profile.TicketPlacement)
+		assert.Len(t, profile.Examples, 5)
+	})
+
+	t.Run("short history has no profile", func(t *testing.T) {
+		assert.Nil(t, Analyze(commitsOf("feat: initial commit", "fix: typo")))
+	})
+}
+
+func TestProfiler_Profile(t *testing.T) {
+	t.Run("reuses the cache until the history grows", func(t *testing.T) {
+		// Arrange
+		source := &fakeHistory{
+			root:    t.TempDir(),
+			count:   40,
+			commits: commitsOf("feat: a", "feat: b", "fix: c", "fix: d", "docs: e"),
+		}
+		profiler := NewProfiler(source)
+		ctx := context.Background()
+
+		// Act
+		first, err := profiler.Profile(ctx)
+		require.NoError(t, err)
+		source.count += refreshCommits - 1
+		second, err := profiler.Profile(ctx)
+		require.NoError(t, err)
+		source.count++
+		third, err := profiler.Profile(ctx)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, 40, first.HistorySize)
+		assert.Equal(t, first, second)
+		assert.Equal(t, 40+refreshCommits, third.HistorySize)
+		assert.Equal(t, 2, source.calls, "the history is read again only after refreshCommits new commits")
+		_, err = os.Stat(filepath.Join(source.root, ".matecommit", ProfileFile))
+		assert.NoError(t, err)
+	})
+
+	t.Run("has no profile in a repository without commits", func(t *testing.T) {
+		// Arrange
+		source := &fakeHistory{root: t.TempDir()}
+
+		// Act
+		profile, err := NewProfiler(source).Profile(context.Background())
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Nil(t, profile)
+		assert.Zero(t, source.calls, "the history is not read")
+	})
+}
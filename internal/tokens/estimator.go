@@ -0,0 +1,129 @@
+// Package tokens estimates how many tokens a text uses without calling the provider.
+//
+// The estimate approximates the BPE tokenizers of each provider family: words are split into
+// pieces of a typical length, punctuation runs and line breaks cost their own tokens, and
+// ideographic scripts cost about one token per character.
+package tokens
+
+import (
+	"math"
+	"unicode"
+)
+
+// Family holds the tokenizer traits of the models of one provider.
+type Family struct {
+	Name string
+	// CharsPerToken is the length of word the tokenizer usually keeps in a single piece
+	CharsPerToken float64
+	// PunctPerToken is how many punctuation characters of a run share one token
+	PunctPerToken float64
+	// IndentPerToken is how many spaces of an indentation share one token
+	IndentPerToken float64
+}
+
+var (
+	// openAIFamily matches the o200k/cl100k vocabularies, which merge long words and indentation well
+	openAIFamily = Family{Name: "openai", CharsPerToken: 6, PunctPerToken: 2, IndentPerToken: 8}
+	// anthropicFamily has a smaller vocabulary, so words split into shorter pieces
+	anthropicFamily = Family{Name: "anthropic", CharsPerToken: 5, PunctPerToken: 1.5, IndentPerToken: 4}
+	// geminiFamily matches the SentencePiece vocabulary of the Gemini models
+	geminiFamily = Family{Name: "gemini", CharsPerToken: 6, PunctPerToken: 2, IndentPerToken: 4}
+	// localFamily covers the Llama and Qwen tokenizers used by most local models
+	localFamily = Family{Name: "local", CharsPerToken: 5.5, PunctPerToken: 2, IndentPerToken: 4}
+)
+
+// FamilyFor returns the tokenizer family of a provider; unknown providers use the OpenAI one.
+func FamilyFor(provider string) Family {
+	switch provider {
+	case "anthropic":
+		return anthropicFamily
+	case "gemini":
+		return geminiFamily
+	case "local":
+		return localFamily
+	default:
+		return openAIFamily
+	}
+}
+
+// Estimate returns the estimated number of tokens of text for the models of provider.
+func Estimate(provider, text string) int {
+	return FamilyFor(provider).Estimate(text)
+}
+
+// runeClass groups the characters that a tokenizer usually merges together.
+type runeClass int
+
+const (
+	classNone runeClass = iota
+	classWord
+	classSpace
+	classNewline
+	classPunct
+	classIdeograph
+)
+
+func classify(r rune) runeClass {
+	switch {
+	case r == '\n' || r == '\r':
+		return classNewline
+	case unicode.IsSpace(r):
+		return classSpace
+	case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+		return classIdeograph
+	case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r):
+		return classWord
+	default:
+		return classPunct
+	}
+}
+
+// Estimate returns the estimated number of tokens of text for the models of the family.
+func (f Family) Estimate(text string) int {
+	var (
+		total   float64
+		class   = classNone
+		length  float64
+		atStart = true
+	)
+
+	flush := func() {
+		switch class {
+		case classWord:
+			total += math.Ceil(length / f.CharsPerToken)
+		case classPunct:
+			total += math.Ceil(length / f.PunctPerToken)
+		case classNewline:
+			total++
+		case classSpace:
+			// A single space is merged into the next word; only indentation costs tokens
+			if atStart && length > 1 {
+				total += math.Ceil(length / f.IndentPerToken)
+			}
+		}
+	}
+
+	for _, r := range text {
+		next := classify(r)
+		if next == classIdeograph {
+			flush()
+			total++
+			class, length = classIdeograph, 0
+			continue
+		}
+		if next != class {
+			flush()
+			atStart = class == classNewline || class == classNone
+			class, length = next, 0
+		}
+		// Characters outside ASCII take several bytes, so the byte-level vocabularies split them more
+		if r > unicode.MaxASCII && next == classWord {
+			length += 2
+		} else {
+			length++
+		}
+	}
+	flush()
+
+	return int(total)
+}
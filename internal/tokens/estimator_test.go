@@ -0,0 +1,69 @@
+package tokens
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFamilyFor(t *testing.T) {
+	assert.Equal(t, "openai", FamilyFor("openai").Name)
+	assert.Equal(t, "anthropic", FamilyFor("anthropic").Name)
+	assert.Equal(t, "gemini", FamilyFor("gemini").Name)
+	assert.Equal(t, "local", FamilyFor("local").Name)
+	assert.Equal(t, "openai", FamilyFor("unknown").Name, "unknown providers use the OpenAI tokenizer")
+}
+
+func TestEstimate(t *testing.T) {
+	t.Run("empty text has no tokens", func(t *testing.T) {
+		assert.Zero(t, Estimate("openai", ""))
+	})
+
+	t.Run("short words and punctuation cost one token each", func(t *testing.T) {
+		// Arrange
+		text := "The quick brown fox jumps over the lazy dog."
+
+		// Act
+		tokens := Estimate("openai", text)
+
+		// Assert
+		assert.Equal(t, 10, tokens)
+	})
+
+	t.Run("long words are split into pieces", func(t *testing.T) {
+		assert.Equal(t, 4, Estimate("openai", "internationalizations"))
+		assert.Equal(t, 5, Estimate("anthropic", "internationalizations"))
+	})
+
+	t.Run("ideographs cost one token per character", func(t *testing.T) {
+		assert.Equal(t, 7, Estimate("gemini", "こんにちは世界"))
+	})
+
+	t.Run("indentation costs tokens but single spaces do not", func(t *testing.T) {
+		// Arrange
+		flat := "if ok {\nreturn nil\n}"
+		indented := "if ok {\n\t\t\t\treturn nil\n}"
+
+		// Act & Assert
+		assert.Greater(t, Estimate("anthropic", indented), Estimate("anthropic", flat))
+		assert.Equal(t, Estimate("anthropic", "a b"), Estimate("anthropic", "ab ab")-Estimate("anthropic", "ab")+1)
+	})
+
+	t.Run("prose stays close to four characters per token", func(t *testing.T) {
+		// Arrange
+		text := strings.Repeat("Release notes and pull request summaries are generated from the commits of the branch. ", 20)
+
+		// Act
+		tokens := Estimate("openai", text)
+
+		// Assert
+		ratio := float64(len(text)) / float64(tokens)
+		assert.InDelta(t, 4.5, ratio, 1, "got %d tokens for %d characters", tokens, len(text))
+	})
+
+	t.Run("families with smaller vocabularies need more tokens", func(t *testing.T) {
+		text := "func (w *CostAwareWrapper) WrapGenerate(ctx context.Context, command string) error"
+		assert.Greater(t, Estimate("anthropic", text), Estimate("openai", text))
+	})
+}
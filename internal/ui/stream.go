@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"unicode"
+)
+
+// streamPreviewWidth is how many of the latest characters of a streamed response are shown.
+const streamPreviewWidth = 48
+
+// IsTerminal reports whether f is an interactive terminal rather than a pipe or a file.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// StreamPreview shows a response while the AI generates it.
+// With an active spinner the latest text is appended to its message; otherwise it is printed as it arrives.
+type StreamPreview struct {
+	mu      sync.Mutex
+	spinner *SmartSpinner
+	suffix  string
+	tail    []rune
+	printed bool
+}
+
+// NewStreamPreview creates a preview for the responses of the current command.
+func NewStreamPreview() *StreamPreview {
+	return &StreamPreview{}
+}
+
+// Chunk adds the next piece of the response to the preview.
+func (p *StreamPreview) Chunk(text string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.spinner == nil && !p.printed {
+		if active := currentSpinner(); active != nil {
+			p.spinner = active
+			p.spinner.spinner.Lock()
+			p.suffix = p.spinner.spinner.Suffix
+			p.spinner.spinner.Unlock()
+		}
+	}
+
+	if p.spinner == nil {
+		p.printed = true
+		_, _ = Dim.Print(text)
+		return
+	}
+
+	// Newlines and indentation are collapsed so the preview stays on the spinner line.
+	for _, r := range text {
+		if unicode.IsSpace(r) {
+			if len(p.tail) == 0 || p.tail[len(p.tail)-1] == ' ' {
+				continue
+			}
+			r = ' '
+		}
+		p.tail = append(p.tail, r)
+	}
+	if len(p.tail) > streamPreviewWidth {
+		p.tail = p.tail[len(p.tail)-streamPreviewWidth:]
+	}
+
+	p.spinner.spinner.Lock()
+	p.spinner.spinner.Suffix = p.suffix + " " + Dim.Sprint("… "+string(p.tail))
+	p.spinner.spinner.Unlock()
+}
+
+// Done restores the spinner message, or ends the printed line, once the response is complete.
+func (p *StreamPreview) Done() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.spinner != nil {
+		p.spinner.spinner.Lock()
+		p.spinner.spinner.Suffix = p.suffix
+		p.spinner.spinner.Unlock()
+	}
+	if p.printed {
+		fmt.Println()
+	}
+	p.spinner = nil
+	p.suffix = ""
+	p.tail = nil
+	p.printed = false
+}
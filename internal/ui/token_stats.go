@@ -19,6 +19,15 @@ func PrintTokenUsage(usage *models.TokenUsage, t *i18n.Translations) {
 	fmt.Printf("%s %d | ", t.GetMessage("ui.input", 0, nil), usage.InputTokens)
 	fmt.Printf("%s %d | ", t.GetMessage("ui.output", 0, nil), usage.OutputTokens)
 	fmt.Printf("%s %d\n", t.GetMessage("ui.total", 0, nil), usage.TotalTokens)
+	if usage.Provider != "" {
+		fmt.Printf("🤖 %s: %s (%s)\n", t.GetMessage("ui.provider", 0, nil), usage.Provider, usage.Model)
+	}
+	if usage.Attempts > 1 {
+		_, _ = yellow.Printf("↻ %s\n", t.GetMessage("ui.fallback_attempt", 0, struct{ Attempts int }{usage.Attempts}))
+	}
+	if usage.Repairs > 0 {
+		_, _ = yellow.Printf("🔧 %s\n", t.GetMessage("ui.schema_repair", 0, struct{ Repairs int }{usage.Repairs}))
+	}
 	if usage.CostUSD > 0 {
 		_, _ = yellow.Print("💰 ")
 		fmt.Printf("%s: ", t.GetMessage("ui.cost", 0, nil))
@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/briandowns/spinner"
@@ -33,8 +34,12 @@ var (
 	StatsEmoji   = Accent.Sprint("📊")
 )
 
-var activeSpinner *SmartSpinner
-var suspendedSpinner *SmartSpinner
+// spinnerMu guards activeSpinner and suspendedSpinner, which stream previews read while a response arrives
+var (
+	spinnerMu        sync.Mutex
+	activeSpinner    *SmartSpinner
+	suspendedSpinner *SmartSpinner
+)
 
 // SmartSpinner is a spinner with enhanced capabilities
 type SmartSpinner struct {
@@ -54,13 +59,17 @@ func NewSmartSpinner(initialMessage string) *SmartSpinner {
 
 // Start starts the spinner and registers it as the globally active spinner.
 func (s *SmartSpinner) Start() {
+	spinnerMu.Lock()
 	activeSpinner = s
+	spinnerMu.Unlock()
 	s.spinner.Start()
 }
 
 // Stop stops the spinner and clears the active spinner record.
 func (s *SmartSpinner) Stop() {
 	s.spinner.Stop()
+	spinnerMu.Lock()
+	defer spinnerMu.Unlock()
 	if activeSpinner == s {
 		activeSpinner = nil
 	}
@@ -69,16 +78,25 @@ func (s *SmartSpinner) Stop() {
 	}
 }
 
+// currentSpinner returns the spinner shown in the terminal, if any.
+func currentSpinner() *SmartSpinner {
+	spinnerMu.Lock()
+	defer spinnerMu.Unlock()
+	return activeSpinner
+}
+
 // StopActiveSpinner stops the currently active spinner in the terminal session.
 func StopActiveSpinner() {
-	if activeSpinner != nil {
-		activeSpinner.Stop()
+	if s := currentSpinner(); s != nil {
+		s.Stop()
 	}
 }
 
 // SuspendActiveSpinner temporarily stops the active spinner without deleting its reference,
 // allowing it to be resumed after user interaction.
 func SuspendActiveSpinner() {
+	spinnerMu.Lock()
+	defer spinnerMu.Unlock()
 	if activeSpinner != nil {
 		suspendedSpinner = activeSpinner
 		activeSpinner.spinner.Stop()
@@ -88,6 +106,8 @@ func SuspendActiveSpinner() {
 
 // ResumeSuspendedSpinner resumes the previously suspended spinner.
 func ResumeSuspendedSpinner() {
+	spinnerMu.Lock()
+	defer spinnerMu.Unlock()
 	if suspendedSpinner != nil {
 		activeSpinner = suspendedSpinner
 		activeSpinner.spinner.Start()
@@ -96,7 +116,9 @@ func ResumeSuspendedSpinner() {
 }
 
 func (s *SmartSpinner) UpdateMessage(msg string) {
+	s.spinner.Lock()
 	s.spinner.Suffix = " " + msg
+	s.spinner.Unlock()
 }
 
 func (s *SmartSpinner) Success(msg string) {
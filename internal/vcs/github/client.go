@@ -15,6 +15,7 @@ import (
 	"github.com/google/go-github/v80/github"
 	"github.com/thomas-vilte/matecommit/internal/builder"
 	domainErrors "github.com/thomas-vilte/matecommit/internal/errors"
+	"github.com/thomas-vilte/matecommit/internal/ignore"
 	"github.com/thomas-vilte/matecommit/internal/logger"
 	"github.com/thomas-vilte/matecommit/internal/models"
 	"github.com/thomas-vilte/matecommit/internal/regex"
@@ -90,6 +91,7 @@ type GitHubClient struct {
 	httpClient           *http.Client
 	mainPath             string
 	binaryBuilderFactory binaryBuilderFactory
+	ignore               *ignore.Matcher
 }
 
 var allowedLabels = map[string]struct {
@@ -166,6 +168,11 @@ func (ghc *GitHubClient) SetMainPath(path string) {
 	}
 }
 
+// SetIgnoreMatcher omits the content of the matching files from the PR diffs.
+func (ghc *GitHubClient) SetIgnoreMatcher(matcher *ignore.Matcher) {
+	ghc.ignore = matcher
+}
+
 func (ghc *GitHubClient) UpdatePR(ctx context.Context, prNumber int, summary models.PRSummary) error {
 	pr := &github.PullRequest{
 		Title: github.Ptr(summary.Title),
@@ -274,6 +281,13 @@ func (ghc *GitHubClient) GetPR(ctx context.Context, prNumber int) (models.PRData
 		}
 	}
 
+	diff, omitted := ghc.ignore.FilterDiff(diff)
+	if len(omitted) > 0 {
+		log.Debug("omitted ignored files from PR diff",
+			"pr_number", prNumber,
+			"files", omitted)
+	}
+
 	prData := models.PRData{
 		ID:          prNumber,
 		Title:       pr.GetTitle(),